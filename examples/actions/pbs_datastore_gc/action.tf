@@ -0,0 +1,26 @@
+# Terraform 1.14+: run garbage collection right after the datastore is
+# changed, e.g. to validate a new tuning.gc_atime_cutoff immediately
+action "pbs_datastore_gc" "backups" {
+  config {
+    store   = "backups"
+    timeout = "2h"
+  }
+}
+
+resource "pbs_datastore" "backups" {
+  name        = "backups"
+  path        = "/mnt/datastore/backups"
+  gc_schedule = "daily"
+
+  tuning = {
+    gc_atime_cutoff       = 1440
+    gc_atime_safety_check = true
+  }
+
+  lifecycle {
+    action_trigger {
+      events  = [after_update]
+      actions = [action.pbs_datastore_gc.backups]
+    }
+  }
+}
@@ -0,0 +1,13 @@
+# Terraform 1.14+: log what a retention policy would remove, without removing
+# anything, with
+#   terraform apply -invoke=action.pbs_datastore_prune.preview
+action "pbs_datastore_prune" "preview" {
+  config {
+    store       = "backups"
+    namespace   = "prod"
+    keep_last   = 3
+    keep_daily  = 7
+    keep_weekly = 4
+    dry_run     = true
+  }
+}
@@ -0,0 +1,10 @@
+# Terraform 1.14+: verify the production namespace on demand with
+#   terraform apply -invoke=action.pbs_datastore_verify.prod
+action "pbs_datastore_verify" "prod" {
+  config {
+    store           = "backups"
+    namespace       = "prod"
+    ignore_verified = true
+    outdated_after  = 30
+  }
+}
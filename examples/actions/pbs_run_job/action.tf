@@ -0,0 +1,28 @@
+# Terraform 1.14+: run the verify job after every change to it
+locals {
+  verify_job_id = "weekly-verify"
+}
+
+action "pbs_run_job" "verify_now" {
+  config {
+    job_type = "verify"
+    id       = local.verify_job_id
+    timeout  = "30m"
+  }
+}
+
+resource "pbs_verify_job" "weekly" {
+  id       = local.verify_job_id
+  store    = "backups"
+  schedule = "sat 03:00"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.pbs_run_job.verify_now]
+    }
+  }
+}
+
+# The action can also be invoked on demand:
+#   terraform apply -invoke=action.pbs_run_job.verify_now
@@ -0,0 +1,10 @@
+data "pbs_metrics_status" "current" {}
+
+output "pbs_cpu" {
+  value = data.pbs_metrics_status.current.host["cpu_current"]
+}
+
+# Used space per datastore, as sent to the metrics servers
+output "datastore_used_bytes" {
+  value = { for name, m in data.pbs_metrics_status.current.datastores : name => m["used"] }
+}
@@ -30,15 +30,24 @@ locals {
 
 # Example: filter sync job to specific groups
 resource "pbs_sync_job" "group_filtered_sync" {
-  id           = "sync-specific-vms"
-  store        = "local-backups"
-  remote       = pbs_remote.backup_server.name
-  remote_store = "offsite-backups"
-  remote_ns    = "production"
-  
-  # Only sync specific backup types
-  group_filter = "vm"
-  schedule     = "04:00"
+  id               = "sync-specific-vms"
+  store            = "local-backups"
+  remote           = pbs_remote.backup_server.name
+  remote_store     = "offsite-backups"
+  remote_namespace = "production"
+  schedule         = "04:00"
+
+  # Only sync VMs, except the scratch VM
+  group_filter {
+    kind  = "type"
+    value = "vm"
+  }
+
+  group_filter {
+    action = "exclude"
+    kind   = "group"
+    value  = "vm/999"
+  }
   
   depends_on = [data.pbs_remote_groups.production_groups]
 }
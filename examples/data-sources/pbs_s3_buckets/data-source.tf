@@ -0,0 +1,24 @@
+# List buckets reachable through an S3 endpoint
+data "pbs_s3_buckets" "backups" {
+  endpoint_id = pbs_s3_endpoint.aws.id
+}
+
+output "available_buckets" {
+  description = "Buckets accessible with the endpoint credentials"
+  value       = data.pbs_s3_buckets.backups.names
+}
+
+# Example: fail the plan early if the bucket is missing
+resource "pbs_datastore" "s3_backups" {
+  name      = "s3-backups"
+  path      = "/datastore/s3-backups"
+  s3_client = pbs_s3_endpoint.aws.id
+  s3_bucket = "pbs-backups"
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.pbs_s3_buckets.backups.names, "pbs-backups")
+      error_message = "Bucket pbs-backups is not accessible through the S3 endpoint."
+    }
+  }
+}
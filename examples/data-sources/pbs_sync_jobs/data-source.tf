@@ -0,0 +1,34 @@
+# List all sync jobs with their last run status
+data "pbs_sync_jobs" "all" {}
+
+locals {
+  # Jobs whose last run failed; runs with warnings and jobs that have not
+  # run yet are not failures
+  failed_sync_jobs = [
+    for job in data.pbs_sync_jobs.all.jobs : job.id if job.last_run_failed
+  ]
+}
+
+# Report failed sync jobs on every plan and apply. Check assertions are
+# reported as warnings and do not block the run.
+check "sync_jobs_healthy" {
+  assert {
+    condition     = length(local.failed_sync_jobs) == 0
+    error_message = "Sync jobs failed on their last run: ${join(", ", local.failed_sync_jobs)}"
+  }
+}
+
+# Fail the plan outright, e.g. in a CI pipeline, when any sync job's last run errored
+data "pbs_sync_jobs" "ci_gate" {
+  lifecycle {
+    postcondition {
+      condition     = !anytrue([for job in self.jobs : job.last_run_failed])
+      error_message = "At least one sync job failed on its last run."
+    }
+  }
+}
+
+output "next_sync_runs" {
+  description = "Next scheduled run of each sync job"
+  value       = { for job in data.pbs_sync_jobs.all.jobs : job.id => job.next_run }
+}
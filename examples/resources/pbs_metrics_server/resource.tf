@@ -0,0 +1,22 @@
+# InfluxDB v2 over HTTP. With verify_on_apply, the apply fails if InfluxDB is
+# unreachable, rejects the token, or the token may not write to the bucket.
+resource "pbs_metrics_server" "influxdb" {
+  name         = "influxdb"
+  type         = "influxdb-http"
+  url          = "https://influxdb.example.com:8086"
+  organization = "ops"
+  bucket       = "pbs"
+  token        = var.influxdb_token
+
+  verify_on_apply = true
+}
+
+# InfluxDB over UDP. Only name resolution can be checked for UDP.
+resource "pbs_metrics_server" "influxdb_udp" {
+  name   = "influxdb-udp"
+  type   = "influxdb-udp"
+  server = "influxdb.example.com"
+  port   = 8089
+
+  verify_on_apply = true
+}
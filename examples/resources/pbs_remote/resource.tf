@@ -19,6 +19,10 @@ resource "pbs_remote" "offsite_pbs" {
   host     = "192.168.1.100"
   auth_id  = "admin@pam"
   password = var.offsite_password  # Recommended: use variable for sensitive data
+
+  # Optional: log in to the remote on refresh and resend the password if it was
+  # changed outside of Terraform
+  verify_secret = true
   
   comment = "Offsite PBS for disaster recovery"
 }
@@ -30,3 +34,18 @@ resource "pbs_remote" "local_pbs" {
   auth_id  = "sync@pbs!sync-token"
   password = var.sync_password
 }
+
+# Example with a write-only password (Terraform 1.11+), never stored in state.
+# Bump password_wo_version to push a rotated secret.
+ephemeral "vault_kv_secret_v2" "sync" {
+  mount = "secret"
+  name  = "pbs/sync-token"
+}
+
+resource "pbs_remote" "vault_pbs" {
+  name                = "vault-backup"
+  host                = "pbs.example.com"
+  auth_id             = "sync@pbs!sync-token"
+  password_wo         = ephemeral.vault_kv_secret_v2.sync.data["token"]
+  password_wo_version = 1
+}
@@ -11,4 +11,7 @@ resource "pbs_s3_endpoint" "example" {
   fingerprint = ""  # SSL certificate fingerprint if needed
   port        = 443
   path_style  = false
+
+  # Check credentials, bucket access and conditional PUT support after apply
+  verify_bucket = "pbs-backups"
 }
\ No newline at end of file
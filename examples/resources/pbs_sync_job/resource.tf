@@ -0,0 +1,17 @@
+# Pull sync job that runs once right after it is created and again whenever
+# the remote side of the job changes, in addition to its schedule.
+resource "pbs_sync_job" "offsite" {
+  id               = "offsite-pull"
+  store            = "backups"
+  remote           = pbs_remote.offsite_pbs.name
+  remote_store     = "datastore1"
+  remote_namespace = "prod"
+  schedule         = "daily"
+
+  run_on_apply = sha1(join("/", [pbs_remote.offsite_pbs.name, "datastore1", "prod"]))
+
+  # Optional: block the apply until the sync finished; a failed sync fails the
+  # apply with the tail of the task log
+  wait_for_run = true
+  run_timeout  = "2h"
+}
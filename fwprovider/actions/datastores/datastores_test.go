@@ -0,0 +1,45 @@
+package datastores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastoreActionSchemas(t *testing.T) {
+	tests := map[string]struct {
+		action   action.Action
+		typeName string
+		extra    []string
+	}{
+		"gc":     {action: NewGCAction(), typeName: "pbs_datastore_gc"},
+		"verify": {action: NewVerifyAction(), typeName: "pbs_datastore_verify", extra: []string{"ignore_verified", "outdated_after", "namespace", "max_depth"}},
+		"prune":  {action: NewPruneAction(), typeName: "pbs_datastore_prune", extra: []string{"namespace", "max_depth", "keep_last", "keep_yearly", "dry_run"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			metadata := &action.MetadataResponse{}
+			tc.action.Metadata(ctx, action.MetadataRequest{ProviderTypeName: "pbs"}, metadata)
+			require.Equal(t, tc.typeName, metadata.TypeName)
+
+			resp := &action.SchemaResponse{}
+			tc.action.Schema(ctx, action.SchemaRequest{}, resp)
+			require.False(t, resp.Diagnostics.HasError())
+
+			store, ok := resp.Schema.Attributes["store"]
+			require.True(t, ok, "store attribute should exist")
+			require.True(t, store.IsRequired(), "store should be required")
+
+			for _, attrName := range append([]string{"wait", "timeout"}, tc.extra...) {
+				attr, ok := resp.Schema.Attributes[attrName]
+				require.True(t, ok, "%s attribute should exist", attrName)
+				require.True(t, attr.IsOptional(), "%s should be optional", attrName)
+			}
+		})
+	}
+}
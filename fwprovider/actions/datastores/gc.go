@@ -0,0 +1,77 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package datastores
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &gcAction{}
+	_ action.ActionWithConfigure = &gcAction{}
+)
+
+// NewGCAction is a helper function to simplify the provider implementation.
+func NewGCAction() action.Action {
+	return &gcAction{}
+}
+
+// gcAction is the action implementation.
+type gcAction struct {
+	client *pbs.Client
+}
+
+// gcActionModel maps the action schema data.
+type gcActionModel struct {
+	Store   types.String `tfsdk:"store"`
+	Wait    types.Bool   `tfsdk:"wait"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+// Metadata returns the action type name.
+func (a *gcAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datastore_gc"
+}
+
+// Schema defines the schema for the action.
+func (a *gcAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs garbage collection on a datastore.",
+		MarkdownDescription: `Runs garbage collection on a datastore.
+
+Requires Terraform 1.14 or later. Trigger it from the ` + "`lifecycle.action_trigger`" + ` of ` + "`pbs_datastore`" + `
+to validate GC tuning such as ` + "`tuning.gc_atime_cutoff`" + ` right after it changes, or invoke it on demand with
+` + "`terraform apply -invoke`" + `. Scheduled collection is configured with the ` + "`gc_schedule`" + ` of the datastore.`,
+		Attributes: taskAttributes("garbage collection", nil),
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *gcAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = configureClient(req, resp)
+}
+
+// Invoke starts garbage collection and optionally waits for it to finish.
+func (a *gcAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data gcActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	store := data.Store.ValueString()
+	startAndWait(ctx, a.client, resp, "garbage collection", store, data.Wait, data.Timeout, func() (string, error) {
+		return a.client.Datastores.StartGarbageCollection(ctx, store)
+	})
+}
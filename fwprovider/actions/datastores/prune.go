@@ -0,0 +1,139 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package datastores
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &pruneAction{}
+	_ action.ActionWithConfigure = &pruneAction{}
+)
+
+// NewPruneAction is a helper function to simplify the provider implementation.
+func NewPruneAction() action.Action {
+	return &pruneAction{}
+}
+
+// pruneAction is the action implementation.
+type pruneAction struct {
+	client *pbs.Client
+}
+
+// pruneActionModel maps the action schema data.
+type pruneActionModel struct {
+	Store       types.String `tfsdk:"store"`
+	Wait        types.Bool   `tfsdk:"wait"`
+	Timeout     types.String `tfsdk:"timeout"`
+	Namespace   types.String `tfsdk:"namespace"`
+	MaxDepth    types.Int64  `tfsdk:"max_depth"`
+	KeepLast    types.Int64  `tfsdk:"keep_last"`
+	KeepHourly  types.Int64  `tfsdk:"keep_hourly"`
+	KeepDaily   types.Int64  `tfsdk:"keep_daily"`
+	KeepWeekly  types.Int64  `tfsdk:"keep_weekly"`
+	KeepMonthly types.Int64  `tfsdk:"keep_monthly"`
+	KeepYearly  types.Int64  `tfsdk:"keep_yearly"`
+	DryRun      types.Bool   `tfsdk:"dry_run"`
+}
+
+// Metadata returns the action type name.
+func (a *pruneAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datastore_prune"
+}
+
+// Schema defines the schema for the action.
+func (a *pruneAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	keepAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description:         description,
+			MarkdownDescription: description,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Prunes the backup groups of a datastore namespace.",
+		MarkdownDescription: `Prunes the backup groups of a datastore namespace with the given retention.
+
+Requires Terraform 1.14 or later. Without any ` + "`keep_*`" + ` option every snapshot is kept. Scheduled pruning
+is configured with ` + "`pbs_prune_job`" + `; use the ` + "`pbs_prune_simulation`" + ` data source to preview
+a retention policy and ` + "`dry_run`" + ` to have PBS log its decisions without removing anything.`,
+		Attributes: taskAttributes("prune", map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Description:         "Namespace to prune.",
+				MarkdownDescription: "Namespace to prune. Defaults to the root namespace.",
+				Optional:            true,
+			},
+			"max_depth": schema.Int64Attribute{
+				Description:         "How many levels of namespaces below namespace to prune.",
+				MarkdownDescription: "How many levels of namespaces below `namespace` to prune. Defaults to all.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, datastores.MaxNamespaceDepth),
+				},
+			},
+			"keep_last":    keepAttribute("Number of most recent snapshots to keep."),
+			"keep_hourly":  keepAttribute("Number of hourly snapshots to keep."),
+			"keep_daily":   keepAttribute("Number of daily snapshots to keep."),
+			"keep_weekly":  keepAttribute("Number of weekly snapshots to keep."),
+			"keep_monthly": keepAttribute("Number of monthly snapshots to keep."),
+			"keep_yearly":  keepAttribute("Number of yearly snapshots to keep."),
+			"dry_run": schema.BoolAttribute{
+				Description:         "Only log what would be pruned.",
+				MarkdownDescription: "Only log what would be pruned, without removing snapshots. Defaults to `false`.",
+				Optional:            true,
+			},
+		}),
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *pruneAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = configureClient(req, resp)
+}
+
+// Invoke starts the prune and optionally waits for it to finish.
+func (a *pruneAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data pruneActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := datastores.DatastorePruneOptions{
+		PruneOptions: datastores.PruneOptions{
+			KeepLast:    intPointer(data.KeepLast),
+			KeepHourly:  intPointer(data.KeepHourly),
+			KeepDaily:   intPointer(data.KeepDaily),
+			KeepWeekly:  intPointer(data.KeepWeekly),
+			KeepMonthly: intPointer(data.KeepMonthly),
+			KeepYearly:  intPointer(data.KeepYearly),
+		},
+		Namespace: data.Namespace.ValueString(),
+		MaxDepth:  intPointer(data.MaxDepth),
+		DryRun:    data.DryRun.ValueBool(),
+	}
+
+	store := data.Store.ValueString()
+	startAndWait(ctx, a.client, resp, "prune", store, data.Wait, data.Timeout, func() (string, error) {
+		return a.client.Datastores.StartPrune(ctx, store, opts)
+	})
+}
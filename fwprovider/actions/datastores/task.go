@@ -0,0 +1,121 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package datastores implements Terraform actions for PBS datastore maintenance
+package datastores
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+// defaultTimeout is how long the actions wait for their task unless timeout is set.
+const defaultTimeout = time.Hour
+
+// taskAttributes returns the schema attributes shared by the datastore
+// actions, merged with the action-specific attributes.
+func taskAttributes(description string, attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	shared := map[string]schema.Attribute{
+		"store": schema.StringAttribute{
+			Description:         "Name of the datastore.",
+			MarkdownDescription: "Name of the datastore.",
+			Required:            true,
+		},
+		"wait": schema.BoolAttribute{
+			Description:         fmt.Sprintf("Wait for the %s to finish and fail if it fails.", description),
+			MarkdownDescription: fmt.Sprintf("Wait for the %s to finish and fail, with the tail of the task log, if it fails. Defaults to `true`.", description),
+			Optional:            true,
+		},
+		"timeout": schema.StringAttribute{
+			Description:         fmt.Sprintf("How long to wait for the %s.", description),
+			MarkdownDescription: fmt.Sprintf("How long to wait for the %s, as a duration such as `30m`. Defaults to `1h`.", description),
+			Optional:            true,
+			Validators: []validator.String{
+				validators.Duration(),
+			},
+		},
+	}
+	maps.Copy(shared, attributes)
+	return shared
+}
+
+// configureClient returns the PBS client from the provider data of an action.
+func configureClient(req action.ConfigureRequest, resp *action.ConfigureResponse) *pbs.Client {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	cfg, ok := req.ProviderData.(*config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *config.Action, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+
+	return cfg.Client
+}
+
+// startAndWait starts a datastore task, reports its UPID and, unless wait is
+// false, waits for it to finish.
+func startAndWait(ctx context.Context, client *pbs.Client, resp *action.InvokeResponse, description, store string, wait types.Bool, timeout types.String, start func() (string, error)) {
+	d := defaultTimeout
+	if !timeout.IsNull() {
+		parsed, err := time.ParseDuration(timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Duration", fmt.Sprintf("Could not parse timeout: %s", err.Error()))
+			return
+		}
+		d = parsed
+	}
+
+	upid, err := start()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error starting "+description,
+			fmt.Sprintf("Could not start %s of datastore %s: %s", description, store, err.Error()),
+		)
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Started %s of datastore %s (%s)", description, store, upid),
+	})
+
+	if !wait.IsNull() && !wait.ValueBool() {
+		return
+	}
+
+	if err := client.Datastores.WaitForTask(ctx, upid, d); err != nil {
+		resp.Diagnostics.AddError(
+			"Datastore "+description+" failed",
+			fmt.Sprintf("The %s of datastore %s failed: %s", description, store, err.Error()),
+		)
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Finished %s of datastore %s", description, store),
+	})
+}
+
+func intPointer(value types.Int64) *int {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	v := int(value.ValueInt64())
+	return &v
+}
@@ -0,0 +1,117 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package datastores
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &verifyAction{}
+	_ action.ActionWithConfigure = &verifyAction{}
+)
+
+// NewVerifyAction is a helper function to simplify the provider implementation.
+func NewVerifyAction() action.Action {
+	return &verifyAction{}
+}
+
+// verifyAction is the action implementation.
+type verifyAction struct {
+	client *pbs.Client
+}
+
+// verifyActionModel maps the action schema data.
+type verifyActionModel struct {
+	Store          types.String `tfsdk:"store"`
+	Wait           types.Bool   `tfsdk:"wait"`
+	Timeout        types.String `tfsdk:"timeout"`
+	IgnoreVerified types.Bool   `tfsdk:"ignore_verified"`
+	OutdatedAfter  types.Int64  `tfsdk:"outdated_after"`
+	Namespace      types.String `tfsdk:"namespace"`
+	MaxDepth       types.Int64  `tfsdk:"max_depth"`
+}
+
+// Metadata returns the action type name.
+func (a *verifyAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datastore_verify"
+}
+
+// Schema defines the schema for the action.
+func (a *verifyAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Verifies the backups of a datastore.",
+		MarkdownDescription: `Verifies the backups of a datastore, optionally limited to a namespace.
+
+Requires Terraform 1.14 or later. Scheduled verification is configured with ` + "`pbs_verify_job`" + `; use the
+` + "`pbs_run_job`" + ` action to run such a job on demand.`,
+		Attributes: taskAttributes("verification", map[string]schema.Attribute{
+			"ignore_verified": schema.BoolAttribute{
+				Description:         "Skip snapshots that were verified recently.",
+				MarkdownDescription: "Skip snapshots that were verified within `outdated_after` days.",
+				Optional:            true,
+			},
+			"outdated_after": schema.Int64Attribute{
+				Description:         "Days after which a verified snapshot is verified again.",
+				MarkdownDescription: "Days after which a verified snapshot is verified again. Only applies with `ignore_verified`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description:         "Namespace to verify.",
+				MarkdownDescription: "Namespace to verify. Defaults to the root namespace.",
+				Optional:            true,
+			},
+			"max_depth": schema.Int64Attribute{
+				Description:         "How many levels of namespaces below namespace to verify.",
+				MarkdownDescription: "How many levels of namespaces below `namespace` to verify. Defaults to all.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, datastores.MaxNamespaceDepth),
+				},
+			},
+		}),
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *verifyAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = configureClient(req, resp)
+}
+
+// Invoke starts verification and optionally waits for it to finish.
+func (a *verifyAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data verifyActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := datastores.VerifyOptions{
+		IgnoreVerified: data.IgnoreVerified.ValueBoolPointer(),
+		OutdatedAfter:  intPointer(data.OutdatedAfter),
+		Namespace:      data.Namespace.ValueString(),
+		MaxDepth:       intPointer(data.MaxDepth),
+	}
+
+	store := data.Store.ValueString()
+	startAndWait(ctx, a.client, resp, "verification", store, data.Wait, data.Timeout, func() (string, error) {
+		return a.client.Datastores.StartVerify(ctx, store, opts)
+	})
+}
@@ -0,0 +1,164 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package jobs implements Terraform actions for PBS jobs
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+// defaultTimeout is how long the action waits for a run unless timeout is set.
+const defaultTimeout = time.Hour
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &runJobAction{}
+	_ action.ActionWithConfigure = &runJobAction{}
+)
+
+// NewRunJobAction is a helper function to simplify the provider implementation.
+func NewRunJobAction() action.Action {
+	return &runJobAction{}
+}
+
+// runJobAction is the action implementation.
+type runJobAction struct {
+	client *pbs.Client
+}
+
+// runJobActionModel maps the action schema data.
+type runJobActionModel struct {
+	JobType types.String `tfsdk:"job_type"`
+	ID      types.String `tfsdk:"id"`
+	Wait    types.Bool   `tfsdk:"wait"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+// Metadata returns the action type name.
+func (a *runJobAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_run_job"
+}
+
+// Schema defines the schema for the action.
+func (a *runJobAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a prune, sync or verify job immediately, independent of its schedule.",
+		MarkdownDescription: `Runs a prune, sync or verify job immediately, independent of its schedule.
+
+Requires Terraform 1.14 or later. Invoke it from the ` + "`action_trigger`" + ` of a job resource's ` + "`lifecycle`" + `
+block to run the job after it is created or updated, or on demand with ` + "`terraform apply -invoke`" + `.
+On older Terraform versions use the ` + "`run_on_apply`" + ` attribute of the job resources instead.`,
+		Attributes: map[string]schema.Attribute{
+			"job_type": schema.StringAttribute{
+				Description:         "Type of the job: prune, sync or verify.",
+				MarkdownDescription: "Type of the job: `prune`, `sync` or `verify`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(jobs.JobTypePrune, jobs.JobTypeSync, jobs.JobTypeVerify),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the job to run.",
+				MarkdownDescription: "ID of the job to run.",
+				Required:            true,
+			},
+			"wait": schema.BoolAttribute{
+				Description:         "Wait for the run to finish and fail if it fails.",
+				MarkdownDescription: "Wait for the run to finish and fail, with the tail of the task log, if it fails. Defaults to `true`.",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				Description:         "How long to wait for the run.",
+				MarkdownDescription: "How long to wait for the run, as a duration such as `30m`. Defaults to `1h`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the action.
+func (a *runJobAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Action)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *config.Action, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = cfg.Client
+}
+
+// Invoke runs the job and optionally waits for it to finish.
+func (a *runJobAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data runJobActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobType := data.JobType.ValueString()
+	id := data.ID.ValueString()
+
+	timeout := defaultTimeout
+	if !data.Timeout.IsNull() {
+		d, err := time.ParseDuration(data.Timeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Duration", fmt.Sprintf("Could not parse timeout: %s", err.Error()))
+			return
+		}
+		timeout = d
+	}
+
+	upid, err := a.client.Jobs.RunJob(ctx, jobType, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running "+jobType+" job",
+			fmt.Sprintf("Could not run %s job %s: %s", jobType, id, err.Error()),
+		)
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Started %s job %s (%s)", jobType, id, upid),
+	})
+
+	if !data.Wait.IsNull() && !data.Wait.ValueBool() {
+		return
+	}
+
+	if err := a.client.Jobs.WaitForJobRun(ctx, upid, timeout); err != nil {
+		resp.Diagnostics.AddError(
+			"Job run failed",
+			fmt.Sprintf("Run of %s job %s failed: %s", jobType, id, err.Error()),
+		)
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("%s job %s finished", jobType, id),
+	})
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunJobActionSchema(t *testing.T) {
+	a := &runJobAction{}
+	resp := &action.SchemaResponse{}
+
+	a.Schema(context.Background(), action.SchemaRequest{}, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+
+	for _, name := range []string{"job_type", "id"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+		require.True(t, attr.IsRequired(), "%s should be required", name)
+	}
+	for _, name := range []string{"wait", "timeout"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+		require.True(t, attr.IsOptional(), "%s should be optional", name)
+	}
+}
+
+func TestRunJobActionMetadata(t *testing.T) {
+	a := &runJobAction{}
+	resp := &action.MetadataResponse{}
+
+	a.Metadata(context.Background(), action.MetadataRequest{ProviderTypeName: "pbs"}, resp)
+
+	require.Equal(t, "pbs_run_job", resp.TypeName)
+}
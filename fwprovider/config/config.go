@@ -18,3 +18,8 @@ type DataSource struct {
 type Resource struct {
 	Client *pbs.Client
 }
+
+// Action is the global configuration for all actions.
+type Action struct {
+	Client *pbs.Client
+}
@@ -0,0 +1,222 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package apt provides Terraform data sources for PBS package update status
+package apt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &aptUpdatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &aptUpdatesDataSource{}
+)
+
+// NewAPTUpdatesDataSource is a helper function to simplify the provider implementation.
+func NewAPTUpdatesDataSource() datasource.DataSource {
+	return &aptUpdatesDataSource{}
+}
+
+// aptUpdatesDataSource is the data source implementation.
+type aptUpdatesDataSource struct {
+	client *pbs.Client
+}
+
+// aptUpdatesDataSourceModel maps the data source schema data.
+type aptUpdatesDataSourceModel struct {
+	Node    types.String     `tfsdk:"node"`
+	Refresh types.Bool       `tfsdk:"refresh"`
+	Count   types.Int64      `tfsdk:"count"`
+	Updates []aptUpdateModel `tfsdk:"updates"`
+}
+
+// aptUpdateModel represents a single pending package update
+type aptUpdateModel struct {
+	Package     types.String `tfsdk:"package"`
+	Title       types.String `tfsdk:"title"`
+	Arch        types.String `tfsdk:"arch"`
+	Version     types.String `tfsdk:"version"`
+	OldVersion  types.String `tfsdk:"old_version"`
+	Origin      types.String `tfsdk:"origin"`
+	Priority    types.String `tfsdk:"priority"`
+	Section     types.String `tfsdk:"section"`
+	Description types.String `tfsdk:"description"`
+}
+
+// Metadata returns the data source type name.
+func (d *aptUpdatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apt_updates"
+}
+
+// Schema defines the schema for the data source.
+func (d *aptUpdatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Lists pending package updates on a Proxmox Backup Server node.",
+		MarkdownDescription: "Lists pending package updates on a Proxmox Backup Server node, as reported by the node's package cache.",
+
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node to query. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node to query. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"refresh": schema.BoolAttribute{
+				Description:         "Refresh the package database before listing updates.",
+				MarkdownDescription: "Run `apt update` on the node and wait for it before listing updates. Defaults to `false`, which reports the cached state.",
+				Optional:            true,
+			},
+			"count": schema.Int64Attribute{
+				Description:         "The number of pending updates.",
+				MarkdownDescription: "The number of pending updates.",
+				Computed:            true,
+			},
+			"updates": schema.ListNestedAttribute{
+				Description:         "List of pending package updates.",
+				MarkdownDescription: "List of pending package updates.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"package": schema.StringAttribute{
+							Description:         "The package name.",
+							MarkdownDescription: "The package name.",
+							Computed:            true,
+						},
+						"title": schema.StringAttribute{
+							Description:         "The package title.",
+							MarkdownDescription: "The package title.",
+							Computed:            true,
+						},
+						"arch": schema.StringAttribute{
+							Description:         "The package architecture.",
+							MarkdownDescription: "The package architecture.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							Description:         "The available version.",
+							MarkdownDescription: "The available version.",
+							Computed:            true,
+						},
+						"old_version": schema.StringAttribute{
+							Description:         "The installed version.",
+							MarkdownDescription: "The installed version.",
+							Computed:            true,
+						},
+						"origin": schema.StringAttribute{
+							Description:         "The repository origin of the update.",
+							MarkdownDescription: "The repository origin of the update (e.g., `Proxmox`, `Debian`).",
+							Computed:            true,
+						},
+						"priority": schema.StringAttribute{
+							Description:         "The package priority.",
+							MarkdownDescription: "The package priority.",
+							Computed:            true,
+						},
+						"section": schema.StringAttribute{
+							Description:         "The package section.",
+							MarkdownDescription: "The package section.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							Description:         "The package description.",
+							MarkdownDescription: "The package description.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *aptUpdatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *aptUpdatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state aptUpdatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := d.client.Nodes.ResolveNode(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	state.Node = types.StringValue(node)
+
+	if state.Refresh.ValueBool() {
+		if err := d.client.APT.RefreshUpdates(ctx, node); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Refreshing Package Database",
+				fmt.Sprintf("Could not refresh the package database on node %s: %s", node, err.Error()),
+			)
+			return
+		}
+	}
+
+	updates, err := d.client.APT.ListUpdates(ctx, node)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading APT Updates",
+			fmt.Sprintf("Could not list pending updates on node %s: %s", node, err.Error()),
+		)
+		return
+	}
+
+	state.Updates = make([]aptUpdateModel, 0, len(updates))
+	for _, u := range updates {
+		state.Updates = append(state.Updates, aptUpdateModel{
+			Package:     types.StringValue(u.Package),
+			Title:       stringValueOrNull(u.Title),
+			Arch:        stringValueOrNull(u.Arch),
+			Version:     types.StringValue(u.Version),
+			OldVersion:  stringValueOrNull(u.OldVersion),
+			Origin:      stringValueOrNull(u.Origin),
+			Priority:    stringValueOrNull(u.Priority),
+			Section:     stringValueOrNull(u.Section),
+			Description: stringValueOrNull(u.Description),
+		})
+	}
+	state.Count = types.Int64Value(int64(len(state.Updates)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,34 @@
+package apt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPTUpdatesDataSourceSchema(t *testing.T) {
+	ds := &aptUpdatesDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.Schema.Attributes)
+
+	nodeAttr, ok := resp.Schema.Attributes["node"]
+	require.True(t, ok, "node attribute should exist")
+	require.True(t, nodeAttr.IsOptional(), "node should be optional")
+	require.True(t, nodeAttr.IsComputed(), "node should be computed from the default node")
+
+	refreshAttr, ok := resp.Schema.Attributes["refresh"]
+	require.True(t, ok, "refresh attribute should exist")
+	require.True(t, refreshAttr.IsOptional(), "refresh should be optional")
+
+	// Verify updates attribute exists and is computed
+	updatesAttr, ok := resp.Schema.Attributes["updates"]
+	require.True(t, ok, "updates attribute should exist")
+	require.True(t, updatesAttr.IsComputed(), "updates should be computed")
+}
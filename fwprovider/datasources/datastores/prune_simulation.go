@@ -0,0 +1,368 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package datastores
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+)
+
+var (
+	_ datasource.DataSource              = &pruneSimulationDataSource{}
+	_ datasource.DataSourceWithConfigure = &pruneSimulationDataSource{}
+)
+
+// NewPruneSimulationDataSource is a helper function to simplify the provider implementation.
+func NewPruneSimulationDataSource() datasource.DataSource {
+	return &pruneSimulationDataSource{}
+}
+
+// pruneSimulationDataSource is the data source implementation.
+type pruneSimulationDataSource struct {
+	client *pbs.Client
+}
+
+// pruneSimulationDataSourceModel maps the data source schema data.
+type pruneSimulationDataSourceModel struct {
+	Store         types.String              `tfsdk:"store"`
+	Namespace     types.String              `tfsdk:"namespace"`
+	BackupType    types.String              `tfsdk:"backup_type"`
+	BackupID      types.String              `tfsdk:"backup_id"`
+	SnapshotTimes types.List                `tfsdk:"snapshot_times"`
+	TimeZone      types.String              `tfsdk:"time_zone"`
+	KeepLast      types.Int64               `tfsdk:"keep_last"`
+	KeepHourly    types.Int64               `tfsdk:"keep_hourly"`
+	KeepDaily     types.Int64               `tfsdk:"keep_daily"`
+	KeepWeekly    types.Int64               `tfsdk:"keep_weekly"`
+	KeepMonthly   types.Int64               `tfsdk:"keep_monthly"`
+	KeepYearly    types.Int64               `tfsdk:"keep_yearly"`
+	Snapshots     []pruneSimulationSnapshot `tfsdk:"snapshots"`
+	KeepCount     types.Int64               `tfsdk:"keep_count"`
+	RemoveCount   types.Int64               `tfsdk:"remove_count"`
+}
+
+// pruneSimulationSnapshot represents the decision for a single snapshot
+type pruneSimulationSnapshot struct {
+	BackupTime types.String `tfsdk:"backup_time"`
+	Keep       types.Bool   `tfsdk:"keep"`
+	Protected  types.Bool   `tfsdk:"protected"`
+	Reason     types.String `tfsdk:"reason"`
+}
+
+// Metadata returns the data source type name.
+func (d *pruneSimulationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prune_simulation"
+}
+
+// Schema defines the schema for the data source.
+func (d *pruneSimulationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	keepAttribute := func(period string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description:         fmt.Sprintf("Number of %s backups to keep.", period),
+			MarkdownDescription: fmt.Sprintf("Number of %s backups to keep.", period),
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Simulates prune retention settings and reports which snapshots would be kept or removed.",
+		MarkdownDescription: `Simulates prune retention settings and reports which snapshots would be kept or removed.
+
+Two modes are supported:
+
+* **Server dry run** – set ` + "`store`, `backup_type` and `backup_id`" + ` to run a prune with ` + "`dry-run`" + ` against
+  a real backup group. Nothing is removed.
+* **Local simulation** – set ` + "`snapshot_times`" + ` to evaluate the PBS prune selection against synthetic
+  timestamps, e.g. to prove a multi-year retention policy before any backups exist.
+
+Each snapshot reports the option that kept it (` + "`keep-last`, `keep-daily`, ..." + `), ` + "`protected`" + ` or ` + "`remove`" + `.
+When no keep option is set, PBS keeps every snapshot (` + "`keep-all`" + `).`,
+
+		Attributes: map[string]schema.Attribute{
+			"store": schema.StringAttribute{
+				Description:         "Datastore holding the backup group to dry-run the prune against.",
+				MarkdownDescription: "Datastore holding the backup group to dry-run the prune against. Conflicts with `snapshot_times`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("backup_type"), path.MatchRoot("backup_id")),
+					stringvalidator.ConflictsWith(path.MatchRoot("snapshot_times")),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description:         "Namespace of the backup group.",
+				MarkdownDescription: "Namespace of the backup group. Defaults to the root namespace.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("store")),
+				},
+			},
+			"backup_type": schema.StringAttribute{
+				Description:         "Backup type of the group (vm, ct, host).",
+				MarkdownDescription: "Backup type of the group: `vm`, `ct` or `host`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("vm", "ct", "host"),
+					stringvalidator.AlsoRequires(path.MatchRoot("store")),
+				},
+			},
+			"backup_id": schema.StringAttribute{
+				Description:         "Backup ID of the group.",
+				MarkdownDescription: "Backup ID of the group (e.g., `100` or a host name).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("store")),
+				},
+			},
+			"snapshot_times": schema.ListAttribute{
+				Description:         "Synthetic snapshot timestamps (RFC 3339) to simulate locally.",
+				MarkdownDescription: "Synthetic snapshot timestamps (RFC 3339) to simulate locally. Conflicts with `store`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"time_zone": schema.StringAttribute{
+				Description:         "Time zone used to group snapshots into hours, days, weeks, months and years.",
+				MarkdownDescription: "IANA time zone (e.g., `Europe/Berlin`) used to group snapshots into hours, days, weeks, months and years. PBS uses the server's local time zone. Defaults to `UTC`.",
+				Optional:            true,
+			},
+			"keep_last":    keepAttribute("latest"),
+			"keep_hourly":  keepAttribute("hourly"),
+			"keep_daily":   keepAttribute("daily"),
+			"keep_weekly":  keepAttribute("weekly"),
+			"keep_monthly": keepAttribute("monthly"),
+			"keep_yearly":  keepAttribute("yearly"),
+			"snapshots": schema.ListNestedAttribute{
+				Description:         "Prune decision for each snapshot, newest first.",
+				MarkdownDescription: "Prune decision for each snapshot, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"backup_time": schema.StringAttribute{
+							Description:         "Snapshot time (RFC 3339).",
+							MarkdownDescription: "Snapshot time (RFC 3339).",
+							Computed:            true,
+						},
+						"keep": schema.BoolAttribute{
+							Description:         "Whether the snapshot would be kept.",
+							MarkdownDescription: "Whether the snapshot would be kept.",
+							Computed:            true,
+						},
+						"protected": schema.BoolAttribute{
+							Description:         "Whether the snapshot is protected from pruning.",
+							MarkdownDescription: "Whether the snapshot is protected from pruning. Always `false` in local simulations.",
+							Computed:            true,
+						},
+						"reason": schema.StringAttribute{
+							Description:         "Why the snapshot is kept or removed.",
+							MarkdownDescription: "Why the snapshot is kept or removed: the keep option that selected it, `keep-all`, `protected`, `remove`, or `kept` when the server keeps a snapshot for a reason the simulation does not model (such as an unfinished backup).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"keep_count": schema.Int64Attribute{
+				Description:         "Number of snapshots that would be kept.",
+				MarkdownDescription: "Number of snapshots that would be kept.",
+				Computed:            true,
+			},
+			"remove_count": schema.Int64Attribute{
+				Description:         "Number of snapshots that would be removed.",
+				MarkdownDescription: "Number of snapshots that would be removed.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pruneSimulationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pruneSimulationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pruneSimulationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := datastores.PruneOptions{
+		KeepLast:    int64PtrToIntPtr(state.KeepLast),
+		KeepHourly:  int64PtrToIntPtr(state.KeepHourly),
+		KeepDaily:   int64PtrToIntPtr(state.KeepDaily),
+		KeepWeekly:  int64PtrToIntPtr(state.KeepWeekly),
+		KeepMonthly: int64PtrToIntPtr(state.KeepMonthly),
+		KeepYearly:  int64PtrToIntPtr(state.KeepYearly),
+	}
+
+	loc := time.UTC
+	if !state.TimeZone.IsNull() {
+		var err error
+		loc, err = time.LoadLocation(state.TimeZone.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("time_zone"),
+				"Invalid Time Zone",
+				fmt.Sprintf("Could not load time zone %q: %s", state.TimeZone.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	switch {
+	case !state.SnapshotTimes.IsNull():
+		var raw []string
+		resp.Diagnostics.Append(state.SnapshotTimes.ElementsAs(ctx, &raw, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		times := make([]time.Time, 0, len(raw))
+		for i, s := range raw {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("snapshot_times").AtListIndex(i),
+					"Invalid Snapshot Time",
+					fmt.Sprintf("Could not parse %q as RFC 3339: %s", s, err.Error()),
+				)
+				return
+			}
+			times = append(times, t)
+		}
+
+		state.Snapshots = make([]pruneSimulationSnapshot, 0, len(times))
+		for _, decision := range datastores.SimulatePrune(times, opts, loc) {
+			state.Snapshots = append(state.Snapshots, pruneSimulationSnapshot{
+				BackupTime: types.StringValue(decision.Time.Format(time.RFC3339)),
+				Keep:       types.BoolValue(decision.Keep),
+				Protected:  types.BoolValue(false),
+				Reason:     types.StringValue(decision.Reason),
+			})
+		}
+
+	case !state.Store.IsNull():
+		entries, err := d.client.Datastores.PruneGroupDryRun(
+			ctx,
+			state.Store.ValueString(),
+			state.Namespace.ValueString(),
+			state.BackupType.ValueString(),
+			state.BackupID.ValueString(),
+			opts,
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Simulating Prune",
+				fmt.Sprintf("Could not run prune dry run on datastore %s: %s", state.Store.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		state.Snapshots = explainPruneDryRun(entries, opts, loc)
+
+	default:
+		resp.Diagnostics.AddError(
+			"Missing Prune Simulation Input",
+			"Set either snapshot_times for a local simulation, or store, backup_type and backup_id for a server dry run.",
+		)
+		return
+	}
+
+	var keepCount, removeCount int64
+	for _, s := range state.Snapshots {
+		if s.Keep.ValueBool() {
+			keepCount++
+		} else {
+			removeCount++
+		}
+	}
+	state.KeepCount = types.Int64Value(keepCount)
+	state.RemoveCount = types.Int64Value(removeCount)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// int64PtrToIntPtr converts an optional Terraform integer to the client representation.
+func int64PtrToIntPtr(v types.Int64) *int {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	i := int(v.ValueInt64())
+	return &i
+}
+
+// explainPruneDryRun annotates the entries of a server dry run with the reason
+// for each decision. PBS only reports keep/remove, so the selection is replayed
+// locally. Protected snapshots are left out of the replay, as PBS skips them
+// before selecting and they do not use up any keep-* slot.
+func explainPruneDryRun(entries []datastores.PruneEntry, opts datastores.PruneOptions, loc *time.Location) []pruneSimulationSnapshot {
+	times := make([]time.Time, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Protected {
+			times = append(times, time.Unix(entry.BackupTime, 0).UTC())
+		}
+	}
+	reasons := make(map[int64]string, len(entries))
+	for _, decision := range datastores.SimulatePrune(times, opts, loc) {
+		reasons[decision.Time.Unix()] = decision.Reason
+	}
+
+	snapshots := make([]pruneSimulationSnapshot, 0, len(entries))
+	for _, entry := range entries {
+		reason := reasons[entry.BackupTime]
+		switch {
+		case entry.Protected:
+			reason = datastores.PruneReasonProtected
+		case !entry.Keep:
+			reason = datastores.PruneReasonRemove
+		case reason == "" || reason == datastores.PruneReasonRemove:
+			// Kept by the server for a reason the local replay does not model (e.g. an unfinished snapshot)
+			reason = "kept"
+		}
+
+		snapshots = append(snapshots, pruneSimulationSnapshot{
+			BackupTime: types.StringValue(time.Unix(entry.BackupTime, 0).UTC().Format(time.RFC3339)),
+			Keep:       types.BoolValue(entry.Keep),
+			Protected:  types.BoolValue(entry.Protected),
+			Reason:     types.StringValue(reason),
+		})
+	}
+	return snapshots
+}
@@ -0,0 +1,65 @@
+package datastores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+)
+
+func TestPruneSimulationDataSourceSchema(t *testing.T) {
+	ds := &pruneSimulationDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.Schema.Attributes)
+
+	for _, name := range []string{"store", "backup_type", "backup_id", "snapshot_times", "keep_last", "keep_yearly"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+		require.True(t, attr.IsOptional(), "%s should be optional", name)
+	}
+
+	// Verify snapshots attribute exists and is computed
+	snapshotsAttr, ok := resp.Schema.Attributes["snapshots"]
+	require.True(t, ok, "snapshots attribute should exist")
+	require.True(t, snapshotsAttr.IsComputed(), "snapshots should be computed")
+}
+
+func TestExplainPruneDryRunSkipsProtected(t *testing.T) {
+	day := func(d int) int64 { return time.Date(2025, 1, d, 12, 0, 0, 0, time.UTC).Unix() }
+	keepLast, keepDaily := 1, 2
+
+	// The newest snapshot is protected and must not use up the keep-last slot
+	entries := []datastores.PruneEntry{
+		{BackupTime: day(4), Keep: true, Protected: true},
+		{BackupTime: day(3), Keep: true},
+		{BackupTime: day(2), Keep: true},
+		{BackupTime: day(1), Keep: true},
+		{BackupTime: day(1) - 3600, Keep: false},
+	}
+	opts := datastores.PruneOptions{KeepLast: &keepLast, KeepDaily: &keepDaily}
+
+	snapshots := explainPruneDryRun(entries, opts, time.UTC)
+	require.Len(t, snapshots, len(entries))
+
+	want := []string{
+		datastores.PruneReasonProtected,
+		datastores.PruneReasonKeepLast,
+		datastores.PruneReasonKeepDaily,
+		datastores.PruneReasonKeepDaily,
+		datastores.PruneReasonRemove,
+	}
+	for i, s := range snapshots {
+		require.Equal(t, want[i], s.Reason.ValueString(), "snapshot %s", s.BackupTime.ValueString())
+		require.Equal(t, entries[i].Keep, s.Keep.ValueBool())
+		require.Equal(t, entries[i].Protected, s.Protected.ValueBool())
+	}
+}
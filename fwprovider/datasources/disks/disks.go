@@ -0,0 +1,266 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package disks provides Terraform data sources for PBS node disks
+package disks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/disks"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &disksDataSource{}
+	_ datasource.DataSourceWithConfigure = &disksDataSource{}
+)
+
+// NewDisksDataSource is a helper function to simplify the provider implementation.
+func NewDisksDataSource() datasource.DataSource {
+	return &disksDataSource{}
+}
+
+// disksDataSource is the data source implementation.
+type disksDataSource struct {
+	client *pbs.Client
+}
+
+// disksDataSourceModel maps the data source schema data.
+type disksDataSourceModel struct {
+	Node              types.String `tfsdk:"node"`
+	IncludePartitions types.Bool   `tfsdk:"include_partitions"`
+	SkipSmart         types.Bool   `tfsdk:"skip_smart"`
+	UsageType         types.String `tfsdk:"usage_type"`
+	Disks             []diskModel  `tfsdk:"disks"`
+}
+
+// diskModel represents a single disk in the list
+type diskModel struct {
+	Name        types.String  `tfsdk:"name"`
+	DevPath     types.String  `tfsdk:"devpath"`
+	Used        types.String  `tfsdk:"used"`
+	DiskType    types.String  `tfsdk:"disk_type"`
+	Vendor      types.String  `tfsdk:"vendor"`
+	Model       types.String  `tfsdk:"model"`
+	Serial      types.String  `tfsdk:"serial"`
+	WWN         types.String  `tfsdk:"wwn"`
+	Size        types.Int64   `tfsdk:"size"`
+	GPT         types.Bool    `tfsdk:"gpt"`
+	RPM         types.Int64   `tfsdk:"rpm"`
+	Wearout     types.Float64 `tfsdk:"wearout"`
+	Status      types.String  `tfsdk:"status"`
+	IsPartition types.Bool    `tfsdk:"is_partition"`
+}
+
+// Metadata returns the data source type name.
+func (d *disksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_disks"
+}
+
+// Schema defines the schema for the data source.
+func (d *disksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Lists the block devices on a Proxmox Backup Server node.",
+		MarkdownDescription: "Lists the block devices on a Proxmox Backup Server node, including usage, SMART status and SSD wearout.",
+
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node to query. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node to query. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"include_partitions": schema.BoolAttribute{
+				Description:         "Include partitions in the list.",
+				MarkdownDescription: "Include partitions in the list. Defaults to `false`.",
+				Optional:            true,
+			},
+			"skip_smart": schema.BoolAttribute{
+				Description:         "Skip SMART checks.",
+				MarkdownDescription: "Skip SMART checks. `status` and `wearout` are not populated when set. Defaults to `false`.",
+				Optional:            true,
+			},
+			"usage_type": schema.StringAttribute{
+				Description:         "Only list disks with this usage type.",
+				MarkdownDescription: "Only list disks with this usage type. Valid values: `unused`, `partitions`, `lvm`, `zfs`, `mounted`, `filesystem`, `device-mapper`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("unused", "partitions", "lvm", "zfs", "mounted", "filesystem", "device-mapper"),
+				},
+			},
+			"disks": schema.ListNestedAttribute{
+				Description:         "List of disks.",
+				MarkdownDescription: "List of disks.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description:         "The disk name.",
+							MarkdownDescription: "The disk name (e.g., `sda`).",
+							Computed:            true,
+						},
+						"devpath": schema.StringAttribute{
+							Description:         "The device path.",
+							MarkdownDescription: "The device path (e.g., `/dev/sda`).",
+							Computed:            true,
+						},
+						"used": schema.StringAttribute{
+							Description:         "How the disk is used.",
+							MarkdownDescription: "How the disk is used (e.g., `unused`, `partitions`, `zfs`, `mounted`).",
+							Computed:            true,
+						},
+						"disk_type": schema.StringAttribute{
+							Description:         "The disk type.",
+							MarkdownDescription: "The disk type (e.g., `hdd`, `ssd`, `usb`).",
+							Computed:            true,
+						},
+						"vendor": schema.StringAttribute{
+							Description:         "The disk vendor.",
+							MarkdownDescription: "The disk vendor.",
+							Computed:            true,
+						},
+						"model": schema.StringAttribute{
+							Description:         "The disk model.",
+							MarkdownDescription: "The disk model.",
+							Computed:            true,
+						},
+						"serial": schema.StringAttribute{
+							Description:         "The disk serial number.",
+							MarkdownDescription: "The disk serial number.",
+							Computed:            true,
+						},
+						"wwn": schema.StringAttribute{
+							Description:         "The disk World Wide Name.",
+							MarkdownDescription: "The disk World Wide Name.",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							Description:         "The disk size in bytes.",
+							MarkdownDescription: "The disk size in bytes.",
+							Computed:            true,
+						},
+						"gpt": schema.BoolAttribute{
+							Description:         "Whether the disk has a GPT partition table.",
+							MarkdownDescription: "Whether the disk has a GPT partition table.",
+							Computed:            true,
+						},
+						"rpm": schema.Int64Attribute{
+							Description:         "The rotational speed of the disk.",
+							MarkdownDescription: "The rotational speed of the disk. Null for SSDs.",
+							Computed:            true,
+						},
+						"wearout": schema.Float64Attribute{
+							Description:         "The SSD wearout percentage.",
+							MarkdownDescription: "The SSD wearout percentage as reported by SMART.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "The SMART health status.",
+							MarkdownDescription: "The SMART health status (e.g., `passed`, `failed`, `unknown`).",
+							Computed:            true,
+						},
+						"is_partition": schema.BoolAttribute{
+							Description:         "Whether the entry is a partition.",
+							MarkdownDescription: "Whether the entry is a partition rather than a whole disk.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *disksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *disksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state disksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := d.client.Nodes.ResolveNode(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	state.Node = types.StringValue(node)
+
+	opts := disks.ListDisksOptions{
+		IncludePartitions: state.IncludePartitions.ValueBool(),
+		SkipSmart:         state.SkipSmart.ValueBool(),
+		UsageType:         state.UsageType.ValueString(),
+	}
+
+	diskList, err := d.client.Disks.ListDisks(ctx, state.Node.ValueString(), opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Disks",
+			fmt.Sprintf("Could not list disks on node %s: %s", state.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Disks = make([]diskModel, 0, len(diskList))
+	for _, disk := range diskList {
+		model := diskModel{
+			Name:        types.StringValue(disk.Name),
+			DevPath:     stringValueOrNull(disk.DevPath),
+			Used:        stringValueOrNull(disk.Used),
+			DiskType:    stringValueOrNull(disk.DiskType),
+			Vendor:      stringValueOrNull(disk.Vendor),
+			Model:       stringValueOrNull(disk.Model),
+			Serial:      stringValueOrNull(disk.Serial),
+			WWN:         stringValueOrNull(disk.WWN),
+			Size:        types.Int64Value(disk.Size),
+			GPT:         types.BoolValue(disk.GPT),
+			RPM:         types.Int64PointerValue(disk.RPM),
+			Wearout:     types.Float64PointerValue(disk.Wearout),
+			Status:      stringValueOrNull(disk.Status),
+			IsPartition: types.BoolValue(disk.IsPartition),
+		}
+
+		state.Disks = append(state.Disks, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
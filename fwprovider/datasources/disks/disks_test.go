@@ -0,0 +1,38 @@
+package disks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisksDataSourceSchema(t *testing.T) {
+	ds := &disksDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.Schema.Attributes)
+
+	// Verify node is optional and defaults to the only node
+	nodeAttr, ok := resp.Schema.Attributes["node"]
+	require.True(t, ok, "node attribute should exist")
+	require.True(t, nodeAttr.IsOptional(), "node should be optional")
+	require.True(t, nodeAttr.IsComputed(), "node should be computed from the default node")
+
+	// Verify optional filters
+	for _, name := range []string{"include_partitions", "skip_smart", "usage_type"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+		require.True(t, attr.IsOptional(), "%s should be optional", name)
+	}
+
+	// Verify disks attribute exists and is computed
+	disksAttr, ok := resp.Schema.Attributes["disks"]
+	require.True(t, ok, "disks attribute should exist")
+	require.True(t, disksAttr.IsComputed(), "disks should be computed")
+}
@@ -0,0 +1,151 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &s3BucketsDataSource{}
+	_ datasource.DataSourceWithConfigure = &s3BucketsDataSource{}
+)
+
+// NewS3BucketsDataSource is a helper function to simplify the provider implementation.
+func NewS3BucketsDataSource() datasource.DataSource {
+	return &s3BucketsDataSource{}
+}
+
+// s3BucketsDataSource is the data source implementation.
+type s3BucketsDataSource struct {
+	client *pbs.Client
+}
+
+// s3BucketsDataSourceModel maps the data source schema data.
+type s3BucketsDataSourceModel struct {
+	EndpointID types.String    `tfsdk:"endpoint_id"`
+	Names      []types.String  `tfsdk:"names"`
+	Buckets    []s3BucketModel `tfsdk:"buckets"`
+}
+
+// s3BucketModel represents a single bucket in the list
+type s3BucketModel struct {
+	Name         types.String `tfsdk:"name"`
+	CreationDate types.String `tfsdk:"creation_date"`
+}
+
+// Metadata returns the data source type name.
+func (d *s3BucketsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_buckets"
+}
+
+// Schema defines the schema for the data source.
+func (d *s3BucketsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the buckets accessible through an S3 endpoint configured on Proxmox Backup Server.",
+		MarkdownDescription: `Lists the buckets accessible through an S3 endpoint configured on Proxmox Backup Server.
+
+PBS queries the object store with the endpoint's credentials, so the result reflects what a datastore using
+this endpoint can reach. Useful for validating ` + "`s3_bucket`" + ` before planning a ` + "`pbs_datastore`" + `.`,
+
+		Attributes: map[string]schema.Attribute{
+			"endpoint_id": schema.StringAttribute{
+				Description:         "The ID of the S3 endpoint to list buckets for.",
+				MarkdownDescription: "The ID of the S3 endpoint to list buckets for, e.g. `pbs_s3_endpoint.example.id`.",
+				Required:            true,
+			},
+			"names": schema.ListAttribute{
+				Description:         "Names of the accessible buckets.",
+				MarkdownDescription: "Names of the accessible buckets, convenient for `contains()` checks.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"buckets": schema.ListNestedAttribute{
+				Description:         "List of accessible buckets.",
+				MarkdownDescription: "List of accessible buckets.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description:         "Bucket name.",
+							MarkdownDescription: "Bucket name.",
+							Computed:            true,
+						},
+						"creation_date": schema.StringAttribute{
+							Description:         "Bucket creation date in RFC 3339 format, if reported by the object store.",
+							MarkdownDescription: "Bucket creation date in RFC 3339 format, if reported by the object store.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *s3BucketsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *s3BucketsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state s3BucketsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	buckets, err := d.client.Endpoints.ListBuckets(ctx, state.EndpointID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing S3 Buckets",
+			fmt.Sprintf("Could not list buckets for S3 endpoint %s: %s", state.EndpointID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Names = make([]types.String, 0, len(buckets))
+	state.Buckets = make([]s3BucketModel, 0, len(buckets))
+	for _, bucket := range buckets {
+		creationDate := types.StringNull()
+		if !bucket.CreationDate.IsZero() {
+			creationDate = types.StringValue(bucket.CreationDate.UTC().Format(time.RFC3339))
+		}
+
+		state.Names = append(state.Names, types.StringValue(bucket.Name))
+		state.Buckets = append(state.Buckets, s3BucketModel{
+			Name:         types.StringValue(bucket.Name),
+			CreationDate: creationDate,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -90,3 +90,34 @@ func TestS3EndpointsDataSourceSchema(t *testing.T) {
 		assert.True(t, exists, "%s attribute should exist in nested object", attrName)
 	}
 }
+
+// TestS3BucketsDataSourceSchema verifies the s3_buckets data source schema
+func TestS3BucketsDataSourceSchema(t *testing.T) {
+	ds := NewS3BucketsDataSource()
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+
+	ds.Schema(nil, schemaReq, schemaResp)
+
+	assert.NotNil(t, schemaResp.Schema)
+	assert.NotEmpty(t, schemaResp.Schema.Description)
+
+	endpointIDAttr, exists := schemaResp.Schema.Attributes["endpoint_id"]
+	assert.True(t, exists, "endpoint_id attribute should exist")
+	assert.True(t, endpointIDAttr.(schema.StringAttribute).Required, "endpoint_id should be required")
+
+	namesAttr, exists := schemaResp.Schema.Attributes["names"]
+	assert.True(t, exists, "names attribute should exist")
+	assert.True(t, namesAttr.(schema.ListAttribute).Computed, "names should be computed")
+
+	bucketsAttr, exists := schemaResp.Schema.Attributes["buckets"]
+	assert.True(t, exists, "buckets attribute should exist")
+	assert.True(t, bucketsAttr.(schema.ListNestedAttribute).Computed, "buckets should be computed")
+
+	nestedObj := bucketsAttr.(schema.ListNestedAttribute).NestedObject
+	for _, attrName := range []string{"name", "creation_date"} {
+		_, exists := nestedObj.Attributes[attrName]
+		assert.True(t, exists, "%s attribute should exist in nested object", attrName)
+	}
+}
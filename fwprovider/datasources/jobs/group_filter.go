@@ -0,0 +1,89 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+// groupFilterModel maps a single group_filter block.
+type groupFilterModel struct {
+	Action types.String `tfsdk:"action"`
+	Kind   types.String `tfsdk:"kind"`
+	Value  types.String `tfsdk:"value"`
+}
+
+// groupFilterBlock returns the group_filter block accepted by the data sources
+// that evaluate sync job group filters.
+func groupFilterBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description:         "Group filters to apply, as in the group_filter blocks of pbs_sync_job.",
+		MarkdownDescription: "Group filters to apply, as in the `group_filter` blocks of `pbs_sync_job`. Conflicts with `job_id`.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"action": schema.StringAttribute{
+					Description:         "Whether matching groups are included or excluded.",
+					MarkdownDescription: "Whether matching groups are `include`d or `exclude`d. Defaults to `include`.",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(jobs.GroupFilterInclude, jobs.GroupFilterExclude),
+					},
+				},
+				"kind": schema.StringAttribute{
+					Description:         "Filter kind: group, type or regex.",
+					MarkdownDescription: "Filter kind: `group`, `type` or `regex`.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(jobs.GroupFilterGroup, jobs.GroupFilterType, jobs.GroupFilterRegex),
+					},
+				},
+				"value": schema.StringAttribute{
+					Description:         "Group, backup type or regular expression to match.",
+					MarkdownDescription: "Group (e.g. `vm/100`), backup type (e.g. `ct`) or regular expression matched against `<type>/<id>`.",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
+// groupFiltersFromBlocks parses configured group_filter blocks.
+func groupFiltersFromBlocks(blocks []groupFilterModel) ([]jobs.GroupFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filters := make([]jobs.GroupFilter, 0, len(blocks))
+	for i, block := range blocks {
+		f, err := jobs.NewGroupFilter(block.Action.ValueString(), block.Kind.ValueString(), block.Value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("group_filter").AtListIndex(i), "Invalid Group Filter", err.Error())
+			continue
+		}
+		filters = append(filters, f)
+	}
+	return filters, diags
+}
+
+// groupFiltersFromEntries parses the group-filter entries of a sync job.
+func groupFiltersFromEntries(jobID string, entries []string) ([]jobs.GroupFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filters := make([]jobs.GroupFilter, 0, len(entries))
+	for _, entry := range entries {
+		f, err := jobs.ParseGroupFilter(entry)
+		if err != nil {
+			diags.AddError("Invalid Group Filter", "Sync job "+jobID+": "+err.Error())
+			continue
+		}
+		filters = append(filters, f)
+	}
+	return filters, diags
+}
@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -36,20 +37,25 @@ type pruneJobDataSource struct {
 
 // pruneJobDataSourceModel maps the data source schema data.
 type pruneJobDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Store       types.String `tfsdk:"store"`
-	Schedule    types.String `tfsdk:"schedule"`
-	KeepLast    types.Int64  `tfsdk:"keep_last"`
-	KeepHourly  types.Int64  `tfsdk:"keep_hourly"`
-	KeepDaily   types.Int64  `tfsdk:"keep_daily"`
-	KeepWeekly  types.Int64  `tfsdk:"keep_weekly"`
-	KeepMonthly types.Int64  `tfsdk:"keep_monthly"`
-	KeepYearly  types.Int64  `tfsdk:"keep_yearly"`
-	MaxDepth    types.Int64  `tfsdk:"max_depth"`
-	Namespace   types.String `tfsdk:"namespace"`
-	Comment     types.String `tfsdk:"comment"`
-	Disable     types.Bool   `tfsdk:"disable"`
-	Digest      types.String `tfsdk:"digest"`
+	ID             types.String `tfsdk:"id"`
+	Store          types.String `tfsdk:"store"`
+	Schedule       types.String `tfsdk:"schedule"`
+	KeepLast       types.Int64  `tfsdk:"keep_last"`
+	KeepHourly     types.Int64  `tfsdk:"keep_hourly"`
+	KeepDaily      types.Int64  `tfsdk:"keep_daily"`
+	KeepWeekly     types.Int64  `tfsdk:"keep_weekly"`
+	KeepMonthly    types.Int64  `tfsdk:"keep_monthly"`
+	KeepYearly     types.Int64  `tfsdk:"keep_yearly"`
+	MaxDepth       types.Int64  `tfsdk:"max_depth"`
+	Namespace      types.String `tfsdk:"namespace"`
+	Comment        types.String `tfsdk:"comment"`
+	Disable        types.Bool   `tfsdk:"disable"`
+	Digest         types.String `tfsdk:"digest"`
+	LastRunState   types.String `tfsdk:"last_run_state"`
+	LastRunFailed  types.Bool   `tfsdk:"last_run_failed"`
+	LastRunUPID    types.String `tfsdk:"last_run_upid"`
+	LastRunEndtime types.String `tfsdk:"last_run_endtime"`
+	NextRun        types.String `tfsdk:"next_run"`
 }
 
 // Metadata returns the data source type name.
@@ -135,6 +141,7 @@ func (d *pruneJobDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, jobStatusAttributes())
 }
 
 // Configure adds the provider configured client to the data source.
@@ -176,6 +183,16 @@ func (d *pruneJobDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Map API response to state
 	pruneJobToState(job, &state)
 
+	status, err := d.client.Jobs.GetPruneJobStatus(ctx, job.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Prune Job Status",
+			fmt.Sprintf("Could not read run status of prune job %s: %s", job.ID, err.Error()),
+		)
+		return
+	}
+	state.LastRunState, state.LastRunUPID, state.LastRunEndtime, state.NextRun, state.LastRunFailed = jobStatusValues(status)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -136,6 +137,7 @@ func (d *pruneJobsDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes["jobs"].(schema.ListNestedAttribute).NestedObject.Attributes, jobStatusAttributes())
 }
 
 // Configure adds the provider configured client to the data source.
@@ -180,6 +182,16 @@ func (d *pruneJobsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		storeFilter = state.Store.ValueString()
 	}
 
+	statuses, err := d.client.Jobs.ListPruneJobStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Prune Job Status",
+			fmt.Sprintf("Could not list prune job run status: %s", err.Error()),
+		)
+		return
+	}
+	statusByID := jobStatusByID(statuses)
+
 	// Map API response to state
 	state.Jobs = make([]pruneJobDataSourceModel, 0)
 	for _, job := range jobs {
@@ -188,6 +200,7 @@ func (d *pruneJobsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		}
 		var jobModel pruneJobDataSourceModel
 		pruneJobToState(&job, &jobModel)
+		jobModel.LastRunState, jobModel.LastRunUPID, jobModel.LastRunEndtime, jobModel.NextRun, jobModel.LastRunFailed = jobStatusValues(statusByID[job.ID])
 		state.Jobs = append(state.Jobs, jobModel)
 	}
 
@@ -0,0 +1,97 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+// jobStatusAttributes returns the computed run status attributes shared by the job data sources.
+func jobStatusAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"last_run_state": schema.StringAttribute{
+			Description:         "Result of the last run: OK, WARNINGS: <n>, or the error message.",
+			MarkdownDescription: "Result of the last run: `OK`, `WARNINGS: <n>`, or the error message of a failed run. Null when the job has not run yet.",
+			Computed:            true,
+		},
+		"last_run_failed": schema.BoolAttribute{
+			Description:         "Whether the last run ended with an error. Runs that finished with warnings and jobs that have not run are not failures.",
+			MarkdownDescription: "Whether the last run ended with an error. Runs that finished with warnings (`WARNINGS: <n>`) and jobs that have not run yet are not failures, so checks can use this instead of matching `last_run_state`.",
+			Computed:            true,
+		},
+		"last_run_upid": schema.StringAttribute{
+			Description:         "Task UPID of the last run.",
+			MarkdownDescription: "Task UPID of the last run.",
+			Computed:            true,
+		},
+		"last_run_endtime": schema.StringAttribute{
+			Description:         "End time of the last run (RFC 3339).",
+			MarkdownDescription: "End time of the last run (RFC 3339).",
+			Computed:            true,
+		},
+		"next_run": schema.StringAttribute{
+			Description:         "Next scheduled run (RFC 3339).",
+			MarkdownDescription: "Next scheduled run (RFC 3339).",
+			Computed:            true,
+		},
+	}
+}
+
+// jobStatusValues converts a job status into the values of the run status
+// attributes. A nil status yields nulls, and last_run_failed is false.
+func jobStatusValues(status *jobs.JobStatus) (lastRunState, lastRunUPID, lastRunEndtime, nextRun types.String, lastRunFailed types.Bool) {
+	if status == nil {
+		return types.StringNull(), types.StringNull(), types.StringNull(), types.StringNull(), types.BoolValue(false)
+	}
+	return stringToValue(status.LastRunState), stringToValue(status.LastRunUPID),
+		epochToValue(status.LastRunEndtime), epochToValue(status.NextRun), types.BoolValue(status.Failed())
+}
+
+// jobStatusByID indexes job statuses by job ID.
+func jobStatusByID(statuses []jobs.JobStatus) map[string]*jobs.JobStatus {
+	byID := make(map[string]*jobs.JobStatus, len(statuses))
+	for i := range statuses {
+		byID[statuses[i].ID] = &statuses[i]
+	}
+	return byID
+}
+
+// syncJobStatusByID returns the run status of the given sync jobs by ID. Push
+// jobs are listed separately by PBS, so they are only queried when present.
+func syncJobStatusByID(ctx context.Context, client *pbs.Client, syncJobs []jobs.SyncJob) (map[string]*jobs.JobStatus, error) {
+	statuses, err := client.Jobs.ListSyncJobStatus(ctx, jobs.SyncDirectionPull)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range syncJobs {
+		if job.SyncDirection == jobs.SyncDirectionPush {
+			pushStatuses, err := client.Jobs.ListSyncJobStatus(ctx, jobs.SyncDirectionPush)
+			if err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, pushStatuses...)
+			break
+		}
+	}
+
+	return jobStatusByID(statuses), nil
+}
+
+func epochToValue(epoch *int64) types.String {
+	if epoch == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Unix(*epoch, 0).UTC().Format(time.RFC3339))
+}
@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -47,6 +48,11 @@ type syncJobDataSourceModel struct {
 	RemoveVanished  types.Bool   `tfsdk:"remove_vanished"`
 	Comment         types.String `tfsdk:"comment"`
 	Digest          types.String `tfsdk:"digest"`
+	LastRunState    types.String `tfsdk:"last_run_state"`
+	LastRunFailed   types.Bool   `tfsdk:"last_run_failed"`
+	LastRunUPID     types.String `tfsdk:"last_run_upid"`
+	LastRunEndtime  types.String `tfsdk:"last_run_endtime"`
+	NextRun         types.String `tfsdk:"next_run"`
 }
 
 // Metadata returns the data source type name.
@@ -123,6 +129,7 @@ func (d *syncJobDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, jobStatusAttributes())
 }
 
 // Configure adds the provider configured client to the data source.
@@ -174,6 +181,16 @@ func (d *syncJobDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	state.Comment = stringToValue(job.Comment)
 	state.Digest = types.StringValue(job.Digest)
 
+	status, err := d.client.Jobs.GetSyncJobStatus(ctx, job.ID, job.SyncDirection)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Sync Job Status",
+			fmt.Sprintf("Could not read run status of sync job %s: %s", job.ID, err.Error()),
+		)
+		return
+	}
+	state.LastRunState, state.LastRunUPID, state.LastRunEndtime, state.NextRun, state.LastRunFailed = jobStatusValues(status)
+
 	// Convert group filter
 	if len(job.GroupFilter) > 0 {
 		groupFilterValues := make([]types.String, 0, len(job.GroupFilter))
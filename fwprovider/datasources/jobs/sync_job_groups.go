@@ -0,0 +1,295 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+var (
+	_ datasource.DataSource              = &syncJobGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &syncJobGroupsDataSource{}
+)
+
+// NewSyncJobGroupsDataSource is a helper function to simplify the provider implementation.
+func NewSyncJobGroupsDataSource() datasource.DataSource {
+	return &syncJobGroupsDataSource{}
+}
+
+// syncJobGroupsDataSource is the data source implementation.
+type syncJobGroupsDataSource struct {
+	client *pbs.Client
+}
+
+// syncJobGroupsDataSourceModel maps the data source schema data.
+type syncJobGroupsDataSourceModel struct {
+	JobID           types.String         `tfsdk:"job_id"`
+	Remote          types.String         `tfsdk:"remote"`
+	RemoteStore     types.String         `tfsdk:"remote_store"`
+	RemoteNamespace types.String         `tfsdk:"remote_namespace"`
+	GroupFilter     []groupFilterModel   `tfsdk:"group_filter"`
+	Groups          []syncJobGroupsGroup `tfsdk:"groups"`
+	SelectedGroups  []types.String       `tfsdk:"selected_groups"`
+	SelectedCount   types.Int64          `tfsdk:"selected_count"`
+}
+
+// syncJobGroupsGroup is a remote backup group and the filter decision for it
+type syncJobGroupsGroup struct {
+	BackupType  types.String `tfsdk:"backup_type"`
+	BackupID    types.String `tfsdk:"backup_id"`
+	Owner       types.String `tfsdk:"owner"`
+	BackupCount types.Int64  `tfsdk:"backup_count"`
+	LastBackup  types.String `tfsdk:"last_backup"`
+	Selected    types.Bool   `tfsdk:"selected"`
+	DecidedBy   types.String `tfsdk:"decided_by"`
+}
+
+// Metadata returns the data source type name.
+func (d *syncJobGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sync_job_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *syncJobGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Applies sync job group filters to the backup groups of a remote datastore namespace.",
+		MarkdownDescription: `Applies sync job group filters to the backup groups of a remote datastore namespace.
+
+Either set ` + "`job_id`" + ` to evaluate an existing pull sync job, or set ` + "`remote`, `remote_store`" + ` and
+` + "`group_filter`" + ` blocks to try a filter list before creating the job. Every group directly in
+` + "`remote_namespace`" + ` is listed with whether the filters select it and which filter decided that, so
+misconfigured excludes show up before a sync runs. Namespaces below ` + "`remote_namespace`" + ` are not scanned.
+
+For push jobs, use ` + "`pbs_sync_job_preview`" + ` instead, which evaluates the filters against the local datastore.`,
+
+		Attributes: map[string]schema.Attribute{
+			"job_id": schema.StringAttribute{
+				Description:         "ID of an existing pull sync job to evaluate.",
+				MarkdownDescription: "ID of an existing pull sync job to evaluate. Conflicts with `remote`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("remote")),
+				},
+			},
+			"remote": schema.StringAttribute{
+				Description:         "Remote to scan.",
+				MarkdownDescription: "Remote to scan. Read from the job when `job_id` is set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("remote_store")),
+				},
+			},
+			"remote_store": schema.StringAttribute{
+				Description:         "Datastore on the remote to scan.",
+				MarkdownDescription: "Datastore on the remote to scan. Read from the job when `job_id` is set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"remote_namespace": schema.StringAttribute{
+				Description:         "Namespace on the remote to scan.",
+				MarkdownDescription: "Namespace on the remote to scan. Defaults to the root namespace.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"groups": schema.ListNestedAttribute{
+				Description:         "All backup groups of the namespace with the filter decision, sorted by type and ID.",
+				MarkdownDescription: "All backup groups of the namespace with the filter decision, sorted by type and ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"backup_type": schema.StringAttribute{
+							Description:         "Backup type (vm, ct, host).",
+							MarkdownDescription: "Backup type: `vm`, `ct` or `host`.",
+							Computed:            true,
+						},
+						"backup_id": schema.StringAttribute{
+							Description:         "Backup ID.",
+							MarkdownDescription: "Backup ID.",
+							Computed:            true,
+						},
+						"owner": schema.StringAttribute{
+							Description:         "Owner of the group on the remote.",
+							MarkdownDescription: "Owner of the group on the remote.",
+							Computed:            true,
+						},
+						"backup_count": schema.Int64Attribute{
+							Description:         "Number of snapshots in the group.",
+							MarkdownDescription: "Number of snapshots in the group.",
+							Computed:            true,
+						},
+						"last_backup": schema.StringAttribute{
+							Description:         "Time of the latest snapshot (RFC 3339).",
+							MarkdownDescription: "Time of the latest snapshot (RFC 3339).",
+							Computed:            true,
+						},
+						"selected": schema.BoolAttribute{
+							Description:         "Whether the job would sync the group.",
+							MarkdownDescription: "Whether the job would sync the group.",
+							Computed:            true,
+						},
+						"decided_by": schema.StringAttribute{
+							Description:         "The filter that decided the outcome, in PBS syntax.",
+							MarkdownDescription: "The filter that decided the outcome in PBS syntax (e.g. `exclude:type:ct`). Null when no filter matched: the group is then selected only if there are no include filters.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"selected_groups": schema.ListAttribute{
+				Description:         "Identifiers (type/id) of the groups the job would sync.",
+				MarkdownDescription: "Identifiers (`<type>/<id>`) of the groups the job would sync.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"selected_count": schema.Int64Attribute{
+				Description:         "Number of groups the job would sync.",
+				MarkdownDescription: "Number of groups the job would sync.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"group_filter": groupFilterBlock(),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *syncJobGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *syncJobGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state syncJobGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters, diags := groupFiltersFromBlocks(state.GroupFilter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.JobID.IsNull() {
+		if len(state.GroupFilter) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("group_filter"),
+				"Conflicting Group Filters",
+				"group_filter cannot be combined with job_id; the group filters of the job are used.",
+			)
+			return
+		}
+
+		job, err := d.client.Jobs.GetSyncJob(ctx, state.JobID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Sync Job",
+				fmt.Sprintf("Could not read sync job %s: %s", state.JobID.ValueString(), err.Error()),
+			)
+			return
+		}
+		if job.SyncDirection == jobs.SyncDirectionPush {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("job_id"),
+				"Not a Pull Sync Job",
+				fmt.Sprintf("Sync job %s pushes to remote %s; use the pbs_sync_job_preview data source to evaluate push jobs.", job.ID, job.Remote),
+			)
+			return
+		}
+
+		state.Remote = types.StringValue(job.Remote)
+		state.RemoteStore = types.StringValue(job.RemoteStore)
+		state.RemoteNamespace = types.StringValue(job.RemoteNamespace)
+
+		filters, diags = groupFiltersFromEntries(job.ID, job.GroupFilter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state.RemoteNamespace.IsNull() {
+		state.RemoteNamespace = types.StringValue("")
+	}
+
+	groups, err := d.client.Remotes.ListRemoteGroups(ctx, state.Remote.ValueString(), state.RemoteStore.ValueString(), state.RemoteNamespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Remote Groups",
+			fmt.Sprintf("Could not list backup groups of %s on remote %s: %s", state.RemoteStore.ValueString(), state.Remote.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].BackupType != groups[j].BackupType {
+			return groups[i].BackupType < groups[j].BackupType
+		}
+		return groups[i].BackupID < groups[j].BackupID
+	})
+
+	state.Groups = make([]syncJobGroupsGroup, 0, len(groups))
+	state.SelectedGroups = []types.String{}
+	for _, group := range groups {
+		selected, decidedBy := jobs.EvaluateGroupFilters(filters, group.BackupType, group.BackupID)
+
+		decision := types.StringNull()
+		if decidedBy >= 0 {
+			decision = types.StringValue(filters[decidedBy].String())
+		}
+		lastBackup := types.StringNull()
+		if group.LastBackup > 0 {
+			lastBackup = types.StringValue(time.Unix(group.LastBackup, 0).UTC().Format(time.RFC3339))
+		}
+
+		state.Groups = append(state.Groups, syncJobGroupsGroup{
+			BackupType:  types.StringValue(group.BackupType),
+			BackupID:    types.StringValue(group.BackupID),
+			Owner:       stringToValue(group.Owner),
+			BackupCount: types.Int64Value(int64(group.BackupCount)),
+			LastBackup:  lastBackup,
+			Selected:    types.BoolValue(selected),
+			DecidedBy:   decision,
+		})
+		if selected {
+			state.SelectedGroups = append(state.SelectedGroups, types.StringValue(group.BackupType+"/"+group.BackupID))
+		}
+	}
+	state.SelectedCount = types.Int64Value(int64(len(state.SelectedGroups)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
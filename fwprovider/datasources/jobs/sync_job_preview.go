@@ -0,0 +1,339 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+var (
+	_ datasource.DataSource              = &syncJobPreviewDataSource{}
+	_ datasource.DataSourceWithConfigure = &syncJobPreviewDataSource{}
+)
+
+// NewSyncJobPreviewDataSource is a helper function to simplify the provider implementation.
+func NewSyncJobPreviewDataSource() datasource.DataSource {
+	return &syncJobPreviewDataSource{}
+}
+
+// syncJobPreviewDataSource is the data source implementation.
+type syncJobPreviewDataSource struct {
+	client *pbs.Client
+}
+
+// syncJobPreviewDataSourceModel maps the data source schema data.
+type syncJobPreviewDataSourceModel struct {
+	JobID           types.String          `tfsdk:"job_id"`
+	Store           types.String          `tfsdk:"store"`
+	Namespace       types.String          `tfsdk:"namespace"`
+	RemoteNamespace types.String          `tfsdk:"remote_namespace"`
+	MaxDepth        types.Int64           `tfsdk:"max_depth"`
+	GroupFilter     []groupFilterModel    `tfsdk:"group_filter"`
+	Owner           types.String          `tfsdk:"owner"`
+	Groups          []syncJobPreviewGroup `tfsdk:"groups"`
+	GroupCount      types.Int64           `tfsdk:"group_count"`
+}
+
+// syncJobPreviewGroup is a local backup group selected for pushing
+type syncJobPreviewGroup struct {
+	Namespace       types.String `tfsdk:"namespace"`
+	TargetNamespace types.String `tfsdk:"target_namespace"`
+	BackupType      types.String `tfsdk:"backup_type"`
+	BackupID        types.String `tfsdk:"backup_id"`
+	Owner           types.String `tfsdk:"owner"`
+	BackupCount     types.Int64  `tfsdk:"backup_count"`
+	LastBackup      types.String `tfsdk:"last_backup"`
+}
+
+// Metadata returns the data source type name.
+func (d *syncJobPreviewDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sync_job_preview"
+}
+
+// Schema defines the schema for the data source.
+func (d *syncJobPreviewDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the local backup groups a push sync job would send to the remote.",
+		MarkdownDescription: `Lists the local backup groups a push sync job would send to the remote.
+
+Either set ` + "`job_id`" + ` to preview an existing push sync job, or describe the job with ` + "`store`" + `,
+` + "`namespace`, `max_depth`, `owner`" + ` and ` + "`group_filter`" + ` blocks. Group filters are applied the way PBS
+applies them: a group is selected when no include filter is given or any include filter matches it, and
+removed when an exclude filter matches it. With ` + "`owner`" + ` set, only groups owned by that user or its
+API tokens are listed.`,
+
+		Attributes: map[string]schema.Attribute{
+			"job_id": schema.StringAttribute{
+				Description:         "ID of an existing push sync job to preview.",
+				MarkdownDescription: "ID of an existing push sync job to preview. Conflicts with `store`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("store")),
+				},
+			},
+			"store": schema.StringAttribute{
+				Description:         "Local datastore the job pushes from.",
+				MarkdownDescription: "Local datastore the job pushes from. Read from the job when `job_id` is set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"namespace": schema.StringAttribute{
+				Description:         "Local namespace the job pushes from.",
+				MarkdownDescription: "Local namespace the job pushes from. Defaults to the root namespace.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"remote_namespace": schema.StringAttribute{
+				Description:         "Remote namespace the job pushes to, used to compute target namespaces.",
+				MarkdownDescription: "Remote namespace the job pushes to, used to compute `target_namespace`. Defaults to the root namespace.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"max_depth": schema.Int64Attribute{
+				Description:         "Maximum namespace depth below namespace to include.",
+				MarkdownDescription: "Maximum namespace depth below `namespace` to include. Unlimited when unset.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Description:         "Local user whose backup groups are pushed.",
+				MarkdownDescription: "Local user whose backup groups are pushed. When unset, groups of all owners are listed.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"groups": schema.ListNestedAttribute{
+				Description:         "Backup groups that would be pushed, sorted by namespace, type and ID.",
+				MarkdownDescription: "Backup groups that would be pushed, sorted by namespace, type and ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"namespace": schema.StringAttribute{
+							Description:         "Local namespace of the group; empty for the root namespace.",
+							MarkdownDescription: "Local namespace of the group; empty for the root namespace.",
+							Computed:            true,
+						},
+						"target_namespace": schema.StringAttribute{
+							Description:         "Remote namespace the group would be pushed to.",
+							MarkdownDescription: "Remote namespace the group would be pushed to.",
+							Computed:            true,
+						},
+						"backup_type": schema.StringAttribute{
+							Description:         "Backup type (vm, ct, host).",
+							MarkdownDescription: "Backup type: `vm`, `ct` or `host`.",
+							Computed:            true,
+						},
+						"backup_id": schema.StringAttribute{
+							Description:         "Backup ID.",
+							MarkdownDescription: "Backup ID.",
+							Computed:            true,
+						},
+						"owner": schema.StringAttribute{
+							Description:         "Owner of the group.",
+							MarkdownDescription: "Owner of the group.",
+							Computed:            true,
+						},
+						"backup_count": schema.Int64Attribute{
+							Description:         "Number of snapshots in the group.",
+							MarkdownDescription: "Number of snapshots in the group.",
+							Computed:            true,
+						},
+						"last_backup": schema.StringAttribute{
+							Description:         "Time of the latest snapshot (RFC 3339).",
+							MarkdownDescription: "Time of the latest snapshot (RFC 3339).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"group_count": schema.Int64Attribute{
+				Description:         "Number of backup groups that would be pushed.",
+				MarkdownDescription: "Number of backup groups that would be pushed.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"group_filter": groupFilterBlock(),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *syncJobPreviewDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *syncJobPreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state syncJobPreviewDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters, diags := groupFiltersFromBlocks(state.GroupFilter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.JobID.IsNull() {
+		if len(state.GroupFilter) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("group_filter"),
+				"Conflicting Group Filters",
+				"group_filter cannot be combined with job_id; the group filters of the job are used.",
+			)
+			return
+		}
+
+		job, err := d.client.Jobs.GetSyncJob(ctx, state.JobID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Sync Job",
+				fmt.Sprintf("Could not read sync job %s: %s", state.JobID.ValueString(), err.Error()),
+			)
+			return
+		}
+		if job.SyncDirection != jobs.SyncDirectionPush {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("job_id"),
+				"Not a Push Sync Job",
+				fmt.Sprintf("Sync job %s pulls from remote %s; only push jobs can be previewed from the local datastore.", job.ID, job.Remote),
+			)
+			return
+		}
+
+		state.Store = types.StringValue(job.Store)
+		state.Namespace = types.StringValue(job.Namespace)
+		state.RemoteNamespace = types.StringValue(job.RemoteNamespace)
+		state.MaxDepth = intPtrToValue(job.MaxDepth)
+		state.Owner = stringToValue(job.Owner)
+
+		filters, diags = groupFiltersFromEntries(job.ID, job.GroupFilter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state.Namespace.IsNull() {
+		state.Namespace = types.StringValue("")
+	}
+	if state.RemoteNamespace.IsNull() {
+		state.RemoteNamespace = types.StringValue("")
+	}
+
+	store := state.Store.ValueString()
+	namespace := state.Namespace.ValueString()
+	var maxDepth *int
+	if !state.MaxDepth.IsNull() {
+		depth := int(state.MaxDepth.ValueInt64())
+		maxDepth = &depth
+	}
+
+	namespaces, err := d.client.Datastores.ListNamespaces(ctx, store, namespace, maxDepth)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Namespaces",
+			fmt.Sprintf("Could not list namespaces below %q in datastore %s: %s", namespace, store, err.Error()),
+		)
+		return
+	}
+
+	// Depending on the PBS version the listing may omit the parent itself
+	hasParent := false
+	for _, ns := range namespaces {
+		if ns.Namespace == namespace {
+			hasParent = true
+			break
+		}
+	}
+	if !hasParent {
+		namespaces = append(namespaces, datastores.Namespace{Namespace: namespace})
+	}
+
+	state.Groups = []syncJobPreviewGroup{}
+	for _, ns := range namespaces {
+		groups, err := d.client.Datastores.ListGroups(ctx, store, ns.Namespace)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing Backup Groups",
+				fmt.Sprintf("Could not list backup groups of namespace %q in datastore %s: %s", ns.Namespace, store, err.Error()),
+			)
+			return
+		}
+
+		for _, group := range groups {
+			if !jobs.GroupFiltersMatch(filters, group.BackupType, group.BackupID) {
+				continue
+			}
+			if !state.Owner.IsNull() && !jobs.OwnedBy(group.Owner, state.Owner.ValueString()) {
+				continue
+			}
+
+			lastBackup := types.StringNull()
+			if group.LastBackup > 0 {
+				lastBackup = types.StringValue(time.Unix(group.LastBackup, 0).UTC().Format(time.RFC3339))
+			}
+			state.Groups = append(state.Groups, syncJobPreviewGroup{
+				Namespace:       types.StringValue(ns.Namespace),
+				TargetNamespace: types.StringValue(jobs.MapSyncNamespace(namespace, state.RemoteNamespace.ValueString(), ns.Namespace)),
+				BackupType:      types.StringValue(group.BackupType),
+				BackupID:        types.StringValue(group.BackupID),
+				Owner:           stringToValue(group.Owner),
+				BackupCount:     types.Int64Value(int64(group.BackupCount)),
+				LastBackup:      lastBackup,
+			})
+		}
+	}
+
+	sort.Slice(state.Groups, func(i, j int) bool {
+		a, b := state.Groups[i], state.Groups[j]
+		if a.Namespace.ValueString() != b.Namespace.ValueString() {
+			return a.Namespace.ValueString() < b.Namespace.ValueString()
+		}
+		if a.BackupType.ValueString() != b.BackupType.ValueString() {
+			return a.BackupType.ValueString() < b.BackupType.ValueString()
+		}
+		return a.BackupID.ValueString() < b.BackupID.ValueString()
+	})
+	state.GroupCount = types.Int64Value(int64(len(state.Groups)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncJobPreviewDataSourceSchema(t *testing.T) {
+	ds := &syncJobPreviewDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.Schema.Attributes)
+
+	for _, name := range []string{"job_id", "store", "namespace", "remote_namespace", "max_depth", "owner"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+		require.True(t, attr.IsOptional(), "%s should be optional", name)
+	}
+
+	_, ok := resp.Schema.Blocks["group_filter"]
+	require.True(t, ok, "group_filter block should exist")
+
+	for _, name := range []string{"groups", "group_count"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+		require.True(t, attr.IsComputed(), "%s should be computed", name)
+	}
+}
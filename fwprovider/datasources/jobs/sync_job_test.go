@@ -26,7 +26,8 @@ func TestSyncJobDataSourceSchema(t *testing.T) {
 	// Verify computed attributes
 	computedAttrs := []string{"store", "remote", "remote_store", "schedule",
 		"remote_namespace", "namespace", "comment", "remove_vanished",
-		"max_depth", "group_filter", "digest"}
+		"max_depth", "group_filter", "digest", "last_run_state", "last_run_failed",
+		"last_run_upid", "last_run_endtime", "next_run"}
 
 	for _, attrName := range computedAttrs {
 		attr, ok := resp.Schema.Attributes[attrName]
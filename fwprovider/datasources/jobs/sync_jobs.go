@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -109,6 +110,7 @@ func (d *syncJobsDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes["jobs"].(schema.ListNestedAttribute).NestedObject.Attributes, jobStatusAttributes())
 }
 
 // Configure adds the provider configured client to the data source.
@@ -157,6 +159,15 @@ func (d *syncJobsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		remoteFilter = state.Remote.ValueString()
 	}
 
+	statusByID, err := syncJobStatusByID(ctx, d.client, jobs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Sync Job Status",
+			fmt.Sprintf("Could not list sync job run status: %s", err.Error()),
+		)
+		return
+	}
+
 	// Map API response to state
 	state.Jobs = make([]syncJobDataSourceModel, 0)
 	for _, job := range jobs {
@@ -193,6 +204,7 @@ func (d *syncJobsDataSource) Read(ctx context.Context, req datasource.ReadReques
 			jobModel.GroupFilter = types.ListNull(types.StringType)
 		}
 
+		jobModel.LastRunState, jobModel.LastRunUPID, jobModel.LastRunEndtime, jobModel.NextRun, jobModel.LastRunFailed = jobStatusValues(statusByID[job.ID])
 		state.Jobs = append(state.Jobs, jobModel)
 	}
 
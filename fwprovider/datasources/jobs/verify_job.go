@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -45,6 +46,11 @@ type verifyJobDataSourceModel struct {
 	MaxDepth       types.Int64  `tfsdk:"max_depth"`
 	Comment        types.String `tfsdk:"comment"`
 	Digest         types.String `tfsdk:"digest"`
+	LastRunState   types.String `tfsdk:"last_run_state"`
+	LastRunFailed  types.Bool   `tfsdk:"last_run_failed"`
+	LastRunUPID    types.String `tfsdk:"last_run_upid"`
+	LastRunEndtime types.String `tfsdk:"last_run_endtime"`
+	NextRun        types.String `tfsdk:"next_run"`
 }
 
 // Metadata returns the data source type name.
@@ -105,6 +111,7 @@ func (d *verifyJobDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, jobStatusAttributes())
 }
 
 // Configure adds the provider configured client to the data source.
@@ -146,6 +153,16 @@ func (d *verifyJobDataSource) Read(ctx context.Context, req datasource.ReadReque
 	// Map API response to state
 	verifyJobToState(job, &state)
 
+	status, err := d.client.Jobs.GetVerifyJobStatus(ctx, job.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Verify Job Status",
+			fmt.Sprintf("Could not read run status of verify job %s: %s", job.ID, err.Error()),
+		)
+		return
+	}
+	state.LastRunState, state.LastRunUPID, state.LastRunEndtime, state.NextRun, state.LastRunFailed = jobStatusValues(status)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
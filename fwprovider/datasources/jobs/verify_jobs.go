@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -93,6 +94,7 @@ func (d *verifyJobsDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes["jobs"].(schema.ListNestedAttribute).NestedObject.Attributes, jobStatusAttributes())
 }
 
 // Configure adds the provider configured client to the data source.
@@ -137,6 +139,16 @@ func (d *verifyJobsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		storeFilter = state.Store.ValueString()
 	}
 
+	statuses, err := d.client.Jobs.ListVerifyJobStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Verify Job Status",
+			fmt.Sprintf("Could not list verify job run status: %s", err.Error()),
+		)
+		return
+	}
+	statusByID := jobStatusByID(statuses)
+
 	// Map API response to state
 	state.Jobs = make([]verifyJobDataSourceModel, 0)
 	for _, job := range jobs {
@@ -145,6 +157,7 @@ func (d *verifyJobsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		}
 		var jobModel verifyJobDataSourceModel
 		verifyJobToState(&job, &jobModel)
+		jobModel.LastRunState, jobModel.LastRunUPID, jobModel.LastRunEndtime, jobModel.NextRun, jobModel.LastRunFailed = jobStatusValues(statusByID[job.ID])
 		state.Jobs = append(state.Jobs, jobModel)
 	}
 
@@ -7,6 +7,7 @@
 package metrics
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -51,3 +52,25 @@ func TestMetricsServersDataSource(t *testing.T) {
 		t.Fatal("NewMetricsServersDataSource() did not return a datasource.DataSourceWithConfigure")
 	}
 }
+
+func TestMetricsStatusDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ds := NewMetricsStatusDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics: %v", resp.Diagnostics)
+	}
+	for _, name := range []string{"metrics", "host", "datastores"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("%s attribute should exist", name)
+		}
+		if !attr.IsComputed() {
+			t.Errorf("%s should be computed", name)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/metrics"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &metricsStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &metricsStatusDataSource{}
+)
+
+// NewMetricsStatusDataSource is a helper function to simplify the provider implementation.
+func NewMetricsStatusDataSource() datasource.DataSource {
+	return &metricsStatusDataSource{}
+}
+
+// metricsStatusDataSource is the data source implementation.
+type metricsStatusDataSource struct {
+	client *pbs.Client
+}
+
+// metricsStatusDataSourceModel maps the data source schema data.
+type metricsStatusDataSourceModel struct {
+	Metrics    []metricDataPointModel `tfsdk:"metrics"`
+	Host       types.Map              `tfsdk:"host"`
+	Datastores types.Map              `tfsdk:"datastores"`
+}
+
+// metricDataPointModel represents a single metric value
+type metricDataPointModel struct {
+	ID        types.String  `tfsdk:"id"`
+	Metric    types.String  `tfsdk:"metric"`
+	Type      types.String  `tfsdk:"type"`
+	Timestamp types.String  `tfsdk:"timestamp"`
+	Value     types.Float64 `tfsdk:"value"`
+}
+
+// Metadata returns the data source type name.
+func (d *metricsStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metrics_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *metricsStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the current host and datastore metrics that PBS sends to its metrics servers.",
+		MarkdownDescription: `Reads the current host and datastore metrics that PBS sends to its metrics servers.
+
+Use it to check what a ` + "`pbs_metrics_server`" + ` will receive, or to feed PBS metrics into checks and outputs
+without an external monitoring system.`,
+
+		Attributes: map[string]schema.Attribute{
+			"metrics": schema.ListNestedAttribute{
+				Description:         "All metric values, sorted by ID and metric name.",
+				MarkdownDescription: "All metric values, sorted by ID and metric name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "What the value describes: host or datastore/<name>.",
+							MarkdownDescription: "What the value describes: `host` or `datastore/<name>`.",
+							Computed:            true,
+						},
+						"metric": schema.StringAttribute{
+							Description:         "Metric name, e.g. cpu_current or used.",
+							MarkdownDescription: "Metric name, e.g. `cpu_current`, `mem_used` or `used`.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							Description:         "Metric type: gauge, counter or derive.",
+							MarkdownDescription: "Metric type: `gauge`, `counter` or `derive`.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description:         "Time the value was collected (RFC 3339).",
+							MarkdownDescription: "Time the value was collected (RFC 3339).",
+							Computed:            true,
+						},
+						"value": schema.Float64Attribute{
+							Description:         "Metric value.",
+							MarkdownDescription: "Metric value.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"host": schema.MapAttribute{
+				Description:         "Host metric values by metric name.",
+				MarkdownDescription: "Host metric values by metric name, e.g. `host[\"cpu_current\"]`.",
+				ElementType:         types.Float64Type,
+				Computed:            true,
+			},
+			"datastores": schema.MapAttribute{
+				Description:         "Datastore metric values by datastore name and metric name.",
+				MarkdownDescription: "Datastore metric values by datastore name and metric name, e.g. `datastores[\"backups\"][\"used\"]`.",
+				ElementType:         types.MapType{ElemType: types.Float64Type},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *metricsStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *metricsStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state metricsStatusDataSourceModel
+
+	points, err := d.client.Metrics.GetMetricsStatus(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Metrics Status",
+			fmt.Sprintf("Could not read metrics status: %s", err.Error()),
+		)
+		return
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].ID != points[j].ID {
+			return points[i].ID < points[j].ID
+		}
+		return points[i].Metric < points[j].Metric
+	})
+
+	host := map[string]float64{}
+	datastores := map[string]map[string]float64{}
+	state.Metrics = make([]metricDataPointModel, 0, len(points))
+	for _, point := range points {
+		state.Metrics = append(state.Metrics, metricDataPointModel{
+			ID:        types.StringValue(point.ID),
+			Metric:    types.StringValue(point.Metric),
+			Type:      stringValueOrNull(point.Type),
+			Timestamp: types.StringValue(time.Unix(point.Timestamp, 0).UTC().Format(time.RFC3339)),
+			Value:     types.Float64Value(point.Value),
+		})
+
+		if point.ID == metrics.MetricsHostID {
+			host[point.Metric] = point.Value
+		} else if store, ok := point.Datastore(); ok {
+			if datastores[store] == nil {
+				datastores[store] = map[string]float64{}
+			}
+			datastores[store][point.Metric] = point.Value
+		}
+	}
+
+	hostValue, diags := types.MapValueFrom(ctx, types.Float64Type, host)
+	resp.Diagnostics.Append(diags...)
+	datastoresValue, diags := types.MapValueFrom(ctx, types.MapType{ElemType: types.Float64Type}, datastores)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Host = hostValue
+	state.Datastores = datastoresValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,261 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package nodes provides Terraform data sources for PBS nodes
+package nodes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/nodes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &nodesDataSource{}
+	_ datasource.DataSourceWithConfigure = &nodesDataSource{}
+)
+
+// NewNodesDataSource is a helper function to simplify the provider implementation.
+func NewNodesDataSource() datasource.DataSource {
+	return &nodesDataSource{}
+}
+
+// nodesDataSource is the data source implementation.
+type nodesDataSource struct {
+	client *pbs.Client
+}
+
+// nodesDataSourceModel maps the data source schema data.
+type nodesDataSourceModel struct {
+	DefaultNode types.String `tfsdk:"default_node"`
+	Nodes       []nodeModel  `tfsdk:"nodes"`
+}
+
+// nodeModel represents a single node in the list
+type nodeModel struct {
+	Name        types.String  `tfsdk:"name"`
+	Status      types.String  `tfsdk:"status"`
+	Uptime      types.Int64   `tfsdk:"uptime"`
+	CPU         types.Float64 `tfsdk:"cpu"`
+	CPUModel    types.String  `tfsdk:"cpu_model"`
+	CPUs        types.Int64   `tfsdk:"cpus"`
+	CPUSockets  types.Int64   `tfsdk:"cpu_sockets"`
+	MemoryTotal types.Int64   `tfsdk:"memory_total"`
+	MemoryUsed  types.Int64   `tfsdk:"memory_used"`
+	RootTotal   types.Int64   `tfsdk:"root_total"`
+	RootUsed    types.Int64   `tfsdk:"root_used"`
+	RootAvail   types.Int64   `tfsdk:"root_avail"`
+	Kernel      types.String  `tfsdk:"kernel"`
+	PBSVersion  types.String  `tfsdk:"pbs_version"`
+}
+
+// Metadata returns the data source type name.
+func (d *nodesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nodes"
+}
+
+// Schema defines the schema for the data source.
+func (d *nodesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the nodes of a Proxmox Backup Server instance with their status.",
+		MarkdownDescription: `Lists the nodes of a Proxmox Backup Server instance with their status.
+
+Node facts are read from ` + "`/nodes/{node}/status`" + `. Node-scoped resources and data sources
+(disks, APT, subscription) use the only node when their ` + "`node`" + ` attribute is omitted.`,
+		Attributes: map[string]schema.Attribute{
+			"default_node": schema.StringAttribute{
+				Description:         "The node used when node is omitted, or null if the instance has more than one node.",
+				MarkdownDescription: "The node used when `node` is omitted, or null if the instance has more than one node.",
+				Computed:            true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description:         "List of nodes.",
+				MarkdownDescription: "List of nodes.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description:         "The node name.",
+							MarkdownDescription: "The node name.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "The node status reported by PBS.",
+							MarkdownDescription: "The node status reported by PBS (e.g. `online`).",
+							Computed:            true,
+						},
+						"uptime": schema.Int64Attribute{
+							Description:         "Uptime in seconds.",
+							MarkdownDescription: "Uptime in seconds.",
+							Computed:            true,
+						},
+						"cpu": schema.Float64Attribute{
+							Description:         "Current CPU usage as a fraction between 0 and 1.",
+							MarkdownDescription: "Current CPU usage as a fraction between 0 and 1.",
+							Computed:            true,
+						},
+						"cpu_model": schema.StringAttribute{
+							Description:         "The CPU model.",
+							MarkdownDescription: "The CPU model.",
+							Computed:            true,
+						},
+						"cpus": schema.Int64Attribute{
+							Description:         "Number of logical CPUs.",
+							MarkdownDescription: "Number of logical CPUs.",
+							Computed:            true,
+						},
+						"cpu_sockets": schema.Int64Attribute{
+							Description:         "Number of CPU sockets.",
+							MarkdownDescription: "Number of CPU sockets.",
+							Computed:            true,
+						},
+						"memory_total": schema.Int64Attribute{
+							Description:         "Total memory in bytes.",
+							MarkdownDescription: "Total memory in bytes.",
+							Computed:            true,
+						},
+						"memory_used": schema.Int64Attribute{
+							Description:         "Used memory in bytes.",
+							MarkdownDescription: "Used memory in bytes.",
+							Computed:            true,
+						},
+						"root_total": schema.Int64Attribute{
+							Description:         "Size of the root filesystem in bytes.",
+							MarkdownDescription: "Size of the root filesystem in bytes.",
+							Computed:            true,
+						},
+						"root_used": schema.Int64Attribute{
+							Description:         "Used space on the root filesystem in bytes.",
+							MarkdownDescription: "Used space on the root filesystem in bytes.",
+							Computed:            true,
+						},
+						"root_avail": schema.Int64Attribute{
+							Description:         "Available space on the root filesystem in bytes.",
+							MarkdownDescription: "Available space on the root filesystem in bytes.",
+							Computed:            true,
+						},
+						"kernel": schema.StringAttribute{
+							Description:         "The running kernel.",
+							MarkdownDescription: "The running kernel release (e.g. `6.14.8-2-pve`).",
+							Computed:            true,
+						},
+						"pbs_version": schema.StringAttribute{
+							Description:         "The Proxmox Backup Server version.",
+							MarkdownDescription: "The Proxmox Backup Server version (e.g. `4.0.14`).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nodesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *nodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state nodesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeList, err := d.client.Nodes.ListNodes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Nodes",
+			fmt.Sprintf("Could not list nodes: %s", err.Error()),
+		)
+		return
+	}
+
+	version, err := d.client.Nodes.GetVersion(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PBS Version",
+			fmt.Sprintf("Could not read PBS version: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Nodes = make([]nodeModel, 0, len(nodeList))
+	for _, node := range nodeList {
+		status, err := d.client.Nodes.GetNodeStatus(ctx, node.Node)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Node Status",
+				fmt.Sprintf("Could not read status of node %s: %s", node.Node, err.Error()),
+			)
+			return
+		}
+
+		state.Nodes = append(state.Nodes, nodeToModel(node.Node, node.Status, status, version))
+	}
+
+	state.DefaultNode = types.StringNull()
+	if len(nodeList) == 1 {
+		state.DefaultNode = types.StringValue(nodeList[0].Node)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// nodeToModel converts a node status to the data source model
+func nodeToModel(name, nodeStatus string, status *nodes.Status, version *nodes.Version) nodeModel {
+	kernel := status.KVersion
+	if status.CurrentKernel != nil && status.CurrentKernel.Release != "" {
+		kernel = status.CurrentKernel.Release
+	}
+
+	return nodeModel{
+		Name:        types.StringValue(name),
+		Status:      stringValueOrNull(nodeStatus),
+		Uptime:      types.Int64Value(status.Uptime),
+		CPU:         types.Float64Value(status.CPU),
+		CPUModel:    stringValueOrNull(status.CPUInfo.Model),
+		CPUs:        types.Int64Value(status.CPUInfo.CPUs),
+		CPUSockets:  types.Int64Value(status.CPUInfo.Sockets),
+		MemoryTotal: types.Int64Value(status.Memory.Total),
+		MemoryUsed:  types.Int64Value(status.Memory.Used),
+		RootTotal:   types.Int64Value(status.Root.Total),
+		RootUsed:    types.Int64Value(status.Root.Used),
+		RootAvail:   types.Int64Value(status.Root.Avail),
+		Kernel:      stringValueOrNull(kernel),
+		PBSVersion:  stringValueOrNull(version.String()),
+	}
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,54 @@
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/stretchr/testify/require"
+
+	"github.com/micah/terraform-provider-pbs/pbs/nodes"
+)
+
+func TestNodesDataSourceSchema(t *testing.T) {
+	ds := &nodesDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.Schema.Attributes)
+
+	defaultAttr, ok := resp.Schema.Attributes["default_node"]
+	require.True(t, ok, "default_node attribute should exist")
+	require.True(t, defaultAttr.IsComputed(), "default_node should be computed")
+
+	nodesAttr, ok := resp.Schema.Attributes["nodes"].(schema.ListNestedAttribute)
+	require.True(t, ok, "nodes should be a list nested attribute")
+	require.True(t, nodesAttr.IsComputed(), "nodes should be computed")
+
+	for _, name := range []string{"name", "status", "uptime", "cpu", "memory_total", "root_used", "kernel", "pbs_version"} {
+		_, ok := nodesAttr.NestedObject.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+	}
+}
+
+func TestNodeToModel(t *testing.T) {
+	status := &nodes.Status{
+		Uptime:        3600,
+		CPUInfo:       nodes.CPUInfo{Model: "Test CPU", CPUs: 8, Sockets: 1},
+		Memory:        nodes.Usage{Total: 1024, Used: 512},
+		KVersion:      "Linux 6.14.8-2-pve #1 SMP",
+		CurrentKernel: &nodes.Kernel{Release: "6.14.8-2-pve"},
+	}
+
+	m := nodeToModel("pbs1", "", status, &nodes.Version{Version: "4.0", Release: "14"})
+
+	require.Equal(t, "pbs1", m.Name.ValueString())
+	require.True(t, m.Status.IsNull())
+	require.Equal(t, int64(8), m.CPUs.ValueInt64())
+	require.Equal(t, "6.14.8-2-pve", m.Kernel.ValueString())
+	require.Equal(t, "4.0.14", m.PBSVersion.ValueString())
+}
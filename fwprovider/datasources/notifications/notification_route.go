@@ -0,0 +1,186 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/notifications"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &notificationRouteDataSource{}
+	_ datasource.DataSourceWithConfigure = &notificationRouteDataSource{}
+)
+
+// NewNotificationRouteDataSource is a helper function to simplify the provider implementation.
+func NewNotificationRouteDataSource() datasource.DataSource {
+	return &notificationRouteDataSource{}
+}
+
+// notificationRouteDataSource is the data source implementation.
+type notificationRouteDataSource struct {
+	client *pbs.Client
+}
+
+// notificationRouteDataSourceModel maps the data source schema data.
+type notificationRouteDataSourceModel struct {
+	Severity        types.String `tfsdk:"severity"`
+	Fields          types.Map    `tfsdk:"fields"`
+	Timestamp       types.String `tfsdk:"timestamp"`
+	MatchedMatchers types.List   `tfsdk:"matched_matchers"`
+	Targets         types.List   `tfsdk:"targets"`
+}
+
+// Metadata returns the data source type name.
+func (d *notificationRouteDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_route"
+}
+
+// Schema defines the schema for the data source.
+func (d *notificationRouteDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Simulates notification routing to show which matchers and targets a notification would reach.",
+		MarkdownDescription: `Simulates notification routing to show which matchers and targets a notification would reach.
+
+All notification matchers are read from Proxmox Backup Server and evaluated locally against the
+given severity, metadata fields and timestamp, following the same rules PBS applies when dispatching
+a notification (mode, ` + "`invert-match`" + `, severities, exact/regex field matches and calendar windows).
+Disabled matchers are skipped. No notification is sent.`,
+
+		Attributes: map[string]schema.Attribute{
+			"severity": schema.StringAttribute{
+				Description:         "Severity of the simulated notification.",
+				MarkdownDescription: "Severity of the simulated notification: `info`, `notice`, `warning`, `error` or `unknown`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(notifications.Severities...),
+				},
+			},
+			"fields": schema.MapAttribute{
+				Description:         "Metadata fields of the simulated notification.",
+				MarkdownDescription: "Metadata fields of the simulated notification (e.g., `{ type = \"gc\", datastore = \"store1\" }`).",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"timestamp": schema.StringAttribute{
+				Description:         "RFC 3339 timestamp of the simulated notification; defaults to the current time.",
+				MarkdownDescription: "RFC 3339 timestamp of the simulated notification, used for `match_calendar` windows. The time is evaluated in its own UTC offset. Defaults to the current time.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"matched_matchers": schema.ListAttribute{
+				Description:         "Names of the matchers that match the notification.",
+				MarkdownDescription: "Names of the matchers that match the notification, in the order PBS lists them.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"targets": schema.ListAttribute{
+				Description:         "Names of the targets the notification would be sent to.",
+				MarkdownDescription: "De-duplicated names of the targets the notification would be sent to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *notificationRouteDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *notificationRouteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state notificationRouteDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	event := notifications.Event{
+		Severity:  state.Severity.ValueString(),
+		Fields:    map[string]string{},
+		Timestamp: time.Now(),
+	}
+
+	if !state.Fields.IsNull() {
+		resp.Diagnostics.Append(state.Fields.ElementsAs(ctx, &event.Fields, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !state.Timestamp.IsNull() {
+		ts, err := time.Parse(time.RFC3339, state.Timestamp.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timestamp"),
+				"Invalid Timestamp",
+				fmt.Sprintf("Could not parse timestamp %q as RFC 3339: %s", state.Timestamp.ValueString(), err.Error()),
+			)
+			return
+		}
+		event.Timestamp = ts
+	}
+	state.Timestamp = types.StringValue(event.Timestamp.Format(time.RFC3339))
+
+	matchers, err := d.client.Notifications.ListNotificationMatchers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Notification Matchers",
+			fmt.Sprintf("Could not list notification matchers: %s", err.Error()),
+		)
+		return
+	}
+
+	result, err := notifications.RouteNotification(matchers, event)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Simulating Notification Route",
+			fmt.Sprintf("Could not evaluate notification matchers: %s", err.Error()),
+		)
+		return
+	}
+
+	matched, diags := types.ListValueFrom(ctx, types.StringType, result.Matchers)
+	resp.Diagnostics.Append(diags...)
+	targets, diags := types.ListValueFrom(ctx, types.StringType, result.Targets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.MatchedMatchers = matched
+	state.Targets = targets
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -47,3 +47,12 @@ func TestNotificationMatchersDataSource(t *testing.T) {
 	var _ datasource.DataSource = &notificationMatchersDataSource{}
 	var _ datasource.DataSourceWithConfigure = &notificationMatchersDataSource{}
 }
+
+// TestNotificationRouteDataSource verifies the data source implements required interfaces
+func TestNotificationRouteDataSource(t *testing.T) {
+	t.Parallel()
+
+	// Verify type assertion
+	var _ datasource.DataSource = &notificationRouteDataSource{}
+	var _ datasource.DataSourceWithConfigure = &notificationRouteDataSource{}
+}
@@ -0,0 +1,173 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package subscription provides Terraform data sources for PBS subscription status
+package subscription
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &subscriptionDataSource{}
+	_ datasource.DataSourceWithConfigure = &subscriptionDataSource{}
+)
+
+// NewSubscriptionDataSource is a helper function to simplify the provider implementation.
+func NewSubscriptionDataSource() datasource.DataSource {
+	return &subscriptionDataSource{}
+}
+
+// subscriptionDataSource is the data source implementation.
+type subscriptionDataSource struct {
+	client *pbs.Client
+}
+
+// subscriptionDataSourceModel maps the data source schema data.
+type subscriptionDataSourceModel struct {
+	Node        types.String `tfsdk:"node"`
+	Status      types.String `tfsdk:"status"`
+	Level       types.String `tfsdk:"level"`
+	ProductName types.String `tfsdk:"product_name"`
+	ServerID    types.String `tfsdk:"server_id"`
+	RegDate     types.String `tfsdk:"registration_date"`
+	NextDueDate types.String `tfsdk:"next_due_date"`
+	CheckTime   types.Int64  `tfsdk:"check_time"`
+	Message     types.String `tfsdk:"message"`
+}
+
+// Metadata returns the data source type name.
+func (d *subscriptionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscription"
+}
+
+// Schema defines the schema for the data source.
+func (d *subscriptionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Reads the subscription status of a Proxmox Backup Server node.",
+		MarkdownDescription: "Reads the subscription status of a Proxmox Backup Server node. The subscription key itself is not exposed.",
+
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node to query. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node to query. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				Description:         "The subscription status.",
+				MarkdownDescription: "The subscription status (`new`, `notfound`, `active`, `invalid`, `expired`, `suspended`).",
+				Computed:            true,
+			},
+			"level": schema.StringAttribute{
+				Description:         "The subscription level.",
+				MarkdownDescription: "The subscription level derived from the key (`community`, `basic`, `standard`, `premium`).",
+				Computed:            true,
+			},
+			"product_name": schema.StringAttribute{
+				Description:         "The subscribed product name.",
+				MarkdownDescription: "The subscribed product name.",
+				Computed:            true,
+			},
+			"server_id": schema.StringAttribute{
+				Description:         "The server ID of the node.",
+				MarkdownDescription: "The server ID of the node, needed when ordering a subscription.",
+				Computed:            true,
+			},
+			"registration_date": schema.StringAttribute{
+				Description:         "The subscription registration date.",
+				MarkdownDescription: "The subscription registration date.",
+				Computed:            true,
+			},
+			"next_due_date": schema.StringAttribute{
+				Description:         "The next subscription due date.",
+				MarkdownDescription: "The next subscription due date.",
+				Computed:            true,
+			},
+			"check_time": schema.Int64Attribute{
+				Description:         "Unix timestamp of the last subscription check.",
+				MarkdownDescription: "Unix timestamp of the last subscription check.",
+				Computed:            true,
+			},
+			"message": schema.StringAttribute{
+				Description:         "The status message returned by the last check.",
+				MarkdownDescription: "The status message returned by the last check.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *subscriptionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.DataSource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *subscriptionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state subscriptionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := d.client.Nodes.ResolveNode(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	state.Node = types.StringValue(node)
+
+	info, err := d.client.Subscription.GetSubscription(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Subscription",
+			fmt.Sprintf("Could not read subscription on node %s: %s", state.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Status = types.StringValue(info.Status)
+	state.Level = stringValueOrNull(info.Level())
+	state.ProductName = stringValueOrNull(info.ProductName)
+	state.ServerID = stringValueOrNull(info.ServerID)
+	state.RegDate = stringValueOrNull(info.RegDate)
+	state.NextDueDate = stringValueOrNull(info.NextDueDate)
+	state.CheckTime = types.Int64PointerValue(info.CheckTime)
+	state.Message = stringValueOrNull(info.Message)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,35 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionDataSourceSchema(t *testing.T) {
+	ds := &subscriptionDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.Schema.Attributes)
+
+	nodeAttr, ok := resp.Schema.Attributes["node"]
+	require.True(t, ok, "node attribute should exist")
+	require.True(t, nodeAttr.IsOptional(), "node should be optional")
+	require.True(t, nodeAttr.IsComputed(), "node should be computed from the default node")
+
+	// The key must never be exposed by the data source
+	_, ok = resp.Schema.Attributes["key"]
+	require.False(t, ok, "key attribute should not exist")
+
+	for _, name := range []string{"status", "level", "next_due_date", "server_id"} {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "%s attribute should exist", name)
+		require.True(t, attr.IsComputed(), "%s should be computed", name)
+	}
+}
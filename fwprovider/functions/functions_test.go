@@ -0,0 +1,95 @@
+package functions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/micah/terraform-provider-pbs/pbs/calendar"
+)
+
+func TestNextRunsFunction(t *testing.T) {
+	ctx := context.Background()
+	f := NewNextRunsFunction()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("mon..fri 02:00"),
+			types.Int64Value(3),
+			types.StringValue("2025-01-16T12:00:00Z"),
+		}),
+	}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ListUnknown(types.StringType))}
+	f.Run(ctx, req, resp)
+	require.Nil(t, resp.Error)
+
+	want, diags := types.ListValueFrom(ctx, types.StringType, []string{
+		"2025-01-17T02:00:00Z",
+		"2025-01-20T02:00:00Z",
+		"2025-01-21T02:00:00Z",
+	})
+	require.False(t, diags.HasError())
+	require.Equal(t, want, resp.Result.Value())
+}
+
+func TestNextRunsFunctionInvalidSchedule(t *testing.T) {
+	f := NewNextRunsFunction()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("0 3 * * *"),
+			types.Int64Value(1),
+			types.StringValue("2025-01-16T12:00:00Z"),
+		}),
+	}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ListUnknown(types.StringType))}
+	f.Run(context.Background(), req, resp)
+	require.NotNil(t, resp.Error)
+	require.NotNil(t, resp.Error.FunctionArgument)
+	require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+}
+
+func TestScheduleOverlapsFunction(t *testing.T) {
+	tests := []struct {
+		a, b, window string
+		want         bool
+	}{
+		{"daily", "01:00", "2h", true},
+		{"daily", "03:00", "2h", false},
+		{"sun 02:00", "mon..sat 02:00", "12h", false},
+		{"sun 23:00", "mon 00:30", "2h", true},
+		{"*-*-29 04:00", "*-02-29 04:10", "15m", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			f := NewScheduleOverlapsFunction()
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.a),
+					types.StringValue(tt.b),
+					types.StringValue(tt.window),
+				}),
+			}
+			resp := &function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+			f.Run(context.Background(), req, resp)
+			require.Nil(t, resp.Error)
+			require.Equal(t, types.BoolValue(tt.want), resp.Result.Value())
+		})
+	}
+}
+
+func TestSchedulesOverlapAcrossPeriodStart(t *testing.T) {
+	a, err := calendar.Parse("23:30")
+	require.NoError(t, err)
+	b, err := calendar.Parse("00:15")
+	require.NoError(t, err)
+
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, schedulesOverlap(a, b, time.Hour, start, start.Add(time.Hour)))
+}
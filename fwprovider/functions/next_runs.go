@@ -0,0 +1,101 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package functions provides Terraform provider-defined functions for PBS schedules
+package functions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs/calendar"
+)
+
+// maxNextRuns caps the number of trigger times next_runs computes in one call.
+const maxNextRuns = 1000
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &nextRunsFunction{}
+
+// NewNextRunsFunction is a helper function to simplify the provider implementation.
+func NewNextRunsFunction() function.Function {
+	return &nextRunsFunction{}
+}
+
+// nextRunsFunction is the function implementation.
+type nextRunsFunction struct{}
+
+// Metadata returns the function name.
+func (f *nextRunsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "next_runs"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *nextRunsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Computes the next trigger times of a PBS calendar event.",
+		MarkdownDescription: `Computes the next trigger times of a PBS calendar event schedule, such as the
+` + "`schedule`" + ` of a job or the ` + "`gc_schedule`" + ` of a datastore. Times are returned as RFC 3339
+strings in the schedule's time zone, or in the UTC offset of ` + "`from`" + ` when the schedule has none.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "schedule",
+				MarkdownDescription: "Calendar event (e.g., `daily`, `*/15`, `mon..fri 02:00`).",
+			},
+			function.Int64Parameter{
+				Name:                "count",
+				MarkdownDescription: fmt.Sprintf("Number of trigger times to compute (1 to %d).", maxNextRuns),
+			},
+			function.StringParameter{
+				Name:                "from",
+				MarkdownDescription: "RFC 3339 timestamp to start from; only trigger times strictly after it are returned. Use `timestamp()` for the current time.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Run computes the trigger times.
+func (f *nextRunsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schedule, from string
+	var count int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &schedule, &count, &from))
+	if resp.Error != nil {
+		return
+	}
+
+	event, err := calendar.Parse(schedule)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	if count < 1 || count > maxNextRuns {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("count must be between 1 and %d, got %d", maxNextRuns, count))
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("from must be an RFC 3339 timestamp: %s", err.Error()))
+		return
+	}
+
+	runs := event.NextN(start, int(count))
+	result := make([]string, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, run.Format(time.RFC3339))
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
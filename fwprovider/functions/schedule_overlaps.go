@@ -0,0 +1,130 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/micah/terraform-provider-pbs/pbs/calendar"
+)
+
+// overlapHorizonStart and overlapHorizon define the fixed period schedules are
+// compared over. A leap year starting on a Monday covers every weekday, day of
+// month and February 29, and keeps the function result deterministic.
+var (
+	overlapHorizonStart = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	overlapHorizon      = 366 * 24 * time.Hour
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &scheduleOverlapsFunction{}
+
+// NewScheduleOverlapsFunction is a helper function to simplify the provider implementation.
+func NewScheduleOverlapsFunction() function.Function {
+	return &scheduleOverlapsFunction{}
+}
+
+// scheduleOverlapsFunction is the function implementation.
+type scheduleOverlapsFunction struct{}
+
+// Metadata returns the function name.
+func (f *scheduleOverlapsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "schedule_overlaps"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *scheduleOverlapsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether two PBS calendar event schedules run within a time window of each other.",
+		MarkdownDescription: `Checks whether two PBS calendar event schedules run within a time window of each other.
+
+Returns ` + "`true`" + ` if any trigger time of ` + "`a`" + ` is less than ` + "`window`" + ` apart from a trigger time
+of ` + "`b`" + `, i.e. jobs expected to run for ` + "`window`" + ` would overlap. Both schedules are evaluated
+over the whole of 2024 (a leap year), in UTC unless a schedule specifies its own time zone, so the
+result does not depend on when Terraform runs.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "a",
+				MarkdownDescription: "First calendar event (e.g., a datastore `gc_schedule`).",
+			},
+			function.StringParameter{
+				Name:                "b",
+				MarkdownDescription: "Second calendar event (e.g., a verify job `schedule`).",
+			},
+			function.StringParameter{
+				Name:                "window",
+				MarkdownDescription: "Expected run duration as a Go duration string (e.g., `90m`, `2h`).",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run compares the schedules.
+func (f *scheduleOverlapsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b, window string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &a, &b, &window))
+	if resp.Error != nil {
+		return
+	}
+
+	eventA, err := calendar.Parse(a)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	eventB, err := calendar.Parse(b)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	duration, err := time.ParseDuration(window)
+	if err != nil || duration <= 0 {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("window must be a positive duration such as \"2h\", got %q", window))
+		return
+	}
+
+	overlaps := schedulesOverlap(eventA, eventB, duration, overlapHorizonStart, overlapHorizonStart.Add(overlapHorizon))
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, overlaps))
+}
+
+// schedulesOverlap walks both schedules in time order and reports whether any
+// pair of trigger times in [start, end) is less than window apart. Runs of a
+// schedule are started slightly before the period so that a run shortly before
+// start still conflicts with one shortly after it.
+func schedulesOverlap(a, b *calendar.Event, window time.Duration, start, end time.Time) bool {
+	from := start.Add(-window)
+
+	ta, okA := a.Next(from)
+	tb, okB := b.Next(from)
+
+	for okA && okB && (ta.Before(end) || tb.Before(end)) {
+		diff := ta.Sub(tb)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < window {
+			return true
+		}
+
+		// Advancing the earlier run keeps the closest candidate pair in view
+		if ta.Before(tb) {
+			ta, okA = a.Next(ta)
+		} else {
+			tb, okB = b.Next(tb)
+		}
+	}
+
+	return false
+}
@@ -15,7 +15,9 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -24,26 +26,38 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	actionsdatastores "github.com/micah/terraform-provider-pbs/fwprovider/actions/datastores"
+	actionsjobs "github.com/micah/terraform-provider-pbs/fwprovider/actions/jobs"
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	datasourcesapt "github.com/micah/terraform-provider-pbs/fwprovider/datasources/apt"
 	datasourcesdatastores "github.com/micah/terraform-provider-pbs/fwprovider/datasources/datastores"
+	datasourcesdisks "github.com/micah/terraform-provider-pbs/fwprovider/datasources/disks"
 	datasourcesendpoints "github.com/micah/terraform-provider-pbs/fwprovider/datasources/endpoints"
 	datasourcesjobs "github.com/micah/terraform-provider-pbs/fwprovider/datasources/jobs"
 	datasourcesmetrics "github.com/micah/terraform-provider-pbs/fwprovider/datasources/metrics"
+	datasourcesnodes "github.com/micah/terraform-provider-pbs/fwprovider/datasources/nodes"
 	datasourcesnotifications "github.com/micah/terraform-provider-pbs/fwprovider/datasources/notifications"
 	"github.com/micah/terraform-provider-pbs/fwprovider/datasources/remotes"
+	datasourcessubscription "github.com/micah/terraform-provider-pbs/fwprovider/datasources/subscription"
+	"github.com/micah/terraform-provider-pbs/fwprovider/functions"
+	"github.com/micah/terraform-provider-pbs/fwprovider/resources/apt"
 	"github.com/micah/terraform-provider-pbs/fwprovider/resources/datastores"
+	"github.com/micah/terraform-provider-pbs/fwprovider/resources/disks"
 	"github.com/micah/terraform-provider-pbs/fwprovider/resources/endpoints"
 	"github.com/micah/terraform-provider-pbs/fwprovider/resources/jobs"
 	"github.com/micah/terraform-provider-pbs/fwprovider/resources/metrics"
 	"github.com/micah/terraform-provider-pbs/fwprovider/resources/notifications"
 	remotesresources "github.com/micah/terraform-provider-pbs/fwprovider/resources/remotes"
+	"github.com/micah/terraform-provider-pbs/fwprovider/resources/subscription"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/api"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &pbsProvider{}
+	_ provider.Provider              = &pbsProvider{}
+	_ provider.ProviderWithFunctions = &pbsProvider{}
+	_ provider.ProviderWithActions   = &pbsProvider{}
 )
 
 // pbsProvider defines the provider implementation.
@@ -226,13 +240,15 @@ func (p *pbsProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	// Make the PBS client available during DataSource and Resource
+	// Make the PBS client available during DataSource, Resource and Action
 	// type Configure methods.
 	resourceConfig := &config.Resource{Client: client}
 	datasourceConfig := &config.DataSource{Client: client}
+	actionConfig := &config.Action{Client: client}
 
 	resp.DataSourceData = datasourceConfig
 	resp.ResourceData = resourceConfig
+	resp.ActionData = actionConfig
 
 	tflog.Info(ctx, "Configured PBS provider", map[string]any{"success": true})
 }
@@ -247,24 +263,38 @@ func (p *pbsProvider) DataSources(_ context.Context) []func() datasource.DataSou
 		// Datastores
 		datasourcesdatastores.NewDatastoreDataSource,
 		datasourcesdatastores.NewDatastoresDataSource,
+		datasourcesdatastores.NewPruneSimulationDataSource,
+		// Disks
+		datasourcesdisks.NewDisksDataSource,
+		// APT
+		datasourcesapt.NewAPTUpdatesDataSource,
+		// Nodes
+		datasourcesnodes.NewNodesDataSource,
+		// Subscription
+		datasourcessubscription.NewSubscriptionDataSource,
 		// Endpoints
 		datasourcesendpoints.NewS3EndpointDataSource,
 		datasourcesendpoints.NewS3EndpointsDataSource,
+		datasourcesendpoints.NewS3BucketsDataSource,
 		// Jobs
 		datasourcesjobs.NewPruneJobDataSource,
 		datasourcesjobs.NewPruneJobsDataSource,
 		datasourcesjobs.NewSyncJobDataSource,
 		datasourcesjobs.NewSyncJobsDataSource,
+		datasourcesjobs.NewSyncJobPreviewDataSource,
+		datasourcesjobs.NewSyncJobGroupsDataSource,
 		datasourcesjobs.NewVerifyJobDataSource,
 		datasourcesjobs.NewVerifyJobsDataSource,
 		// Metrics
 		datasourcesmetrics.NewMetricsServerDataSource,
 		datasourcesmetrics.NewMetricsServersDataSource,
+		datasourcesmetrics.NewMetricsStatusDataSource,
 		// Notifications
 		datasourcesnotifications.NewNotificationEndpointDataSource,
 		datasourcesnotifications.NewNotificationEndpointsDataSource,
 		datasourcesnotifications.NewNotificationMatcherDataSource,
 		datasourcesnotifications.NewNotificationMatchersDataSource,
+		datasourcesnotifications.NewNotificationRouteDataSource,
 	}
 }
 
@@ -277,6 +307,14 @@ func (p *pbsProvider) Resources(_ context.Context) []func() resource.Resource {
 		remotesresources.NewRemoteResource,
 		// Datastores
 		datastores.NewDatastoreResource,
+		// Disks
+		disks.NewZFSPoolResource,
+		disks.NewDirectoryResource,
+		disks.NewInitializeResource,
+		// APT
+		apt.NewAPTRepositoryResource,
+		// Subscription
+		subscription.NewSubscriptionResource,
 		// Metrics
 		metrics.NewMetricsServerResource,
 		// Notifications - Targets
@@ -292,3 +330,26 @@ func (p *pbsProvider) Resources(_ context.Context) []func() resource.Resource {
 		jobs.NewVerifyJobResource,
 	}
 }
+
+// Functions defines the provider-defined functions implemented in the provider.
+func (p *pbsProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		// Schedules
+		functions.NewNextRunsFunction,
+		functions.NewScheduleOverlapsFunction,
+	}
+}
+
+// Actions defines the actions implemented in the provider. Actions require
+// Terraform 1.14 or later; older versions ignore them.
+func (p *pbsProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		// Datastores
+		actionsdatastores.NewGCAction,
+		actionsdatastores.NewVerifyAction,
+		actionsdatastores.NewPruneAction,
+
+		// Jobs
+		actionsjobs.NewRunJobAction,
+	}
+}
@@ -0,0 +1,327 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package apt provides Terraform resources for PBS APT repository management
+package apt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/apt"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &aptRepositoryResource{}
+	_ resource.ResourceWithConfigure   = &aptRepositoryResource{}
+	_ resource.ResourceWithImportState = &aptRepositoryResource{}
+)
+
+// NewAPTRepositoryResource is a helper function to simplify the provider implementation.
+func NewAPTRepositoryResource() resource.Resource {
+	return &aptRepositoryResource{}
+}
+
+// aptRepositoryResource is the resource implementation.
+type aptRepositoryResource struct {
+	client *pbs.Client
+}
+
+// aptRepositoryResourceModel maps the resource schema data.
+type aptRepositoryResourceModel struct {
+	Node    types.String `tfsdk:"node"`
+	Handle  types.String `tfsdk:"handle"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Name    types.String `tfsdk:"name"`
+	Path    types.String `tfsdk:"file_path"`
+	Index   types.Int64  `tfsdk:"index"`
+}
+
+// Metadata returns the resource type name.
+func (r *aptRepositoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apt_repository"
+}
+
+// Schema defines the schema for the resource.
+func (r *aptRepositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a standard Proxmox APT repository on a PBS node.",
+		MarkdownDescription: `Manages a standard Proxmox APT repository on a PBS node.
+
+If the repository is not yet configured it is added to the node's sources; otherwise the existing
+entry is enabled or disabled in place. PBS has no API to remove repository entries, so destroying
+this resource disables the repository instead.`,
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node to configure. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node to configure. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"handle": schema.StringAttribute{
+				Description:         "The standard repository handle.",
+				MarkdownDescription: "The standard repository handle. Valid values: `enterprise`, `no-subscription`, `test`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(apt.StandardRepoHandles...),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether the repository is enabled.",
+				MarkdownDescription: "Whether the repository is enabled. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"name": schema.StringAttribute{
+				Description:         "The display name of the repository.",
+				MarkdownDescription: "The display name of the repository as reported by PBS.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"file_path": schema.StringAttribute{
+				Description:         "The sources file that contains the repository entry.",
+				MarkdownDescription: "The sources file that contains the repository entry.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"index": schema.Int64Attribute{
+				Description:         "The index of the repository entry within its sources file.",
+				MarkdownDescription: "The index of the repository entry within its sources file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aptRepositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *config.Resource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create adds or enables the repository and sets the initial Terraform state.
+func (r *aptRepositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan aptRepositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := r.client.Nodes.ResolveNode(ctx, plan.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	plan.Node = types.StringValue(node)
+
+	if err := r.apply(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error configuring APT repository",
+			fmt.Sprintf("Could not configure APT repository %s on node %s: %s", plan.Handle.ValueString(), plan.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *aptRepositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state aptRepositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repos, err := r.client.APT.GetRepositories(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading APT repository",
+			fmt.Sprintf("Could not read APT repositories on node %s: %s", state.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	std := repos.StandardRepository(state.Handle.ValueString())
+	if std == nil || std.Status == nil {
+		tflog.Info(ctx, "APT repository is no longer configured, removing from state", map[string]any{"handle": state.Handle.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setAPTRepositoryState(repos, std, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update enables or disables the repository.
+func (r *aptRepositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan aptRepositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating APT repository",
+			fmt.Sprintf("Could not update APT repository %s on node %s: %s", plan.Handle.ValueString(), plan.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete disables the repository; PBS cannot remove repository entries through the API.
+func (r *aptRepositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state aptRepositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repos, err := r.client.APT.GetRepositories(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting APT repository",
+			fmt.Sprintf("Could not read APT repositories on node %s: %s", state.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	loc, repo := repos.FindStandardRepository(state.Handle.ValueString())
+	if loc == nil || !repo.Enabled {
+		return
+	}
+
+	if err := r.client.APT.SetRepositoryEnabled(ctx, state.Node.ValueString(), *loc, false, repos.Digest); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting APT repository",
+			fmt.Sprintf("Could not disable APT repository %s: %s", state.Handle.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource using the "node/handle" format.
+func (r *aptRepositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format \"node/handle\", got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("handle"), parts[1])...)
+}
+
+// apply brings the repository to the planned enabled state and refreshes the computed attributes.
+func (r *aptRepositoryResource) apply(ctx context.Context, plan *aptRepositoryResourceModel) error {
+	node := plan.Node.ValueString()
+	handle := plan.Handle.ValueString()
+	enabled := plan.Enabled.ValueBool()
+
+	repos, err := r.client.APT.GetRepositories(ctx, node)
+	if err != nil {
+		return err
+	}
+
+	loc, repo := repos.FindStandardRepository(handle)
+	if loc == nil {
+		// PBS always adds standard repositories in the enabled state
+		if err := r.client.APT.AddStandardRepository(ctx, node, handle, repos.Digest); err != nil {
+			return err
+		}
+
+		repos, err = r.client.APT.GetRepositories(ctx, node)
+		if err != nil {
+			return err
+		}
+		loc, repo = repos.FindStandardRepository(handle)
+		if loc == nil {
+			return fmt.Errorf("repository %s was added but no matching sources entry was found", handle)
+		}
+	}
+
+	if repo.Enabled != enabled {
+		if err := r.client.APT.SetRepositoryEnabled(ctx, node, *loc, enabled, repos.Digest); err != nil {
+			return err
+		}
+	}
+
+	repos, err = r.client.APT.GetRepositories(ctx, node)
+	if err != nil {
+		return err
+	}
+
+	std := repos.StandardRepository(handle)
+	if std == nil || std.Status == nil {
+		return fmt.Errorf("repository %s is not reported as configured after applying changes", handle)
+	}
+
+	setAPTRepositoryState(repos, std, plan)
+	return nil
+}
+
+func setAPTRepositoryState(repos *apt.Repositories, std *apt.StandardRepository, state *aptRepositoryResourceModel) {
+	state.Enabled = types.BoolValue(*std.Status)
+	state.Name = types.StringValue(std.Name)
+
+	if loc, _ := repos.FindStandardRepository(std.Handle); loc != nil {
+		state.Path = types.StringValue(loc.Path)
+		state.Index = types.Int64Value(int64(loc.Index))
+	} else {
+		state.Path = types.StringNull()
+		state.Index = types.Int64Null()
+	}
+}
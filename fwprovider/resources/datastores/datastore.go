@@ -13,7 +13,6 @@ import (
 	"os"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
@@ -30,8 +29,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+	"github.com/micah/terraform-provider-pbs/pbs/endpoints"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -39,10 +40,6 @@ var (
 	_ resource.Resource                = &datastoreResource{}
 	_ resource.ResourceWithConfigure   = &datastoreResource{}
 	_ resource.ResourceWithImportState = &datastoreResource{}
-
-	// datastoreMutex prevents concurrent datastore operations that would conflict
-	// with PBS's exclusive lock on /etc/proxmox-backup/.datastore.lck
-	datastoreMutex sync.Mutex
 )
 
 // NewDatastoreResource is a helper function to simplify the provider implementation.
@@ -89,6 +86,10 @@ type datastoreResourceModel struct {
 	// S3 backend options
 	S3Client types.String `tfsdk:"s3_client"`
 	S3Bucket types.String `tfsdk:"s3_bucket"`
+
+	// Provider-side lifecycle options (not sent to PBS)
+	DestroyDataOnDelete types.Bool `tfsdk:"destroy_data_on_delete"`
+	DeletionProtection  types.Bool `tfsdk:"deletion_protection"`
 }
 
 type maintenanceModeModel struct {
@@ -182,15 +183,21 @@ func (r *datastoreResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Default:             booldefault.StaticBool(false),
 			},
 			"gc_schedule": schema.StringAttribute{
-				Description:         "Garbage collection schedule in cron format.",
-				MarkdownDescription: "Garbage collection schedule in cron format (e.g., `daily`, `weekly`, or `0 3 * * 0`).",
+				Description:         "Garbage collection schedule in calendar event format.",
+				MarkdownDescription: "Garbage collection schedule in calendar event format (e.g., `daily`, `weekly`, or `sun 03:00`).",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.CalendarEvent(),
+				},
 			},
 			"prune_schedule": schema.StringAttribute{
-				Description:         "Prune schedule in cron format.",
-				MarkdownDescription: "Prune schedule in cron format (e.g., `daily`, `weekly`, or `0 2 * * *`).",
+				Description:         "Prune schedule in calendar event format.",
+				MarkdownDescription: "Prune schedule in calendar event format (e.g., `daily`, `weekly`, or `02:00`).",
 				Optional:            true,
-				DeprecationMessage:  "Removed in PBS 4.0+. Configure prune jobs with the pbs_prune_job resource instead.",
+				Validators: []validator.String{
+					validators.CalendarEvent(),
+				},
+				DeprecationMessage: "Removed in PBS 4.0+. Configure prune jobs with the pbs_prune_job resource instead.",
 			},
 			"keep_last": schema.Int64Attribute{
 				Description:         "Number of latest backups to keep.",
@@ -337,6 +344,18 @@ func (r *datastoreResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				MarkdownDescription: "Allow overwriting chunks that are currently in use.",
 				Optional:            true,
 			},
+			"destroy_data_on_delete": schema.BoolAttribute{
+				Description:         "Remove the datastore contents from disk when the resource is destroyed.",
+				MarkdownDescription: "Remove the datastore contents (chunks, snapshots) from disk when the resource is destroyed. When unset, the `PBS_DESTROY_DATA_ON_DELETE` environment variable is used as a fallback; otherwise only the configuration is removed.",
+				Optional:            true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description:         "Prevent the datastore from being destroyed.",
+				MarkdownDescription: "Prevent the datastore from being destroyed. Destroy fails with an error until this is set to `false` and applied. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"tuning": schema.SingleNestedAttribute{
 				Description:         "Advanced tuning options for datastore behaviour.",
 				MarkdownDescription: "Advanced tuning options for datastore behaviour such as chunk order and sync level.",
@@ -468,24 +487,17 @@ func (r *datastoreResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	tflog.Debug(ctx, "Acquiring datastore creation mutex", map[string]any{
-		"name": plan.Name.ValueString(),
-	})
-
-	// Lock only for the create operation to prevent PBS lock contention
-	// Don't hold the lock during the post-create retries
-	datastoreMutex.Lock()
-	tflog.Debug(ctx, "Mutex acquired, calling createDatastoreWithRetry", map[string]any{
-		"name": plan.Name.ValueString(),
-	})
-
 	startCreate := time.Now()
 	tflog.Info(ctx, "⏱️ TIMING: Starting CreateDatastore", map[string]any{
 		"name":      plan.Name.ValueString(),
 		"timestamp": startCreate.Format(time.RFC3339Nano),
 	})
 
-	err = r.createDatastoreWithRetry(ctx, datastore)
+	// Hold the datastore config lock only for the create call, not the post-create reads
+	err = r.client.WithConfigLock(ctx, pbs.LockDomainDatastore, func() error {
+		return r.client.Datastores.CreateDatastore(ctx, datastore)
+	})
+	err = explainDatastoreTaskFailure(ctx, datastore.Name, err)
 
 	endCreate := time.Now()
 	tflog.Info(ctx, "⏱️ TIMING: CreateDatastore completed", map[string]any{
@@ -495,12 +507,6 @@ func (r *datastoreResource) Create(ctx context.Context, req resource.CreateReque
 		"success":   err == nil,
 	})
 
-	datastoreMutex.Unlock()
-	tflog.Debug(ctx, "Mutex released after create operation", map[string]any{
-		"name":    plan.Name.ValueString(),
-		"success": err == nil,
-	})
-
 	if err != nil {
 		tflog.Error(ctx, "Failed to create datastore", map[string]any{
 			"name":  plan.Name.ValueString(),
@@ -694,6 +700,11 @@ func (r *datastoreResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// deletion_protection is provider-side only; default it for imported resources
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -731,10 +742,9 @@ func (r *datastoreResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// Lock only for the update operation to prevent PBS lock contention
-	datastoreMutex.Lock()
-	err = r.client.Datastores.UpdateDatastore(ctx, plan.Name.ValueString(), datastore)
-	datastoreMutex.Unlock()
+	err = r.client.WithConfigLock(ctx, pbs.LockDomainDatastore, func() error {
+		return r.client.Datastores.UpdateDatastore(ctx, plan.Name.ValueString(), datastore)
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -761,14 +771,18 @@ func (r *datastoreResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	// Delete existing datastore
-	// Check if we should destroy data (useful for tests)
-	destroyData := os.Getenv("PBS_DESTROY_DATA_ON_DELETE") == "true"
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Datastore Is Protected",
+			fmt.Sprintf("Datastore %s has deletion_protection enabled. Set deletion_protection = false and apply before destroying it.", state.Name.ValueString()),
+		)
+		return
+	}
 
-	// Lock only for the delete operation to prevent PBS lock contention
-	datastoreMutex.Lock()
-	err := r.client.Datastores.DeleteDatastoreWithOptions(ctx, state.Name.ValueString(), destroyData)
-	datastoreMutex.Unlock()
+	// Delete existing datastore
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainDatastore, func() error {
+		return r.client.Datastores.DeleteDatastoreWithOptions(ctx, state.Name.ValueString(), destroyDataOnDelete(&state))
+	})
 
 	if err != nil {
 		// Check if the datastore is already gone (desired state achieved)
@@ -800,6 +814,15 @@ func (r *datastoreResource) ImportState(ctx context.Context, req resource.Import
 
 // Helper functions
 
+// destroyDataOnDelete reports whether deleting the datastore also removes its data.
+// The per-resource setting wins; the environment variable is only a fallback for older configurations.
+func destroyDataOnDelete(state *datastoreResourceModel) bool {
+	if !state.DestroyDataOnDelete.IsNull() && !state.DestroyDataOnDelete.IsUnknown() {
+		return state.DestroyDataOnDelete.ValueBool()
+	}
+	return os.Getenv("PBS_DESTROY_DATA_ON_DELETE") == "true"
+}
+
 // validateDatastoreConfig validates configuration requirements that span backend types
 func (r *datastoreResource) validateDatastoreConfig(plan *datastoreResourceModel) error {
 	if !plan.PruneSchedule.IsNull() && !plan.PruneSchedule.IsUnknown() && strings.TrimSpace(plan.PruneSchedule.ValueString()) != "" {
@@ -1469,74 +1492,47 @@ func (r *datastoreResource) datastoreToState(ds *datastores.Datastore, state *da
 	return nil
 }
 
-// createDatastoreWithRetry attempts to create a datastore with retry logic for PBS lock contention
-func (r *datastoreResource) createDatastoreWithRetry(ctx context.Context, datastore *datastores.Datastore) error {
-	maxRetries := 3
-	baseDelay := 2 * time.Second
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		err := r.client.Datastores.CreateDatastore(ctx, datastore)
-		if err == nil {
-			return nil
-		}
-
-		// Check if this is a lock contention error or task failure
-		errorMsg := err.Error()
-
-		// Log detailed information for task failures
-		if strings.Contains(errorMsg, "task failed") {
-			// Try to extract UPID from error message
-			// PBS task errors often contain format like "UPID:node:00001234:..."
-			upid := "unknown"
-			if strings.Contains(errorMsg, "UPID:") {
-				// Extract UPID from error message
-				parts := strings.Split(errorMsg, "UPID:")
-				if len(parts) > 1 {
-					upidPart := strings.Split(parts[1], " ")[0]
-					if upidPart != "" {
-						upid = "UPID:" + upidPart
-					}
-				}
-			}
-
-			// Check for known compatibility issues
-			isBackblazeCompatIssue := strings.Contains(errorMsg, "501") &&
-				strings.Contains(errorMsg, "Not Implemented") &&
-				strings.Contains(errorMsg, "access time safety check")
-
-			logLevel := "Error"
-			if isBackblazeCompatIssue {
-				logLevel = "Warn" // Known issue, not provider error
-			}
+// explainDatastoreTaskFailure logs details of a failed datastore creation task
+// and flags known S3 provider incompatibilities that retrying cannot fix.
+func explainDatastoreTaskFailure(ctx context.Context, name string, err error) error {
+	if err == nil {
+		return nil
+	}
 
-			tflog.Error(ctx, fmt.Sprintf("PBS task failed (%s)", logLevel), map[string]any{
-				"error":                     errorMsg,
-				"upid":                      upid,
-				"attempt":                   attempt,
-				"datastore":                 datastore.Name,
-				"known_compatibility_issue": isBackblazeCompatIssue,
-			})
+	errorMsg := err.Error()
+	if !strings.Contains(errorMsg, "task failed") {
+		return err
+	}
 
-			// For known Backblaze compatibility issues, don't retry
-			if isBackblazeCompatIssue {
-				return fmt.Errorf("known compatibility issue: %s", errorMsg)
-			}
+	// PBS task errors often contain format like "UPID:node:00001234:..."
+	upid := "unknown"
+	if parts := strings.Split(errorMsg, "UPID:"); len(parts) > 1 {
+		if upidPart := strings.Split(parts[1], " ")[0]; upidPart != "" {
+			upid = "UPID:" + upidPart
 		}
+	}
 
-		isLockError := strings.Contains(errorMsg, "Unable to acquire lock") ||
-			strings.Contains(errorMsg, "Interrupted system call") ||
-			strings.Contains(errorMsg, ".datastore.lck")
-
-		if isLockError && attempt < maxRetries {
-			// Exponential backoff with jitter
-			delay := baseDelay * time.Duration(attempt)
-			time.Sleep(delay)
-			continue
-		}
+	// Check for known S3 compatibility issues, e.g. Backblaze B2 answering the
+	// access time safety check with "501 Not Implemented"
+	diagnosis := endpoints.DiagnoseS3Error(err)
 
-		// Not a lock error or final attempt - return error
-		return err
+	logLevel := "Error"
+	if diagnosis.IsProviderQuirk() {
+		logLevel = "Warn" // Known issue, not provider error
 	}
 
-	return fmt.Errorf("failed to create datastore after %d attempts", maxRetries)
+	tflog.Error(ctx, fmt.Sprintf("PBS task failed (%s)", logLevel), map[string]any{
+		"error":                     errorMsg,
+		"upid":                      upid,
+		"datastore":                 name,
+		"known_compatibility_issue": diagnosis.IsProviderQuirk(),
+	})
+
+	switch {
+	case diagnosis.IsProviderQuirk():
+		return fmt.Errorf("known compatibility issue: %s\n\n%s Set verify_bucket on the pbs_s3_endpoint to catch this before creating datastores.", errorMsg, diagnosis.Hint)
+	case diagnosis != nil:
+		return fmt.Errorf("%w\n\n%s", err, diagnosis.Hint)
+	}
+	return err
 }
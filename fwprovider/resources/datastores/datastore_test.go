@@ -0,0 +1,70 @@
+package datastores
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDatastoreDeleteRefusedWhenProtected(t *testing.T) {
+	ctx := context.Background()
+	r := &datastoreResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.SetAttribute(ctx, path.Root("name"), "backups"); diags.HasError() {
+		t.Fatalf("SetAttribute(name) = %v", diags)
+	}
+	if diags := state.SetAttribute(ctx, path.Root("deletion_protection"), true); diags.HasError() {
+		t.Fatalf("SetAttribute(deletion_protection) = %v", diags)
+	}
+
+	// The resource has no client, so reaching the API would panic
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected Delete to refuse a protected datastore")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Datastore Is Protected" {
+		t.Errorf("error summary = %q, want %q", summary, "Datastore Is Protected")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "backups") {
+		t.Errorf("error detail %q does not name the datastore", detail)
+	}
+}
+
+func TestDestroyDataOnDelete(t *testing.T) {
+	tests := map[string]struct {
+		value types.Bool
+		env   string
+		want  bool
+	}{
+		"unset":                     {value: types.BoolNull(), want: false},
+		"enabled":                   {value: types.BoolValue(true), want: true},
+		"environment fallback":      {value: types.BoolNull(), env: "true", want: true},
+		"setting wins over env":     {value: types.BoolValue(false), env: "true", want: false},
+		"unknown uses env fallback": {value: types.BoolUnknown(), env: "true", want: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("PBS_DESTROY_DATA_ON_DELETE", tc.env)
+			state := &datastoreResourceModel{DestroyDataOnDelete: tc.value}
+			if got := destroyDataOnDelete(state); got != tc.want {
+				t.Errorf("destroyDataOnDelete() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
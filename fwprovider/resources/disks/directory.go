@@ -0,0 +1,344 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/disks"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &directoryResource{}
+	_ resource.ResourceWithConfigure   = &directoryResource{}
+	_ resource.ResourceWithImportState = &directoryResource{}
+)
+
+// NewDirectoryResource is a helper function to simplify the provider implementation.
+func NewDirectoryResource() resource.Resource {
+	return &directoryResource{}
+}
+
+// directoryResource is the resource implementation.
+type directoryResource struct {
+	client *pbs.Client
+}
+
+// directoryResourceModel maps the resource schema data.
+type directoryResourceModel struct {
+	Node         types.String `tfsdk:"node"`
+	Name         types.String `tfsdk:"name"`
+	Disk         types.String `tfsdk:"disk"`
+	Filesystem   types.String `tfsdk:"filesystem"`
+	AddDatastore types.Bool   `tfsdk:"add_datastore"`
+	Path         types.String `tfsdk:"path"`
+	Device       types.String `tfsdk:"device"`
+}
+
+// Metadata returns the resource type name.
+func (r *directoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_disk_directory"
+}
+
+// Schema defines the schema for the resource.
+func (r *directoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Formats an unused disk and mounts it as a datastore directory on a PBS node.",
+		MarkdownDescription: `Formats an unused disk and mounts it as a datastore directory on a PBS node.
+
+The disk is partitioned, formatted with the chosen filesystem and mounted under
+` + "`/mnt/datastore/<name>`" + ` via a systemd mount unit. Destroying this resource removes the mount unit;
+the filesystem and its data remain on the disk.`,
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node that owns the disk. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node that owns the disk. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "The name of the directory mount.",
+				MarkdownDescription: "The name of the directory mount. Also used as the datastore name when `add_datastore` is set.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(3, 32),
+					stringvalidator.RegexMatches(storageNameRegex, "must start with a letter and contain only letters, numbers, '-', '_' and '.'"),
+				},
+			},
+			"disk": schema.StringAttribute{
+				Description:         "The unused disk to format (e.g., sdb).",
+				MarkdownDescription: "The unused disk to format (e.g., `sdb`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filesystem": schema.StringAttribute{
+				Description:         "The filesystem to create on the disk.",
+				MarkdownDescription: "The filesystem to create on the disk. Valid values: `ext4`, `xfs`. Defaults to `ext4`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("ext4"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("ext4", "xfs"),
+				},
+			},
+			"add_datastore": schema.BoolAttribute{
+				Description:         "Register the directory as a datastore after creation.",
+				MarkdownDescription: "Register the directory as a datastore named after the mount. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description:         "The mount point of the directory.",
+				MarkdownDescription: "The mount point of the directory (e.g., `/mnt/datastore/<name>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device": schema.StringAttribute{
+				Description:         "The block device backing the mount.",
+				MarkdownDescription: "The block device backing the mount (e.g., `/dev/sdb1`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *directoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *config.Resource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *directoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan directoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := r.client.Nodes.ResolveNode(ctx, plan.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	plan.Node = types.StringValue(node)
+
+	dir := &disks.DirectoryCreate{
+		Name:       plan.Name.ValueString(),
+		Disk:       plan.Disk.ValueString(),
+		Filesystem: plan.Filesystem.ValueString(),
+	}
+	if !plan.AddDatastore.IsNull() && !plan.AddDatastore.IsUnknown() {
+		addDatastore := plan.AddDatastore.ValueBool()
+		dir.AddDatastore = &addDatastore
+	}
+
+	err = withDatastoreLock(ctx, r.client, plan.AddDatastore, func() error {
+		return r.client.Disks.CreateDirectory(ctx, plan.Node.ValueString(), dir)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating directory",
+			fmt.Sprintf("Could not create directory %s on node %s: %s", plan.Name.ValueString(), plan.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	created, err := r.client.Disks.GetDirectory(ctx, plan.Node.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading directory",
+			fmt.Sprintf("Could not read directory %s after creation: %s", plan.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	if created == nil {
+		resp.Diagnostics.AddError(
+			"Error reading directory",
+			fmt.Sprintf("Directory %s was created (task completed) but is not listed on node %s.", plan.Name.ValueString(), plan.Node.ValueString()),
+		)
+		return
+	}
+
+	setDirectoryState(created, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *directoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state directoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dir, err := r.client.Disks.GetDirectory(ctx, state.Node.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading directory",
+			fmt.Sprintf("Could not read directory %s: %s", state.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	if dir == nil {
+		tflog.Info(ctx, "Directory no longer exists, removing from state", map[string]any{"name": state.Name.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setDirectoryState(dir, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called because every configurable attribute requires replacement.
+func (r *directoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan directoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the directory mount unit.
+func (r *directoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state directoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Disks.DeleteDirectory(ctx, state.Node.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") || strings.Contains(err.Error(), "not found") {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error deleting directory",
+			fmt.Sprintf("Could not delete directory %s: %s", state.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource using the "node/name" format.
+func (r *directoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	node, name, ok := splitNodeImportID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format \"node/name\", got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node"), node)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("add_datastore"), false)...)
+}
+
+func setDirectoryState(dir *disks.Directory, state *directoryResourceModel) {
+	state.Name = types.StringValue(dir.Name)
+	state.Path = types.StringValue(dir.Path)
+	state.Device = types.StringValue(dir.Device)
+	if dir.Filesystem != "" {
+		state.Filesystem = types.StringValue(dir.Filesystem)
+	}
+	// On import only the partition is known; derive the parent disk so the plan does not force replacement
+	if state.Disk.IsNull() || state.Disk.IsUnknown() {
+		state.Disk = types.StringValue(diskFromPartition(dir.Device))
+	}
+	if state.AddDatastore.IsNull() || state.AddDatastore.IsUnknown() {
+		state.AddDatastore = types.BoolValue(false)
+	}
+}
+
+// diskFromPartition maps a partition device such as /dev/sdb1 or /dev/nvme0n1p1 to its disk name.
+func diskFromPartition(device string) string {
+	name := strings.TrimPrefix(device, "/dev/")
+	trimmed := strings.TrimRight(name, "0123456789")
+	if trimmed == name {
+		return name
+	}
+	// NVMe and MMC devices separate the partition number with a "p"
+	if strings.HasSuffix(trimmed, "p") && strings.ContainsAny(strings.TrimSuffix(trimmed, "p"), "0123456789") {
+		return strings.TrimSuffix(trimmed, "p")
+	}
+	return trimmed
+}
+
+// withDatastoreLock runs fn under the datastore configuration lock when addDatastore
+// is set, because PBS then also writes datastore.cfg as part of the task.
+func withDatastoreLock(ctx context.Context, client *pbs.Client, addDatastore types.Bool, fn func() error) error {
+	if !addDatastore.ValueBool() {
+		return fn()
+	}
+	return client.WithConfigLock(ctx, pbs.LockDomainDatastore, fn)
+}
+
+// splitNodeImportID splits an import ID of the form "node/name".
+func splitNodeImportID(id string) (string, string, bool) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
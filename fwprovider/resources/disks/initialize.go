@@ -0,0 +1,257 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package disks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/disks"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &initializeResource{}
+	_ resource.ResourceWithConfigure = &initializeResource{}
+)
+
+// NewInitializeResource is a helper function to simplify the provider implementation.
+func NewInitializeResource() resource.Resource {
+	return &initializeResource{}
+}
+
+// initializeResource is the resource implementation.
+type initializeResource struct {
+	client *pbs.Client
+}
+
+// initializeResourceModel maps the resource schema data.
+type initializeResourceModel struct {
+	Node    types.String `tfsdk:"node"`
+	Disk    types.String `tfsdk:"disk"`
+	UUID    types.String `tfsdk:"uuid"`
+	Wipe    types.Bool   `tfsdk:"wipe"`
+	DevPath types.String `tfsdk:"devpath"`
+	GPT     types.Bool   `tfsdk:"gpt"`
+	Used    types.String `tfsdk:"used"`
+}
+
+// Metadata returns the resource type name.
+func (r *initializeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_disk_initialize"
+}
+
+// Schema defines the schema for the resource.
+func (r *initializeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Initializes an unused disk with a GPT partition table.",
+		MarkdownDescription: `Initializes an unused disk with a GPT partition table.
+
+This is a one-shot operation: it runs once on create and again whenever ` + "`node`" + `, ` + "`disk`" + `,
+` + "`uuid`" + ` or ` + "`wipe`" + ` change. Destroying the resource only removes it from Terraform state;
+the partition table is left in place.
+
+~> **Warning:** With ` + "`wipe = true`" + ` all partition tables and filesystem signatures on the disk
+are erased before initialization. Any data on the disk is lost.`,
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node that owns the disk. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node that owns the disk. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"disk": schema.StringAttribute{
+				Description:         "The disk to initialize (e.g., sdb).",
+				MarkdownDescription: "The disk to initialize (e.g., `sdb`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"uuid": schema.StringAttribute{
+				Description:         "Optional UUID for the new GPT partition table.",
+				MarkdownDescription: "Optional UUID for the new GPT partition table.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wipe": schema.BoolAttribute{
+				Description:         "Wipe all partition tables and filesystem signatures before initializing.",
+				MarkdownDescription: "Wipe all partition tables and filesystem signatures before initializing. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"devpath": schema.StringAttribute{
+				Description:         "The device path of the disk.",
+				MarkdownDescription: "The device path of the disk (e.g., `/dev/sdb`).",
+				Computed:            true,
+			},
+			"gpt": schema.BoolAttribute{
+				Description:         "Whether the disk currently has a GPT partition table.",
+				MarkdownDescription: "Whether the disk currently has a GPT partition table.",
+				Computed:            true,
+			},
+			"used": schema.StringAttribute{
+				Description:         "The current usage type of the disk.",
+				MarkdownDescription: "The current usage type of the disk (e.g., `unused`, `partitions`, `zfs`).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *initializeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *config.Resource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create wipes (optionally) and initializes the disk, then sets the initial Terraform state.
+func (r *initializeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan initializeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := r.client.Nodes.ResolveNode(ctx, plan.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	plan.Node = types.StringValue(node)
+
+	disk := plan.Disk.ValueString()
+
+	if plan.Wipe.ValueBool() {
+		if err := r.client.Disks.WipeDisk(ctx, node, disk); err != nil {
+			resp.Diagnostics.AddError(
+				"Error wiping disk",
+				fmt.Sprintf("Could not wipe disk %s on node %s: %s", disk, node, err.Error()),
+			)
+			return
+		}
+	}
+
+	if err := r.client.Disks.InitializeGPT(ctx, node, disk, plan.UUID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error initializing disk",
+			fmt.Sprintf("Could not initialize GPT on disk %s on node %s: %s", disk, node, err.Error()),
+		)
+		return
+	}
+
+	found, err := r.findDisk(ctx, node, disk)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading disk",
+			fmt.Sprintf("Could not read disk %s after initialization: %s", disk, err.Error()),
+		)
+		return
+	}
+	setInitializeState(found, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the computed disk attributes. The resource is kept in state even if
+// the disk has since been repurposed, since it only records a one-shot operation.
+func (r *initializeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state initializeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.findDisk(ctx, state.Node.ValueString(), state.Disk.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading disk",
+			fmt.Sprintf("Could not read disk %s: %s", state.Disk.ValueString(), err.Error()),
+		)
+		return
+	}
+	setInitializeState(found, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called because every configurable attribute requires replacement.
+func (r *initializeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan initializeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the resource from Terraform state; the partition table is left in place.
+func (r *initializeResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// findDisk returns the named disk from the node's disk list, or nil if it is not present
+func (r *initializeResource) findDisk(ctx context.Context, node, name string) (*disks.Disk, error) {
+	list, err := r.client.Disks.ListDisks(ctx, node, disks.ListDisksOptions{SkipSmart: true})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func setInitializeState(disk *disks.Disk, state *initializeResourceModel) {
+	if disk == nil {
+		state.DevPath = types.StringNull()
+		state.GPT = types.BoolNull()
+		state.Used = types.StringNull()
+		return
+	}
+
+	state.DevPath = types.StringValue(disk.DevPath)
+	state.GPT = types.BoolValue(disk.GPT)
+	state.Used = types.StringValue(disk.Used)
+}
@@ -0,0 +1,379 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package disks provides Terraform resources for PBS node disk provisioning
+package disks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/disks"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &zfsPoolResource{}
+	_ resource.ResourceWithConfigure   = &zfsPoolResource{}
+	_ resource.ResourceWithImportState = &zfsPoolResource{}
+)
+
+// storageNameRegex matches the PBS datastore name format, which also applies to pool and directory names
+var storageNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9\-_.]*$`)
+
+// NewZFSPoolResource is a helper function to simplify the provider implementation.
+func NewZFSPoolResource() resource.Resource {
+	return &zfsPoolResource{}
+}
+
+// zfsPoolResource is the resource implementation.
+type zfsPoolResource struct {
+	client *pbs.Client
+}
+
+// zfsPoolResourceModel maps the resource schema data.
+type zfsPoolResourceModel struct {
+	Node         types.String `tfsdk:"node"`
+	Name         types.String `tfsdk:"name"`
+	RaidLevel    types.String `tfsdk:"raid_level"`
+	Devices      types.List   `tfsdk:"devices"`
+	Compression  types.String `tfsdk:"compression"`
+	Ashift       types.Int64  `tfsdk:"ashift"`
+	AddDatastore types.Bool   `tfsdk:"add_datastore"`
+	Size         types.Int64  `tfsdk:"size"`
+	Free         types.Int64  `tfsdk:"free"`
+	Health       types.String `tfsdk:"health"`
+}
+
+// Metadata returns the resource type name.
+func (r *zfsPoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_disk_zfs_pool"
+}
+
+// Schema defines the schema for the resource.
+func (r *zfsPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a ZFS pool on unused disks of a PBS node.",
+		MarkdownDescription: `Creates a ZFS pool on unused disks of a PBS node.
+
+The pool is mounted under ` + "`/mnt/datastore/<name>`" + ` and can optionally be registered as a datastore
+in the same step. PBS does not offer an API to destroy ZFS pools, so removing this resource only
+drops it from Terraform state; the pool and its data remain on the node.`,
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node on which to create the pool. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node on which to create the pool. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "The name of the ZFS pool.",
+				MarkdownDescription: "The name of the ZFS pool. Also used as the datastore name when `add_datastore` is set.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(3, 32),
+					stringvalidator.RegexMatches(storageNameRegex, "must start with a letter and contain only letters, numbers, '-', '_' and '.'"),
+				},
+			},
+			"raid_level": schema.StringAttribute{
+				Description:         "The ZFS RAID level.",
+				MarkdownDescription: "The ZFS RAID level. Valid values: `single`, `mirror`, `raid10`, `raidz`, `raidz2`, `raidz3`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("single", "mirror", "raid10", "raidz", "raidz2", "raidz3"),
+				},
+			},
+			"devices": schema.ListAttribute{
+				Description:         "The disks to use for the pool (e.g., sdb).",
+				MarkdownDescription: "The disks to use for the pool (e.g., `sdb`). Disks must be unused.",
+				ElementType:         types.StringType,
+				Required:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"compression": schema.StringAttribute{
+				Description:         "The compression algorithm for the pool.",
+				MarkdownDescription: "The compression algorithm for the pool. Valid values: `gzip`, `lz4`, `lzjb`, `zle`, `zstd`, `on`, `off`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("gzip", "lz4", "lzjb", "zle", "zstd", "on", "off"),
+				},
+			},
+			"ashift": schema.Int64Attribute{
+				Description:         "The pool sector size exponent.",
+				MarkdownDescription: "The pool sector size exponent (9-16). PBS defaults to `12`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(9, 16),
+				},
+			},
+			"add_datastore": schema.BoolAttribute{
+				Description:         "Register the pool as a datastore after creation.",
+				MarkdownDescription: "Register the pool as a datastore named after the pool. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description:         "The pool size in bytes.",
+				MarkdownDescription: "The pool size in bytes.",
+				Computed:            true,
+			},
+			"free": schema.Int64Attribute{
+				Description:         "The free pool space in bytes.",
+				MarkdownDescription: "The free pool space in bytes.",
+				Computed:            true,
+			},
+			"health": schema.StringAttribute{
+				Description:         "The pool health as reported by ZFS.",
+				MarkdownDescription: "The pool health as reported by ZFS (e.g., `ONLINE`, `DEGRADED`).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *zfsPoolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *config.Resource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *zfsPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan zfsPoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := r.client.Nodes.ResolveNode(ctx, plan.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	plan.Node = types.StringValue(node)
+
+	var devices []string
+	resp.Diagnostics.Append(plan.Devices.ElementsAs(ctx, &devices, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pool := &disks.ZFSPoolCreate{
+		Name:      plan.Name.ValueString(),
+		Devices:   devices,
+		RaidLevel: plan.RaidLevel.ValueString(),
+	}
+	if !plan.Compression.IsNull() && !plan.Compression.IsUnknown() {
+		pool.Compression = plan.Compression.ValueString()
+	}
+	if !plan.Ashift.IsNull() && !plan.Ashift.IsUnknown() {
+		ashift := int(plan.Ashift.ValueInt64())
+		pool.Ashift = &ashift
+	}
+	if !plan.AddDatastore.IsNull() && !plan.AddDatastore.IsUnknown() {
+		addDatastore := plan.AddDatastore.ValueBool()
+		pool.AddDatastore = &addDatastore
+	}
+
+	err = withDatastoreLock(ctx, r.client, plan.AddDatastore, func() error {
+		return r.client.Disks.CreateZFSPool(ctx, plan.Node.ValueString(), pool)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ZFS pool",
+			fmt.Sprintf("Could not create ZFS pool %s on node %s: %s", plan.Name.ValueString(), plan.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	created, err := r.client.Disks.GetZFSPool(ctx, plan.Node.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ZFS pool",
+			fmt.Sprintf("Could not read ZFS pool %s after creation: %s", plan.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	if created == nil {
+		resp.Diagnostics.AddError(
+			"Error reading ZFS pool",
+			fmt.Sprintf("ZFS pool %s was created (task completed) but is not listed on node %s.", plan.Name.ValueString(), plan.Node.ValueString()),
+		)
+		return
+	}
+
+	setZFSPoolState(created, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *zfsPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state zfsPoolResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pool, err := r.client.Disks.GetZFSPool(ctx, state.Node.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ZFS pool",
+			fmt.Sprintf("Could not read ZFS pool %s: %s", state.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	if pool == nil {
+		tflog.Info(ctx, "ZFS pool no longer exists, removing from state", map[string]any{"name": state.Name.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setZFSPoolState(pool, &state)
+
+	// raid_level and devices are not part of the pool list; after an import they
+	// are derived from the vdev tree so the configuration does not force a replacement
+	if state.RaidLevel.IsNull() || state.Devices.IsNull() {
+		details, err := r.client.Disks.GetZFSPoolDetails(ctx, state.Node.ValueString(), state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading ZFS pool",
+				fmt.Sprintf("Could not read the layout of ZFS pool %s: %s", state.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+		raidLevel, devices := details.Layout()
+		if state.RaidLevel.IsNull() {
+			state.RaidLevel = types.StringValue(raidLevel)
+		}
+		if state.Devices.IsNull() {
+			list, diags := types.ListValueFrom(ctx, types.StringType, devices)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			state.Devices = list
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called because every configurable attribute requires replacement.
+func (r *zfsPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan zfsPoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the resource from Terraform state; PBS has no API to destroy ZFS pools.
+func (r *zfsPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state zfsPoolResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"ZFS pool not destroyed",
+		fmt.Sprintf("PBS does not provide an API to destroy ZFS pools. Pool %s on node %s was removed from Terraform state "+
+			"but still exists; destroy it with `zpool destroy` on the node if it is no longer needed.",
+			state.Name.ValueString(), state.Node.ValueString()),
+	)
+}
+
+// ImportState imports the resource using the "node/name" format.
+func (r *zfsPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	node, name, ok := splitNodeImportID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format \"node/name\", got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node"), node)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func setZFSPoolState(pool *disks.ZFSPool, state *zfsPoolResourceModel) {
+	state.Name = types.StringValue(pool.Name)
+	state.Size = types.Int64Value(pool.Size)
+	state.Free = types.Int64Value(pool.Free)
+	if pool.Health != "" {
+		state.Health = types.StringValue(pool.Health)
+	} else {
+		state.Health = types.StringNull()
+	}
+	if state.AddDatastore.IsNull() || state.AddDatastore.IsUnknown() {
+		state.AddDatastore = types.BoolValue(false)
+	}
+	if state.Devices.IsUnknown() {
+		state.Devices = types.ListNull(types.StringType)
+	}
+}
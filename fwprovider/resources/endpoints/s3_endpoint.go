@@ -11,10 +11,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,6 +27,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/writeonly"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/endpoints"
 )
@@ -48,15 +51,21 @@ type s3EndpointResource struct {
 
 // s3EndpointResourceModel maps the resource schema data.
 type s3EndpointResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	AccessKey      types.String `tfsdk:"access_key"`
-	SecretKey      types.String `tfsdk:"secret_key"`
-	Endpoint       types.String `tfsdk:"endpoint"`
-	Region         types.String `tfsdk:"region"`
-	Fingerprint    types.String `tfsdk:"fingerprint"`
-	Port           types.Int64  `tfsdk:"port"`
-	PathStyle      types.Bool   `tfsdk:"path_style"`
-	ProviderQuirks types.Set    `tfsdk:"provider_quirks"`
+	ID                 types.String `tfsdk:"id"`
+	AccessKey          types.String `tfsdk:"access_key"`
+	SecretKey          types.String `tfsdk:"secret_key"`
+	SecretKeyWO        types.String `tfsdk:"secret_key_wo"`
+	SecretKeyWOVersion types.Int64  `tfsdk:"secret_key_wo_version"`
+	SecretKeyHash      types.String `tfsdk:"secret_key_hash"`
+	VerifySecret       types.Bool   `tfsdk:"verify_secret"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	Region             types.String `tfsdk:"region"`
+	Fingerprint        types.String `tfsdk:"fingerprint"`
+	Port               types.Int64  `tfsdk:"port"`
+	PathStyle          types.Bool   `tfsdk:"path_style"`
+	ProviderQuirks     types.Set    `tfsdk:"provider_quirks"`
+	VerifyBucket       types.String `tfsdk:"verify_bucket"`
+	VerifyPrefix       types.String `tfsdk:"verify_store_prefix"`
 }
 
 // Metadata returns the resource type name.
@@ -91,13 +100,18 @@ func (r *s3EndpointResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"secret_key": schema.StringAttribute{
-				Description: "S3 secret key.",
-				Required:    true,
+				Description: "S3 secret key. Exactly one of secret_key or secret_key_wo must be set.",
+				Optional:    true,
 				Sensitive:   true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("secret_key_wo")),
 				},
 			},
+			"secret_key_wo":         writeonly.SecretAttribute("secret_key", "S3 secret key."),
+			"secret_key_wo_version": writeonly.VersionAttribute("secret_key"),
+			"secret_key_hash":       writeonly.HashAttribute("secret_key"),
+			"verify_secret":         writeonly.VerifyAttribute("listing the buckets of the endpoint with the stored keys"),
 			"endpoint": schema.StringAttribute{
 				Description: "Endpoint to access S3 object store.",
 				Required:    true,
@@ -131,6 +145,24 @@ func (r *s3EndpointResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"verify_bucket": schema.StringAttribute{
+				Description: "Bucket to check the endpoint against after create and update. " +
+					"When set, PBS uploads, reads and deletes a test object to verify credentials, bucket access and conditional PUT support.",
+				MarkdownDescription: "Bucket to check the endpoint against after create and update. " +
+					"When set, PBS uploads, reads and deletes a test object to verify credentials, bucket access and conditional PUT " +
+					"(`If-None-Match`) support. Failures are reported with the `provider_quirks` or `path_style` settings that usually fix them. " +
+					"When the check fails on create, the endpoint is removed again; on update, the previous state is kept. " +
+					"Either way the next apply retries with corrected settings.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(3, 63),
+				},
+			},
+			"verify_store_prefix": schema.StringAttribute{
+				Description:         "Object key prefix used for the test object written by verify_bucket.",
+				MarkdownDescription: "Object key prefix used for the test object written by `verify_bucket`. Defaults to `" + endpoints.DefaultCheckStorePrefix + "`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -164,11 +196,17 @@ func (r *s3EndpointResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	secretKey, diags := writeonly.Value(ctx, req.Config, "secret_key", plan.SecretKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create the S3 endpoint
 	endpoint := &endpoints.S3Endpoint{
 		ID:        plan.ID.ValueString(),
 		AccessKey: plan.AccessKey.ValueString(),
-		SecretKey: plan.SecretKey.ValueString(),
+		SecretKey: secretKey,
 		Endpoint:  plan.Endpoint.ValueString(),
 	}
 
@@ -201,7 +239,9 @@ func (r *s3EndpointResource) Create(ctx context.Context, req resource.CreateRequ
 		endpoint.ProviderQuirks = quirks
 	}
 
-	err := r.client.Endpoints.CreateS3Endpoint(ctx, endpoint)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainS3, func() error {
+		return r.client.Endpoints.CreateS3Endpoint(ctx, endpoint)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating S3 Endpoint",
@@ -211,6 +251,25 @@ func (r *s3EndpointResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// The ID is already set from the plan (it's required)
+	plan.SecretKeyHash = writeonly.CreateHash(secretKey, &resp.Diagnostics)
+
+	// PBS can only check an endpoint that exists. When the check fails the endpoint
+	// is removed again, so the apply fails without leaving a tainted resource behind.
+	if diags := r.verifyEndpoint(ctx, &plan, endpoint); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		err := r.client.WithConfigLock(ctx, pbs.LockDomainS3, func() error {
+			return r.client.Endpoints.DeleteS3Endpoint(ctx, plan.ID.ValueString())
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Removing S3 Endpoint",
+				fmt.Sprintf("S3 endpoint %s failed its check and could not be removed again: %s", plan.ID.ValueString(), err.Error()),
+			)
+			// Keep tracking the endpoint so it is not left behind unmanaged
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		}
+		return
+	}
 
 	// Log that the resource was created
 	tflog.Trace(ctx, "created S3 endpoint resource")
@@ -286,7 +345,12 @@ func (r *s3EndpointResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Note: SecretKey is not returned by the API for security reasons
-	// so we keep the existing value in the state
+	// so we keep the existing value in the state and optionally check it still works
+	secret := writeonly.Secret{Attr: "secret_key", Plain: &state.SecretKey, Version: &state.SecretKeyWOVersion, Hash: &state.SecretKeyHash}
+	writeonly.Verify(ctx, state.VerifySecret, secret, "S3 endpoint "+state.ID.ValueString(), func() error {
+		_, err := r.client.Endpoints.ListBuckets(ctx, state.ID.ValueString())
+		return err
+	}, &resp.Diagnostics)
 
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -302,11 +366,24 @@ func (r *s3EndpointResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	var state s3EndpointResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only secret keys are only sent when secret_key_wo_version changes
+	secretKey, diags := writeonly.UpdateValue(ctx, req.Config, "secret_key", plan.SecretKey, plan.SecretKeyWOVersion, state.SecretKeyWOVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update the S3 endpoint
 	endpoint := &endpoints.S3Endpoint{
 		ID:        plan.ID.ValueString(),
 		AccessKey: plan.AccessKey.ValueString(),
-		SecretKey: plan.SecretKey.ValueString(),
+		SecretKey: secretKey,
 		Endpoint:  plan.Endpoint.ValueString(),
 	}
 
@@ -339,7 +416,9 @@ func (r *s3EndpointResource) Update(ctx context.Context, req resource.UpdateRequ
 		endpoint.ProviderQuirks = quirks
 	}
 
-	err := r.client.Endpoints.UpdateS3Endpoint(ctx, plan.ID.ValueString(), endpoint)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainS3, func() error {
+		return r.client.Endpoints.UpdateS3Endpoint(ctx, plan.ID.ValueString(), endpoint)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating S3 Endpoint",
@@ -348,6 +427,15 @@ func (r *s3EndpointResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	// A failed check keeps the prior state, so the next plan still shows the
+	// change and the check runs again once the settings are corrected
+	if diags := r.verifyEndpoint(ctx, &plan, endpoint); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	plan.SecretKeyHash = writeonly.UpdateHash(secretKey, state.SecretKeyHash, &resp.Diagnostics)
+
 	// Log that the resource was updated
 	tflog.Trace(ctx, "updated S3 endpoint resource")
 
@@ -368,7 +456,9 @@ func (r *s3EndpointResource) Delete(ctx context.Context, req resource.DeleteRequ
 	// Delete existing endpoint - PBS API will reject deletion if endpoint is still in use
 	// by a datastore. Terraform should handle dependency ordering, but this provides
 	// a clear error message if dependencies are violated.
-	err := r.client.Endpoints.DeleteS3Endpoint(ctx, state.ID.ValueString())
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainS3, func() error {
+		return r.client.Endpoints.DeleteS3Endpoint(ctx, state.ID.ValueString())
+	})
 	if err != nil {
 		// Check if the endpoint is already gone (desired state achieved)
 		errorMsg := err.Error()
@@ -405,6 +495,54 @@ func (r *s3EndpointResource) Delete(ctx context.Context, req resource.DeleteRequ
 	tflog.Trace(ctx, "deleted S3 endpoint resource")
 }
 
+// verifyEndpoint runs the PBS S3 check against verify_bucket, if configured, and
+// translates failures into the endpoint settings that fix them.
+func (r *s3EndpointResource) verifyEndpoint(ctx context.Context, plan *s3EndpointResourceModel, endpoint *endpoints.S3Endpoint) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.VerifyBucket.IsNull() || plan.VerifyBucket.IsUnknown() {
+		return diags
+	}
+
+	bucket := plan.VerifyBucket.ValueString()
+	err := r.client.Endpoints.CheckS3Endpoint(ctx, plan.ID.ValueString(), bucket, plan.VerifyPrefix.ValueString())
+	if err == nil {
+		tflog.Debug(ctx, "S3 endpoint check passed", map[string]any{"id": plan.ID.ValueString(), "bucket": bucket})
+		return diags
+	}
+
+	detail := fmt.Sprintf("PBS could not use S3 endpoint %s with bucket %s: %s", plan.ID.ValueString(), bucket, err.Error())
+
+	diagnosis := endpoints.DiagnoseS3Error(err)
+	if diagnosis != nil {
+		detail += "\n\n" + diagnosis.Hint
+
+		var missing []string
+		for _, quirk := range diagnosis.Quirks {
+			if !slices.Contains(endpoint.ProviderQuirks, quirk) {
+				missing = append(missing, quirk)
+			}
+		}
+		if len(missing) > 0 {
+			detail += fmt.Sprintf("\n\nRequired provider_quirks not configured: %s", strings.Join(missing, ", "))
+		} else if len(diagnosis.Quirks) > 0 {
+			detail += "\n\nThe suggested provider_quirks are already configured; the object store may not be supported."
+		}
+		if diagnosis.PathStyle != nil && (endpoint.PathStyle == nil || *endpoint.PathStyle != *diagnosis.PathStyle) {
+			detail += fmt.Sprintf("\n\nSuggested setting: path_style = %t", *diagnosis.PathStyle)
+		}
+	}
+
+	tflog.Warn(ctx, "S3 endpoint check failed", map[string]any{
+		"id":     plan.ID.ValueString(),
+		"bucket": bucket,
+		"error":  err.Error(),
+	})
+
+	diags.AddAttributeError(path.Root("verify_bucket"), "S3 Endpoint Check Failed", detail)
+	return diags
+}
+
 // ImportState imports an existing resource into Terraform state.
 func (r *s3EndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
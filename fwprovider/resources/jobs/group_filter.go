@@ -0,0 +1,169 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+// groupFilterModel maps a single group_filter block.
+type groupFilterModel struct {
+	Action types.String `tfsdk:"action"`
+	Kind   types.String `tfsdk:"kind"`
+	Value  types.String `tfsdk:"value"`
+}
+
+// groupFilterObjectType is the object type of a group_filter block element.
+var groupFilterObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"action": types.StringType,
+		"kind":   types.StringType,
+		"value":  types.StringType,
+	},
+}
+
+// groupFilterBlock returns the group_filter block shared by jobs that select backup groups.
+func groupFilterBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Selects the backup groups the job processes. Groups are selected when no include filter is given or any include filter matches, unless an exclude filter matches.",
+		MarkdownDescription: "Selects the backup groups the job processes. A group is selected when no `include` filter is given or any " +
+			"`include` filter matches it, and dropped when any `exclude` filter matches it; excludes always win and the order of " +
+			"the blocks does not matter. Use the `pbs_sync_job_groups` data source to check which groups a filter list selects.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"action": schema.StringAttribute{
+					Description:         "Whether matching groups are included or excluded.",
+					MarkdownDescription: "Whether matching groups are `include`d or `exclude`d. Defaults to `include`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(jobs.GroupFilterInclude),
+					Validators: []validator.String{
+						stringvalidator.OneOf(jobs.GroupFilterInclude, jobs.GroupFilterExclude),
+					},
+				},
+				"kind": schema.StringAttribute{
+					Description:         "Filter kind: group, type or regex.",
+					MarkdownDescription: "Filter kind: `group` (a single `<type>/<id>` group), `type` (`vm`, `ct` or `host`) or `regex` (a pattern matched against `<type>/<id>`).",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(jobs.GroupFilterGroup, jobs.GroupFilterType, jobs.GroupFilterRegex),
+					},
+				},
+				"value": schema.StringAttribute{
+					Description:         "Group, backup type or regular expression to match.",
+					MarkdownDescription: "Group (e.g. `vm/100`), backup type (e.g. `ct`) or regular expression (e.g. `^vm/1\\d\\d$`) to match.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateGroupFilters checks group_filter blocks at plan time. Invalid entries
+// are errors; valid lists that are unlikely to do what was meant are warnings.
+func validateGroupFilters(ctx context.Context, list types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if list.IsNull() || list.IsUnknown() {
+		return diags
+	}
+
+	var blocks []groupFilterModel
+	diags.Append(list.ElementsAs(ctx, &blocks, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	filters := make([]jobs.GroupFilter, 0, len(blocks))
+	for i, block := range blocks {
+		if block.Action.IsUnknown() || block.Kind.IsUnknown() || block.Value.IsUnknown() {
+			return diags
+		}
+		f, err := jobs.NewGroupFilter(block.Action.ValueString(), block.Kind.ValueString(), block.Value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("group_filter").AtListIndex(i), "Invalid group_filter", err.Error())
+			continue
+		}
+		filters = append(filters, f)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, problem := range jobs.CheckGroupFilters(filters) {
+		diags.AddAttributeWarning(path.Root("group_filter").AtListIndex(i), "Suspicious group_filter", problem)
+	}
+	return diags
+}
+
+// groupFiltersToAPI formats group_filter blocks as PBS group-filter entries.
+func groupFiltersToAPI(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+
+	var blocks []groupFilterModel
+	diags.Append(list.ElementsAs(ctx, &blocks, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var entries []string
+	for _, block := range blocks {
+		f, err := jobs.NewGroupFilter(block.Action.ValueString(), block.Kind.ValueString(), block.Value.ValueString())
+		if err != nil {
+			diags.AddError("Invalid group_filter", err.Error())
+			return nil, diags
+		}
+		entries = append(entries, f.String())
+	}
+
+	return entries, diags
+}
+
+// groupFiltersFromAPI parses PBS group-filter entries into group_filter blocks.
+// An empty list is returned rather than null because blocks are never null in configuration.
+func groupFiltersFromAPI(ctx context.Context, entries []string) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	blocks := make([]groupFilterModel, 0, len(entries))
+
+	for _, entry := range entries {
+		f, err := jobs.ParseGroupFilter(entry)
+		if err != nil {
+			diags.AddError(
+				"Error parsing group-filter",
+				fmt.Sprintf("Could not parse group-filter %q returned by PBS: %s", entry, err.Error()),
+			)
+			return types.ListNull(groupFilterObjectType), diags
+		}
+
+		blocks = append(blocks, groupFilterModel{
+			Action: types.StringValue(f.Action()),
+			Kind:   types.StringValue(f.Kind),
+			Value:  types.StringValue(f.Value),
+		})
+	}
+
+	list, d := types.ListValueFrom(ctx, groupFilterObjectType, blocks)
+	diags.Append(d...)
+	return list, diags
+}
@@ -64,10 +64,6 @@ func shouldDeleteBoolAttr(plan, state types.Bool) bool {
 	return plan.IsNull() && !state.IsNull() && !state.IsUnknown()
 }
 
-func shouldDeleteListAttr(plan, state types.List) bool {
-	return plan.IsNull() && !state.IsNull() && !state.IsUnknown()
-}
-
 func stringListFromAttribute(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
 	if list.IsNull() || list.IsUnknown() {
 		return nil, nil
@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,9 +17,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/jobs"
 )
@@ -42,20 +45,28 @@ type pruneJobResource struct {
 
 // pruneJobResourceModel maps the resource schema data.
 type pruneJobResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Store       types.String `tfsdk:"store"`
-	Schedule    types.String `tfsdk:"schedule"`
-	KeepLast    types.Int64  `tfsdk:"keep_last"`
-	KeepHourly  types.Int64  `tfsdk:"keep_hourly"`
-	KeepDaily   types.Int64  `tfsdk:"keep_daily"`
-	KeepWeekly  types.Int64  `tfsdk:"keep_weekly"`
-	KeepMonthly types.Int64  `tfsdk:"keep_monthly"`
-	KeepYearly  types.Int64  `tfsdk:"keep_yearly"`
-	MaxDepth    types.Int64  `tfsdk:"max_depth"`
-	Namespace   types.String `tfsdk:"namespace"`
-	Comment     types.String `tfsdk:"comment"`
-	Disable     types.Bool   `tfsdk:"disable"`
-	Digest      types.String `tfsdk:"digest"`
+	ID             types.String `tfsdk:"id"`
+	Store          types.String `tfsdk:"store"`
+	Schedule       types.String `tfsdk:"schedule"`
+	KeepLast       types.Int64  `tfsdk:"keep_last"`
+	KeepHourly     types.Int64  `tfsdk:"keep_hourly"`
+	KeepDaily      types.Int64  `tfsdk:"keep_daily"`
+	KeepWeekly     types.Int64  `tfsdk:"keep_weekly"`
+	KeepMonthly    types.Int64  `tfsdk:"keep_monthly"`
+	KeepYearly     types.Int64  `tfsdk:"keep_yearly"`
+	MaxDepth       types.Int64  `tfsdk:"max_depth"`
+	Namespace      types.String `tfsdk:"namespace"`
+	Comment        types.String `tfsdk:"comment"`
+	Disable        types.Bool   `tfsdk:"disable"`
+	Digest         types.String `tfsdk:"digest"`
+	LastRunState   types.String `tfsdk:"last_run_state"`
+	LastRunFailed  types.Bool   `tfsdk:"last_run_failed"`
+	LastRunUPID    types.String `tfsdk:"last_run_upid"`
+	LastRunEndtime types.String `tfsdk:"last_run_endtime"`
+	NextRun        types.String `tfsdk:"next_run"`
+	RunOnApply     types.String `tfsdk:"run_on_apply"`
+	WaitForRun     types.Bool   `tfsdk:"wait_for_run"`
+	RunTimeout     types.String `tfsdk:"run_timeout"`
 }
 
 // Metadata returns the resource type name.
@@ -91,6 +102,9 @@ keep-monthly, and keep-yearly parameters.`,
 				Description:         "When to run the prune job (systemd calendar event format).",
 				MarkdownDescription: "When to run the prune job. Uses systemd calendar event format (e.g., `daily`, `weekly`, `Mon..Fri *-*-* 02:00:00`).",
 				Required:            true,
+				Validators: []validator.String{
+					validators.CalendarEvent(),
+				},
 			},
 			"keep_last": schema.Int64Attribute{
 				Description:         "Keep the last N backup snapshots.",
@@ -154,6 +168,8 @@ keep-monthly, and keep-yearly parameters.`,
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, jobStatusAttributes())
+	maps.Copy(resp.Schema.Attributes, jobRunAttributes())
 }
 
 // Configure adds the provider configured client to the resource.
@@ -184,7 +200,10 @@ func (r *pruneJobResource) Create(ctx context.Context, req resource.CreateReques
 
 	job := buildPruneJobFromPlan(&plan)
 
-	if err := r.client.Jobs.CreatePruneJob(ctx, job); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.CreatePruneJob(ctx, job)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating prune job",
 			fmt.Sprintf("Could not create prune job %s: %s", plan.ID.ValueString(), err.Error()),
@@ -203,6 +222,11 @@ func (r *pruneJobResource) Create(ctx context.Context, req resource.CreateReques
 
 	var state pruneJobResourceModel
 	setPruneStateFromAPI(createdJob, &state)
+	state.RunOnApply, state.WaitForRun, state.RunTimeout = plan.RunOnApply, plan.WaitForRun, plan.RunTimeout
+	if runRequested(plan.RunOnApply, types.StringNull()) {
+		runJobOnCreate(ctx, r.client, jobs.JobTypePrune, job.ID, plan.WaitForRun, plan.RunTimeout, &resp.Diagnostics)
+	}
+	r.setJobStatus(ctx, &state)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -225,6 +249,8 @@ func (r *pruneJobResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	setPruneStateFromAPI(job, &state)
+	state.WaitForRun, state.RunTimeout = jobRunDefaults(state.WaitForRun, state.RunTimeout)
+	r.setJobStatus(ctx, &state)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -250,7 +276,10 @@ func (r *pruneJobResource) Update(ctx context.Context, req resource.UpdateReques
 	job := buildPruneJobFromPlan(&plan)
 	job.Delete = computePruneDeletes(&plan, &state)
 
-	if err := r.client.Jobs.UpdatePruneJob(ctx, plan.ID.ValueString(), job); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.UpdatePruneJob(ctx, plan.ID.ValueString(), job)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating prune job",
 			fmt.Sprintf("Could not update prune job %s: %s", plan.ID.ValueString(), err.Error()),
@@ -268,6 +297,17 @@ func (r *pruneJobResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	setPruneStateFromAPI(updatedJob, &state)
+	runOnApply := plan.RunOnApply
+	if runRequested(plan.RunOnApply, state.RunOnApply) {
+		runDiags := runJobOnApply(ctx, r.client, jobs.JobTypePrune, plan.ID.ValueString(), plan.WaitForRun, plan.RunTimeout)
+		resp.Diagnostics.Append(runDiags...)
+		if runDiags.HasError() {
+			// Keep the previous trigger so that the next apply retries the run.
+			runOnApply = state.RunOnApply
+		}
+	}
+	state.RunOnApply, state.WaitForRun, state.RunTimeout = runOnApply, plan.WaitForRun, plan.RunTimeout
+	r.setJobStatus(ctx, &state)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -286,7 +326,9 @@ func (r *pruneJobResource) Delete(ctx context.Context, req resource.DeleteReques
 		digest = state.Digest.ValueString()
 	}
 
-	err := r.client.Jobs.DeletePruneJob(ctx, state.ID.ValueString(), digest)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.DeletePruneJob(ctx, state.ID.ValueString(), digest)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting prune job",
@@ -390,3 +432,10 @@ func setPruneStateFromAPI(job *jobs.PruneJob, state *pruneJobResourceModel) {
 	}
 	state.Digest = stringValueOrNull(job.Digest)
 }
+
+// setJobStatus refreshes the run status attributes of state.
+func (r *pruneJobResource) setJobStatus(ctx context.Context, state *pruneJobResourceModel) {
+	id := state.ID.ValueString()
+	status, err := r.client.Jobs.GetPruneJobStatus(ctx, id)
+	state.LastRunState, state.LastRunUPID, state.LastRunEndtime, state.NextRun, state.LastRunFailed = jobStatusValues(jobStatusOrNil(ctx, id, status, err))
+}
@@ -0,0 +1,132 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+// defaultRunTimeout is the default run_timeout of the job resources.
+const defaultRunTimeout = "1h"
+
+// jobRunAttributes returns the attributes that trigger an immediate run of a
+// job resource on apply.
+func jobRunAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"run_on_apply": schema.StringAttribute{
+			Description: "Arbitrary trigger value. The job is run immediately when it is created with a value set, and whenever the value changes.",
+			MarkdownDescription: "Arbitrary trigger value. The job is run immediately, independent of its schedule, when it is created with a " +
+				"value set and whenever the value changes (e.g. `timestamp()` on every apply, or a hash of the job settings). " +
+				"Removing the value does not run the job. When a run fails on update the apply fails and the next apply retries it; " +
+				"when the first run after create fails the job is kept and the failure is reported as a warning.",
+			Optional: true,
+		},
+		"wait_for_run": schema.BoolAttribute{
+			Description:         "Wait for a run triggered by run_on_apply to finish and fail the apply if it fails.",
+			MarkdownDescription: "Wait for a run triggered by `run_on_apply` to finish and fail the apply, with the tail of the task log, if it fails. Defaults to `false`, which only starts the run.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"run_timeout": schema.StringAttribute{
+			Description:         "How long to wait for a run when wait_for_run is set.",
+			MarkdownDescription: "How long to wait for a run when `wait_for_run` is set, as a duration such as `30m`. Defaults to `" + defaultRunTimeout + "`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(defaultRunTimeout),
+			Validators: []validator.String{
+				validators.Duration(),
+			},
+		},
+	}
+}
+
+// runRequested reports whether the run_on_apply trigger asks for a run: it is
+// set on create, or changed to a new non-null value on update. A null state
+// is passed on create.
+func runRequested(plan, state types.String) bool {
+	if plan.IsNull() || plan.IsUnknown() {
+		return false
+	}
+	return state.IsNull() || !plan.Equal(state)
+}
+
+// runJobOnApply runs a job and, when wait is set, waits for the run to finish.
+func runJobOnApply(ctx context.Context, client *pbs.Client, jobType, id string, wait types.Bool, timeout types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	upid, err := client.Jobs.RunJob(ctx, jobType, id)
+	if err != nil {
+		diags.AddError(
+			"Error running "+jobType+" job",
+			fmt.Sprintf("Could not run %s job %s: %s", jobType, id, err.Error()),
+		)
+		return diags
+	}
+	tflog.Info(ctx, "Started job run", map[string]any{"type": jobType, "id": id, "upid": upid})
+
+	if !wait.ValueBool() {
+		return diags
+	}
+
+	d, err := time.ParseDuration(timeout.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Duration", fmt.Sprintf("Could not parse run_timeout: %s", err.Error()))
+		return diags
+	}
+
+	if err := client.Jobs.WaitForJobRun(ctx, upid, d); err != nil {
+		diags.AddError(
+			"Job run failed",
+			fmt.Sprintf("Run of %s job %s failed: %s", jobType, id, err.Error()),
+		)
+	}
+
+	return diags
+}
+
+// runJobOnCreate runs a newly created job. The job already exists in PBS, so a
+// failed run is downgraded to a warning instead of tainting the job. The trigger
+// still has to be saved as planned, because Terraform rejects a null value for
+// a configured attribute after apply; changing it runs the job again.
+func runJobOnCreate(ctx context.Context, client *pbs.Client, jobType, id string, wait types.Bool, timeout types.String, diags *diag.Diagnostics) {
+	for _, d := range runJobOnApply(ctx, client, jobType, id, wait, timeout) {
+		if d.Severity() != diag.SeverityError {
+			diags.Append(d)
+			continue
+		}
+		diags.AddWarning(
+			d.Summary(),
+			d.Detail()+"\n\nThe job was created. Change run_on_apply or use the pbs_run_job action to run it again.",
+		)
+	}
+}
+
+// jobRunDefaults fills in the defaults of wait_for_run and run_timeout, which
+// are null in state written before they existed and after import.
+func jobRunDefaults(wait types.Bool, timeout types.String) (types.Bool, types.String) {
+	if wait.IsNull() {
+		wait = types.BoolValue(false)
+	}
+	if timeout.IsNull() {
+		timeout = types.StringValue(defaultRunTimeout)
+	}
+	return wait, timeout
+}
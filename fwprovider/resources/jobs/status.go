@@ -0,0 +1,77 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+// jobStatusAttributes returns the computed run status attributes shared by the
+// job resources. They are refreshed from the PBS job status listing on every read.
+func jobStatusAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"last_run_state": schema.StringAttribute{
+			Description:         "Result of the last run: OK, WARNINGS: <n>, or the error message.",
+			MarkdownDescription: "Result of the last run: `OK`, `WARNINGS: <n>`, or the error message of a failed run. Null when the job has not run yet.",
+			Computed:            true,
+		},
+		"last_run_failed": schema.BoolAttribute{
+			Description:         "Whether the last run ended with an error. Runs that finished with warnings and jobs that have not run are not failures.",
+			MarkdownDescription: "Whether the last run ended with an error. Runs that finished with warnings (`WARNINGS: <n>`) and jobs that have not run yet are not failures, so checks can use this instead of matching `last_run_state`.",
+			Computed:            true,
+		},
+		"last_run_upid": schema.StringAttribute{
+			Description:         "Task UPID of the last run.",
+			MarkdownDescription: "Task UPID of the last run, usable to look up the task log.",
+			Computed:            true,
+		},
+		"last_run_endtime": schema.StringAttribute{
+			Description:         "End time of the last run (RFC 3339).",
+			MarkdownDescription: "End time of the last run (RFC 3339).",
+			Computed:            true,
+		},
+		"next_run": schema.StringAttribute{
+			Description:         "Next scheduled run (RFC 3339).",
+			MarkdownDescription: "Next scheduled run (RFC 3339). Null when the job is disabled or has no upcoming run.",
+			Computed:            true,
+		},
+	}
+}
+
+// jobStatusValues converts a job status into the values of the run status
+// attributes. A nil status yields nulls, and last_run_failed is false.
+func jobStatusValues(status *jobs.JobStatus) (lastRunState, lastRunUPID, lastRunEndtime, nextRun types.String, lastRunFailed types.Bool) {
+	if status == nil {
+		return types.StringNull(), types.StringNull(), types.StringNull(), types.StringNull(), types.BoolValue(false)
+	}
+	return stringValueOrNull(status.LastRunState), stringValueOrNull(status.LastRunUPID),
+		timeValueOrNull(status.LastRunEndtime), timeValueOrNull(status.NextRun), types.BoolValue(status.Failed())
+}
+
+// jobStatusOrNil returns the status from a status lookup. The run status is
+// informational, so a failed lookup is logged rather than failing the operation.
+func jobStatusOrNil(ctx context.Context, id string, status *jobs.JobStatus, err error) *jobs.JobStatus {
+	if err != nil {
+		tflog.Warn(ctx, "Could not read job run status", map[string]any{"id": id, "error": err.Error()})
+		return nil
+	}
+	return status
+}
+
+func timeValueOrNull(epoch *int64) types.String {
+	if epoch == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(time.Unix(*epoch, 0).UTC().Format(time.RFC3339))
+}
@@ -9,11 +9,10 @@ package jobs
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"maps"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -21,23 +20,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/jobs"
 )
 
 var (
-	_ resource.Resource                = &syncJobResource{}
-	_ resource.ResourceWithConfigure   = &syncJobResource{}
-	_ resource.ResourceWithImportState = &syncJobResource{}
+	_ resource.Resource                   = &syncJobResource{}
+	_ resource.ResourceWithConfigure      = &syncJobResource{}
+	_ resource.ResourceWithImportState    = &syncJobResource{}
+	_ resource.ResourceWithValidateConfig = &syncJobResource{}
+	_ resource.ResourceWithUpgradeState   = &syncJobResource{}
 )
 
-var groupFilterRegex = regexp.MustCompile(`^(?:group:[^\s]+|type:(?:vm|ct|host)|regex:.+)$`)
-
 // NewSyncJobResource is a helper function to simplify the provider implementation.
 func NewSyncJobResource() resource.Resource {
 	return &syncJobResource{}
@@ -73,6 +74,14 @@ type syncJobResourceModel struct {
 	BurstOut        types.String `tfsdk:"burst_out"`
 	Comment         types.String `tfsdk:"comment"`
 	Digest          types.String `tfsdk:"digest"`
+	LastRunState    types.String `tfsdk:"last_run_state"`
+	LastRunFailed   types.Bool   `tfsdk:"last_run_failed"`
+	LastRunUPID     types.String `tfsdk:"last_run_upid"`
+	LastRunEndtime  types.String `tfsdk:"last_run_endtime"`
+	NextRun         types.String `tfsdk:"next_run"`
+	RunOnApply      types.String `tfsdk:"run_on_apply"`
+	WaitForRun      types.Bool   `tfsdk:"wait_for_run"`
+	RunTimeout      types.String `tfsdk:"run_timeout"`
 }
 
 // Metadata returns the resource type name.
@@ -83,13 +92,20 @@ func (r *syncJobResource) Metadata(_ context.Context, req resource.MetadataReque
 // Schema defines the schema for the resource.
 func (r *syncJobResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a PBS sync job for automated remote datastore synchronization.",
 		MarkdownDescription: `Manages a PBS sync job.
 
 Sync jobs pull backups from a remote PBS server to the local datastore, enabling off-site 
 backup replication. You can filter which backup groups to sync and control bandwidth usage 
 with rate limiting. The ` + "`remove_vanished`" + ` option keeps the local copy synchronized 
-by removing backups that no longer exist on the remote.`,
+by removing backups that no longer exist on the remote.
+
+With ` + "`sync_direction = \"push\"`" + ` the job instead sends the local ` + "`store`" + ` and ` + "`namespace`" + ` to
+` + "`remote_store`" + ` and ` + "`remote_namespace`" + ` on the remote. The remote's ` + "`auth_id`" + ` then needs
+` + "`Datastore.Backup`" + ` on the target namespace, plus ` + "`Datastore.Prune`" + ` for ` + "`remove_vanished`" + `;
+the target datastore and namespace are checked when the job is applied. Use the
+` + "`pbs_sync_job_preview`" + ` data source to list the local groups a push job would send.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description:         "The unique identifier for the sync job.",
@@ -100,14 +116,17 @@ by removing backups that no longer exist on the remote.`,
 				},
 			},
 			"store": schema.StringAttribute{
-				Description:         "The local datastore name where backups will be synced to.",
-				MarkdownDescription: "The local datastore name where backups will be synced to.",
+				Description:         "The local datastore: the target of a pull job, the source of a push job.",
+				MarkdownDescription: "The local datastore: where backups are synced to for `pull`, and which is sent to the remote for `push`.",
 				Required:            true,
 			},
 			"schedule": schema.StringAttribute{
 				Description:         "When to run the sync job (systemd calendar event format).",
 				MarkdownDescription: "When to run the sync job. Uses systemd calendar event format (e.g., `hourly`, `*:00/15`, `Mon,Wed,Fri 02:00`).",
 				Required:            true,
+				Validators: []validator.String{
+					validators.CalendarEvent(),
+				},
 			},
 			"remote": schema.StringAttribute{
 				Description:         "The remote server name (configured in PBS remotes).",
@@ -115,37 +134,28 @@ by removing backups that no longer exist on the remote.`,
 				Required:            true,
 			},
 			"remote_store": schema.StringAttribute{
-				Description:         "The datastore name on the remote server.",
-				MarkdownDescription: "The datastore name on the remote server.",
+				Description:         "The datastore on the remote server: the source of a pull job, the target of a push job.",
+				MarkdownDescription: "The datastore on the remote server: the source for `pull`, the target for `push`.",
 				Required:            true,
 			},
 			"remote_namespace": schema.StringAttribute{
-				Description:         "Remote namespace to sync from (optional).",
-				MarkdownDescription: "Remote namespace to sync from. Optional; leave empty to sync from the remote root namespace.",
+				Description:         "Remote namespace to sync from (pull) or to (push).",
+				MarkdownDescription: "Remote namespace to sync from for `pull`, or to push into for `push`. Optional; leave empty for the remote root namespace. A push target namespace must already exist.",
 				Optional:            true,
 			},
 			"namespace": schema.StringAttribute{
-				Description:         "Local namespace where backups will be stored (optional).",
-				MarkdownDescription: "Local namespace where backups will be stored. Optional; supports hierarchical namespaces such as `ns1/sub`.",
+				Description:         "Local namespace where backups are stored (pull) or read from (push).",
+				MarkdownDescription: "Local namespace where backups are stored for `pull`, or read from for `push`. Optional; supports hierarchical namespaces such as `ns1/sub`.",
 				Optional:            true,
 			},
 			"max_depth": schema.Int64Attribute{
 				Description:         "Maximum namespace depth that will be traversed when syncing.",
-				MarkdownDescription: "Maximum namespace depth that will be traversed when syncing. Must be greater than or equal to 0.",
+				MarkdownDescription: "Maximum namespace depth that will be traversed when syncing. Must be greater than or equal to 0, and both namespaces plus this depth must fit within the PBS limit of 7 levels.",
 				Optional:            true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(0),
 				},
 			},
-			"group_filter": schema.ListAttribute{
-				Description:         "List of backup group selectors using `group:<name>`, `type:<vm|ct|host>`, or `regex:<pattern>` syntax.",
-				MarkdownDescription: "List of backup group selectors using `group:<name>`, `type:<vm|ct|host>`, or `regex:<pattern>` syntax. Only matching groups will be synced.",
-				ElementType:         types.StringType,
-				Optional:            true,
-				Validators: []validator.List{
-					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(groupFilterRegex, "must match `<type>/<id>[/<namespace>]`")),
-				},
-			},
 			"remove_vanished": schema.BoolAttribute{
 				Description:         "Remove backups that no longer exist on the remote.",
 				MarkdownDescription: "Remove backups locally that no longer exist on the remote. Defaults to `false`.",
@@ -154,8 +164,8 @@ by removing backups that no longer exist on the remote.`,
 				Default:             booldefault.StaticBool(false),
 			},
 			"resync_corrupt": schema.BoolAttribute{
-				Description:         "Resync snapshots whose data is corrupt.",
-				MarkdownDescription: "Resync snapshots whose data is corrupt. Defaults to `false`.",
+				Description:         "Resync snapshots whose data is corrupt (pull only).",
+				MarkdownDescription: "Resync snapshots whose data is corrupt. Only supported for `pull`. Defaults to `false`.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
@@ -191,15 +201,24 @@ by removing backups that no longer exist on the remote.`,
 			},
 			"sync_direction": schema.StringAttribute{
 				Description:         "Direction of synchronization (`pull` or `push`).",
-				MarkdownDescription: "Direction of synchronization. Must be either `pull` (default) or `push`.",
+				MarkdownDescription: "Direction of synchronization. Must be either `pull` (default) or `push`. Changing the direction replaces the job.",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(jobs.SyncDirectionPull),
 				Validators: []validator.String{
-					stringvalidator.OneOf("pull", "push"),
+					stringvalidator.OneOf(jobs.SyncDirectionPull, jobs.SyncDirectionPush),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						syncDirectionChanged,
+						"Changing between pull and push swaps source and target, so the job is replaced.",
+						"Changing between `pull` and `push` swaps source and target, so the job is replaced.",
+					),
 				},
 			},
 			"owner": schema.StringAttribute{
-				Description:         "Owner of the synced backups (user ID).",
-				MarkdownDescription: "Owner user ID for the synced backups. Optional.",
+				Description:         "Owner of the synced backups (pull) or local user whose groups are pushed (push).",
+				MarkdownDescription: "For `pull`, the owner user ID of the synced backups. For `push`, the local user whose backup groups are read and sent. Optional.",
 				Optional:            true,
 			},
 			"rate_in": schema.StringAttribute{
@@ -236,9 +255,49 @@ by removing backups that no longer exist on the remote.`,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"group_filter": groupFilterBlock(),
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, jobStatusAttributes())
+	maps.Copy(resp.Schema.Attributes, jobRunAttributes())
+}
+
+// ValidateConfig checks the namespace mapping and direction-specific options at plan time.
+func (r *syncJobResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config syncJobResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateGroupFilters(ctx, config.GroupFilter)...)
+
+	if !config.Namespace.IsUnknown() && !config.RemoteNamespace.IsUnknown() && !config.MaxDepth.IsUnknown() {
+		if err := jobs.ValidateSyncNamespaces(config.Namespace.ValueString(), config.RemoteNamespace.ValueString(), intPointerFromAttr(config.MaxDepth)); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("max_depth"), "Invalid namespace mapping", err.Error())
+		}
+	}
+
+	if config.SyncDirection.ValueString() == jobs.SyncDirectionPush && config.ResyncCorrupt.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resync_corrupt"),
+			"Option not supported for push",
+			"resync_corrupt re-downloads corrupt snapshots from the remote and is only available for pull sync jobs.",
+		)
 	}
 }
 
+// syncDirectionChanged requires replacement when the direction really changes,
+// treating an unset direction in existing state as pull.
+func syncDirectionChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	stateDirection := req.StateValue.ValueString()
+	if stateDirection == "" {
+		stateDirection = jobs.SyncDirectionPull
+	}
+	resp.RequiresReplace = req.PlanValue.ValueString() != stateDirection
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *syncJobResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -271,7 +330,15 @@ func (r *syncJobResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	if err := r.client.Jobs.CreateSyncJob(ctx, job); err != nil {
+	resp.Diagnostics.Append(r.checkPushTarget(ctx, job)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.CreateSyncJob(ctx, job)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating sync job",
 			fmt.Sprintf("Could not create sync job %s: %s", plan.ID.ValueString(), err.Error()),
@@ -294,6 +361,12 @@ func (r *syncJobResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	state.RunOnApply, state.WaitForRun, state.RunTimeout = plan.RunOnApply, plan.WaitForRun, plan.RunTimeout
+	if runRequested(plan.RunOnApply, types.StringNull()) {
+		runJobOnCreate(ctx, r.client, jobs.JobTypeSync, job.ID, plan.WaitForRun, plan.RunTimeout, &resp.Diagnostics)
+	}
+	r.setJobStatus(ctx, &state)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -315,6 +388,8 @@ func (r *syncJobResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	resp.Diagnostics.Append(setSyncStateFromAPI(ctx, job, &state)...)
+	state.WaitForRun, state.RunTimeout = jobRunDefaults(state.WaitForRun, state.RunTimeout)
+	r.setJobStatus(ctx, &state)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -348,7 +423,17 @@ func (r *syncJobResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	job.Delete = computeSyncDeletes(&plan, &state)
 
-	if err := r.client.Jobs.UpdateSyncJob(ctx, plan.ID.ValueString(), job); err != nil {
+	if job.Remote != state.Remote.ValueString() || job.RemoteStore != state.RemoteStore.ValueString() || job.RemoteNamespace != state.RemoteNamespace.ValueString() {
+		resp.Diagnostics.Append(r.checkPushTarget(ctx, job)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.UpdateSyncJob(ctx, plan.ID.ValueString(), job)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating sync job",
 			fmt.Sprintf("Could not update sync job %s: %s", plan.ID.ValueString(), err.Error()),
@@ -370,6 +455,18 @@ func (r *syncJobResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	runOnApply := plan.RunOnApply
+	if runRequested(plan.RunOnApply, state.RunOnApply) {
+		runDiags := runJobOnApply(ctx, r.client, jobs.JobTypeSync, plan.ID.ValueString(), plan.WaitForRun, plan.RunTimeout)
+		resp.Diagnostics.Append(runDiags...)
+		if runDiags.HasError() {
+			// Keep the previous trigger so that the next apply retries the run.
+			runOnApply = state.RunOnApply
+		}
+	}
+	state.RunOnApply, state.WaitForRun, state.RunTimeout = runOnApply, plan.WaitForRun, plan.RunTimeout
+	r.setJobStatus(ctx, &state)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -386,7 +483,10 @@ func (r *syncJobResource) Delete(ctx context.Context, req resource.DeleteRequest
 		digest = state.Digest.ValueString()
 	}
 
-	if err := r.client.Jobs.DeleteSyncJob(ctx, state.ID.ValueString(), digest); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.DeleteSyncJob(ctx, state.ID.ValueString(), digest)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting sync job",
 			fmt.Sprintf("Could not delete sync job %s: %s", state.ID.ValueString(), err.Error()),
@@ -399,6 +499,66 @@ func (r *syncJobResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// checkPushTarget verifies that the target datastore and namespace of a push job
+// are visible to the remote's credentials. A remote that cannot be scanned only
+// produces a warning, since the job may still work once it is reachable.
+func (r *syncJobResource) checkPushTarget(ctx context.Context, job *jobs.SyncJob) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if job.SyncDirection != jobs.SyncDirectionPush {
+		return diags
+	}
+
+	stores, err := r.client.Remotes.ListRemoteStores(ctx, job.Remote)
+	if err != nil {
+		diags.AddWarning(
+			"Could not check push target",
+			fmt.Sprintf("Listing the datastores of remote %s failed, so the push target was not checked: %s", job.Remote, err.Error()),
+		)
+		return diags
+	}
+
+	found := false
+	for _, store := range stores {
+		if store.Name == job.RemoteStore {
+			found = true
+			break
+		}
+	}
+	if !found {
+		diags.AddAttributeError(
+			path.Root("remote_store"),
+			"Push target datastore not accessible",
+			fmt.Sprintf("Datastore %s is not visible on remote %s. The remote's auth_id needs Datastore.Backup on the target namespace "+
+				"(and Datastore.Prune for remove_vanished) for push sync jobs.", job.RemoteStore, job.Remote),
+		)
+		return diags
+	}
+
+	if job.RemoteNamespace == "" {
+		return diags
+	}
+	namespaces, err := r.client.Remotes.ListRemoteNamespaces(ctx, job.Remote, job.RemoteStore)
+	if err != nil {
+		diags.AddWarning(
+			"Could not check push target",
+			fmt.Sprintf("Listing the namespaces of %s on remote %s failed: %s", job.RemoteStore, job.Remote, err.Error()),
+		)
+		return diags
+	}
+	for _, ns := range namespaces {
+		if ns.Namespace == job.RemoteNamespace {
+			return diags
+		}
+	}
+	diags.AddAttributeError(
+		path.Root("remote_namespace"),
+		"Push target namespace not found",
+		fmt.Sprintf("Namespace %q does not exist in datastore %s on remote %s, or is not visible to the remote's auth_id. "+
+			"Create it on the remote before pushing into it.", job.RemoteNamespace, job.RemoteStore, job.Remote),
+	)
+	return diags
+}
+
 func buildSyncJobFromPlan(ctx context.Context, plan *syncJobResourceModel) (*jobs.SyncJob, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -425,8 +585,9 @@ func buildSyncJobFromPlan(ctx context.Context, plan *syncJobResourceModel) (*job
 	job.VerifiedOnly = boolPointerFromAttr(plan.VerifiedOnly)
 	job.RunOnMount = boolPointerFromAttr(plan.RunOnMount)
 
-	if !plan.SyncDirection.IsNull() && !plan.SyncDirection.IsUnknown() {
-		job.SyncDirection = plan.SyncDirection.ValueString()
+	// Pull is the PBS default; leaving it unset keeps releases without push support working
+	if plan.SyncDirection.ValueString() == jobs.SyncDirectionPush {
+		job.SyncDirection = jobs.SyncDirectionPush
 	}
 	if !plan.Owner.IsNull() && !plan.Owner.IsUnknown() {
 		job.Owner = plan.Owner.ValueString()
@@ -447,7 +608,7 @@ func buildSyncJobFromPlan(ctx context.Context, plan *syncJobResourceModel) (*job
 		job.Comment = plan.Comment.ValueString()
 	}
 
-	filters, filterDiags := stringListFromAttribute(ctx, plan.GroupFilter)
+	filters, filterDiags := groupFiltersToAPI(ctx, plan.GroupFilter)
 	diags.Append(filterDiags...)
 	if filterDiags.HasError() {
 		return nil, diags
@@ -479,7 +640,7 @@ func computeSyncDeletes(plan, state *syncJobResourceModel) []string {
 	if shouldDeleteIntAttr(plan.MaxDepth, state.MaxDepth) {
 		deletes = append(deletes, "max-depth")
 	}
-	if shouldDeleteListAttr(plan.GroupFilter, state.GroupFilter) {
+	if len(plan.GroupFilter.Elements()) == 0 && len(state.GroupFilter.Elements()) > 0 {
 		deletes = append(deletes, "group-filter")
 	}
 	if shouldDeleteBoolAttr(plan.RemoveVanished, state.RemoveVanished) {
@@ -560,14 +721,8 @@ func setSyncStateFromAPI(ctx context.Context, job *jobs.SyncJob, state *syncJobR
 	state.Namespace = stringValueOrNull(job.Namespace)
 	state.MaxDepth = int64ValueOrNull(job.MaxDepth)
 
-	groupFilter := types.ListNull(types.StringType)
-	if len(job.GroupFilter) > 0 {
-		listValue, listDiags := types.ListValueFrom(ctx, types.StringType, job.GroupFilter)
-		diags.Append(listDiags...)
-		if !listDiags.HasError() {
-			groupFilter = listValue
-		}
-	}
+	groupFilter, filterDiags := groupFiltersFromAPI(ctx, job.GroupFilter)
+	diags.Append(filterDiags...)
 	state.GroupFilter = groupFilter
 
 	if job.RemoveVanished != nil {
@@ -597,7 +752,11 @@ func setSyncStateFromAPI(ctx context.Context, job *jobs.SyncJob, state *syncJobR
 	}
 
 	state.TransferLast = int64ValueOrNull(job.TransferLast)
-	state.SyncDirection = stringValueOrNull(job.SyncDirection)
+	// PBS omits the direction for pull jobs
+	state.SyncDirection = types.StringValue(jobs.SyncDirectionPull)
+	if job.SyncDirection != "" {
+		state.SyncDirection = types.StringValue(job.SyncDirection)
+	}
 	state.Owner = stringValueOrNull(job.Owner)
 	state.RateIn = stringValueOrNull(normalizeRateString(job.RateIn))
 	state.RateOut = stringValueOrNull(normalizeRateString(job.RateOut))
@@ -608,3 +767,141 @@ func setSyncStateFromAPI(ctx context.Context, job *jobs.SyncJob, state *syncJobR
 
 	return diags
 }
+
+// syncJobResourceModelV0 is the state layout before group_filter became a
+// block and the run status and run_on_apply attributes were added.
+type syncJobResourceModelV0 struct {
+	ID              types.String `tfsdk:"id"`
+	Store           types.String `tfsdk:"store"`
+	Schedule        types.String `tfsdk:"schedule"`
+	Remote          types.String `tfsdk:"remote"`
+	RemoteStore     types.String `tfsdk:"remote_store"`
+	RemoteNamespace types.String `tfsdk:"remote_namespace"`
+	Namespace       types.String `tfsdk:"namespace"`
+	MaxDepth        types.Int64  `tfsdk:"max_depth"`
+	GroupFilter     types.List   `tfsdk:"group_filter"`
+	RemoveVanished  types.Bool   `tfsdk:"remove_vanished"`
+	ResyncCorrupt   types.Bool   `tfsdk:"resync_corrupt"`
+	EncryptedOnly   types.Bool   `tfsdk:"encrypted_only"`
+	VerifiedOnly    types.Bool   `tfsdk:"verified_only"`
+	RunOnMount      types.Bool   `tfsdk:"run_on_mount"`
+	TransferLast    types.Int64  `tfsdk:"transfer_last"`
+	SyncDirection   types.String `tfsdk:"sync_direction"`
+	Owner           types.String `tfsdk:"owner"`
+	RateIn          types.String `tfsdk:"rate_in"`
+	RateOut         types.String `tfsdk:"rate_out"`
+	BurstIn         types.String `tfsdk:"burst_in"`
+	BurstOut        types.String `tfsdk:"burst_out"`
+	Comment         types.String `tfsdk:"comment"`
+	Digest          types.String `tfsdk:"digest"`
+}
+
+// syncJobSchemaV0 is the schema of syncJobResourceModelV0, where group_filter
+// held the raw PBS entries.
+func syncJobSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":               schema.StringAttribute{Required: true},
+			"store":            schema.StringAttribute{Required: true},
+			"schedule":         schema.StringAttribute{Required: true},
+			"remote":           schema.StringAttribute{Required: true},
+			"remote_store":     schema.StringAttribute{Required: true},
+			"remote_namespace": schema.StringAttribute{Optional: true},
+			"namespace":        schema.StringAttribute{Optional: true},
+			"max_depth":        schema.Int64Attribute{Optional: true},
+			"group_filter":     schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"remove_vanished":  schema.BoolAttribute{Optional: true, Computed: true},
+			"resync_corrupt":   schema.BoolAttribute{Optional: true, Computed: true},
+			"encrypted_only":   schema.BoolAttribute{Optional: true, Computed: true},
+			"verified_only":    schema.BoolAttribute{Optional: true, Computed: true},
+			"run_on_mount":     schema.BoolAttribute{Optional: true, Computed: true},
+			"transfer_last":    schema.Int64Attribute{Optional: true},
+			"sync_direction":   schema.StringAttribute{Optional: true, Computed: true},
+			"owner":            schema.StringAttribute{Optional: true},
+			"rate_in":          schema.StringAttribute{Optional: true},
+			"rate_out":         schema.StringAttribute{Optional: true},
+			"burst_in":         schema.StringAttribute{Optional: true},
+			"burst_out":        schema.StringAttribute{Optional: true},
+			"comment":          schema.StringAttribute{Optional: true},
+			"digest":           schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// UpgradeState converts state written before group_filter became a block. The
+// previous schema stored group_filter as a list of raw PBS entries.
+func (r *syncJobResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := syncJobSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeSyncJobStateV0,
+		},
+	}
+}
+
+// upgradeSyncJobStateV0 converts a version 0 state. The run status attributes
+// are left null for the next refresh, and the run settings get their defaults.
+func upgradeSyncJobStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior syncJobResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := stringListFromAttribute(ctx, prior.GroupFilter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupFilter, diags := groupFiltersFromAPI(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := syncJobResourceModel{
+		ID:              prior.ID,
+		Store:           prior.Store,
+		Schedule:        prior.Schedule,
+		Remote:          prior.Remote,
+		RemoteStore:     prior.RemoteStore,
+		RemoteNamespace: prior.RemoteNamespace,
+		Namespace:       prior.Namespace,
+		MaxDepth:        prior.MaxDepth,
+		GroupFilter:     groupFilter,
+		RemoveVanished:  prior.RemoveVanished,
+		ResyncCorrupt:   prior.ResyncCorrupt,
+		EncryptedOnly:   prior.EncryptedOnly,
+		VerifiedOnly:    prior.VerifiedOnly,
+		RunOnMount:      prior.RunOnMount,
+		TransferLast:    prior.TransferLast,
+		SyncDirection:   prior.SyncDirection,
+		Owner:           prior.Owner,
+		RateIn:          prior.RateIn,
+		RateOut:         prior.RateOut,
+		BurstIn:         prior.BurstIn,
+		BurstOut:        prior.BurstOut,
+		Comment:         prior.Comment,
+		Digest:          prior.Digest,
+		LastRunState:    types.StringNull(),
+		LastRunFailed:   types.BoolNull(),
+		LastRunUPID:     types.StringNull(),
+		LastRunEndtime:  types.StringNull(),
+		NextRun:         types.StringNull(),
+		RunOnApply:      types.StringNull(),
+		WaitForRun:      types.BoolValue(false),
+		RunTimeout:      types.StringValue(defaultRunTimeout),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// setJobStatus refreshes the run status attributes of state.
+func (r *syncJobResource) setJobStatus(ctx context.Context, state *syncJobResourceModel) {
+	id := state.ID.ValueString()
+	status, err := r.client.Jobs.GetSyncJobStatus(ctx, id, state.SyncDirection.ValueString())
+	state.LastRunState, state.LastRunUPID, state.LastRunEndtime, state.NextRun, state.LastRunFailed = jobStatusValues(jobStatusOrNil(ctx, id, status, err))
+}
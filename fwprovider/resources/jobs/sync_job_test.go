@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/micah/terraform-provider-pbs/pbs/jobs"
+)
+
+func TestUpgradeSyncJobStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	priorSchema := syncJobSchemaV0()
+	prior := tfsdk.State{Schema: priorSchema, Raw: tftypes.NewValue(priorSchema.Type().TerraformType(ctx), nil)}
+	for name, value := range map[string]any{
+		"id":             "offsite",
+		"store":          "backup",
+		"schedule":       "hourly",
+		"remote":         "pbs2",
+		"remote_store":   "backup",
+		"sync_direction": jobs.SyncDirectionPull,
+		"digest":         "abc123",
+	} {
+		if diags := prior.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("setting %s: %v", name, diags)
+		}
+	}
+	if diags := prior.SetAttribute(ctx, path.Root("remove_vanished"), true); diags.HasError() {
+		t.Fatalf("setting remove_vanished: %v", diags)
+	}
+	if diags := prior.SetAttribute(ctx, path.Root("group_filter"), []string{"group:vm/100", "exclude:type:ct"}); diags.HasError() {
+		t.Fatalf("setting group_filter: %v", diags)
+	}
+
+	var current resource.SchemaResponse
+	(&syncJobResource{}).Schema(ctx, resource.SchemaRequest{}, &current)
+
+	req := resource.UpgradeStateRequest{State: &prior}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: current.Schema, Raw: tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgradeSyncJobStateV0(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade failed: %v", resp.Diagnostics)
+	}
+
+	var state syncJobResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("reading upgraded state: %v", diags)
+	}
+
+	var filters []groupFilterModel
+	if diags := state.GroupFilter.ElementsAs(ctx, &filters, false); diags.HasError() {
+		t.Fatalf("reading group_filter: %v", diags)
+	}
+	var got [][3]string
+	for _, f := range filters {
+		got = append(got, [3]string{f.Action.ValueString(), f.Kind.ValueString(), f.Value.ValueString()})
+	}
+	want := [][3]string{
+		{jobs.GroupFilterInclude, "group", "vm/100"},
+		{jobs.GroupFilterExclude, "type", "ct"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("group_filter = %v, want %v", got, want)
+	}
+
+	if state.ID.ValueString() != "offsite" || state.Digest.ValueString() != "abc123" || !state.RemoveVanished.ValueBool() {
+		t.Errorf("v0 attributes not carried over: id=%s digest=%s remove_vanished=%s", state.ID, state.Digest, state.RemoveVanished)
+	}
+	if !state.LastRunState.IsNull() || !state.NextRun.IsNull() || !state.RunOnApply.IsNull() {
+		t.Errorf("status and trigger attributes should be null, got last_run_state=%s next_run=%s run_on_apply=%s",
+			state.LastRunState, state.NextRun, state.RunOnApply)
+	}
+	if state.WaitForRun.ValueBool() || state.RunTimeout.ValueString() != defaultRunTimeout {
+		t.Errorf("run settings should have their defaults, got wait_for_run=%s run_timeout=%s", state.WaitForRun, state.RunTimeout)
+	}
+}
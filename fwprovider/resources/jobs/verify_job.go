@@ -9,6 +9,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -21,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/jobs"
 )
@@ -53,6 +55,14 @@ type verifyJobResourceModel struct {
 	MaxDepth       types.Int64  `tfsdk:"max_depth"`
 	Comment        types.String `tfsdk:"comment"`
 	Digest         types.String `tfsdk:"digest"`
+	LastRunState   types.String `tfsdk:"last_run_state"`
+	LastRunFailed  types.Bool   `tfsdk:"last_run_failed"`
+	LastRunUPID    types.String `tfsdk:"last_run_upid"`
+	LastRunEndtime types.String `tfsdk:"last_run_endtime"`
+	NextRun        types.String `tfsdk:"next_run"`
+	RunOnApply     types.String `tfsdk:"run_on_apply"`
+	WaitForRun     types.Bool   `tfsdk:"wait_for_run"`
+	RunTimeout     types.String `tfsdk:"run_timeout"`
 }
 
 // Metadata returns the resource type name.
@@ -88,6 +98,9 @@ determines how many days until a backup is considered due for re-verification.`,
 				Description:         "When to run the verify job (systemd calendar event format).",
 				MarkdownDescription: "When to run the verification job. Uses systemd calendar event format (e.g., `weekly`, `Mon 03:00`).",
 				Required:            true,
+				Validators: []validator.String{
+					validators.CalendarEvent(),
+				},
 			},
 			"ignore_verified": schema.BoolAttribute{
 				Description:         "Skip backups that have been recently verified.",
@@ -132,6 +145,8 @@ determines how many days until a backup is considered due for re-verification.`,
 			},
 		},
 	}
+	maps.Copy(resp.Schema.Attributes, jobStatusAttributes())
+	maps.Copy(resp.Schema.Attributes, jobRunAttributes())
 }
 
 // Configure adds the provider configured client to the resource.
@@ -162,7 +177,10 @@ func (r *verifyJobResource) Create(ctx context.Context, req resource.CreateReque
 
 	job := buildVerifyJobFromPlan(&plan)
 
-	if err := r.client.Jobs.CreateVerifyJob(ctx, job); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.CreateVerifyJob(ctx, job)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating verify job",
 			fmt.Sprintf("Could not create verify job %s: %s", plan.ID.ValueString(), err.Error()),
@@ -181,6 +199,11 @@ func (r *verifyJobResource) Create(ctx context.Context, req resource.CreateReque
 
 	var state verifyJobResourceModel
 	setVerifyStateFromAPI(createdJob, &state)
+	state.RunOnApply, state.WaitForRun, state.RunTimeout = plan.RunOnApply, plan.WaitForRun, plan.RunTimeout
+	if runRequested(plan.RunOnApply, types.StringNull()) {
+		runJobOnCreate(ctx, r.client, jobs.JobTypeVerify, job.ID, plan.WaitForRun, plan.RunTimeout, &resp.Diagnostics)
+	}
+	r.setJobStatus(ctx, &state)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -203,6 +226,8 @@ func (r *verifyJobResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	setVerifyStateFromAPI(job, &state)
+	state.WaitForRun, state.RunTimeout = jobRunDefaults(state.WaitForRun, state.RunTimeout)
+	r.setJobStatus(ctx, &state)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -227,7 +252,10 @@ func (r *verifyJobResource) Update(ctx context.Context, req resource.UpdateReque
 	job := buildVerifyJobFromPlan(&plan)
 	job.Delete = computeVerifyDeletes(&plan, &state)
 
-	if err := r.client.Jobs.UpdateVerifyJob(ctx, plan.ID.ValueString(), job); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.UpdateVerifyJob(ctx, plan.ID.ValueString(), job)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating verify job",
 			fmt.Sprintf("Could not update verify job %s: %s", plan.ID.ValueString(), err.Error()),
@@ -245,6 +273,17 @@ func (r *verifyJobResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	setVerifyStateFromAPI(updatedJob, &state)
+	runOnApply := plan.RunOnApply
+	if runRequested(plan.RunOnApply, state.RunOnApply) {
+		runDiags := runJobOnApply(ctx, r.client, jobs.JobTypeVerify, plan.ID.ValueString(), plan.WaitForRun, plan.RunTimeout)
+		resp.Diagnostics.Append(runDiags...)
+		if runDiags.HasError() {
+			// Keep the previous trigger so that the next apply retries the run.
+			runOnApply = state.RunOnApply
+		}
+	}
+	state.RunOnApply, state.WaitForRun, state.RunTimeout = runOnApply, plan.WaitForRun, plan.RunTimeout
+	r.setJobStatus(ctx, &state)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -261,7 +300,10 @@ func (r *verifyJobResource) Delete(ctx context.Context, req resource.DeleteReque
 		digest = state.Digest.ValueString()
 	}
 
-	if err := r.client.Jobs.DeleteVerifyJob(ctx, state.ID.ValueString(), digest); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainJobs, func() error {
+		return r.client.Jobs.DeleteVerifyJob(ctx, state.ID.ValueString(), digest)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting verify job",
 			fmt.Sprintf("Could not delete verify job %s: %s", state.ID.ValueString(), err.Error()),
@@ -341,3 +383,10 @@ func setVerifyStateFromAPI(job *jobs.VerifyJob, state *verifyJobResourceModel) {
 		state.IgnoreVerified = types.BoolValue(false)
 	}
 }
+
+// setJobStatus refreshes the run status attributes of state.
+func (r *verifyJobResource) setJobStatus(ctx context.Context, state *verifyJobResourceModel) {
+	id := state.ID.ValueString()
+	status, err := r.client.Jobs.GetVerifyJobStatus(ctx, id)
+	state.LastRunState, state.LastRunUPID, state.LastRunEndtime, state.NextRun, state.LastRunFailed = jobStatusValues(jobStatusOrNil(ctx, id, status, err))
+}
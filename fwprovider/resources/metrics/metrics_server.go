@@ -9,9 +9,9 @@ package metrics
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,19 +20,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/writeonly"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/metrics"
 )
 
-// metricsServerMutex is a global mutex to prevent concurrent PBS metrics server operations.
-// PBS holds an exclusive lock on /etc/proxmox-backup/.metricserver.lck when writing
-// metrics server configurations, which can cause lock contention errors when multiple
-// operations happen simultaneously. This mutex ensures operations are serialized.
-var metricsServerMutex sync.Mutex
-
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource                = &metricsServerResource{}
@@ -52,21 +48,26 @@ type metricsServerResource struct {
 
 // metricsServerResourceModel maps the resource schema data.
 type metricsServerResourceModel struct {
-	Name         types.String `tfsdk:"name"`
-	Type         types.String `tfsdk:"type"`
-	URL          types.String `tfsdk:"url"`
-	Server       types.String `tfsdk:"server"`
-	Port         types.Int64  `tfsdk:"port"`
-	Enable       types.Bool   `tfsdk:"enable"`
-	MTU          types.Int64  `tfsdk:"mtu"`
-	Protocol     types.String `tfsdk:"protocol"`
-	Organization types.String `tfsdk:"organization"`
-	Bucket       types.String `tfsdk:"bucket"`
-	Token        types.String `tfsdk:"token"`
-	MaxBodySize  types.Int64  `tfsdk:"max_body_size"`
-	VerifyTLS    types.Bool   `tfsdk:"verify_tls"`
-	Timeout      types.Int64  `tfsdk:"timeout"`
-	Comment      types.String `tfsdk:"comment"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	URL            types.String `tfsdk:"url"`
+	Server         types.String `tfsdk:"server"`
+	Port           types.Int64  `tfsdk:"port"`
+	Enable         types.Bool   `tfsdk:"enable"`
+	MTU            types.Int64  `tfsdk:"mtu"`
+	Protocol       types.String `tfsdk:"protocol"`
+	Organization   types.String `tfsdk:"organization"`
+	Bucket         types.String `tfsdk:"bucket"`
+	Token          types.String `tfsdk:"token"`
+	TokenWO        types.String `tfsdk:"token_wo"`
+	TokenWOVersion types.Int64  `tfsdk:"token_wo_version"`
+	TokenHash      types.String `tfsdk:"token_hash"`
+	VerifySecret   types.Bool   `tfsdk:"verify_secret"`
+	VerifyOnApply  types.Bool   `tfsdk:"verify_on_apply"`
+	MaxBodySize    types.Int64  `tfsdk:"max_body_size"`
+	VerifyTLS      types.Bool   `tfsdk:"verify_tls"`
+	Timeout        types.Int64  `tfsdk:"timeout"`
+	Comment        types.String `tfsdk:"comment"`
 }
 
 // Metadata returns the resource type name.
@@ -155,6 +156,21 @@ PBS should send its performance and usage metrics.`,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token_wo":         writeonly.SecretAttribute("token", "InfluxDB API token (InfluxDB HTTP only)."),
+			"token_wo_version": writeonly.VersionAttribute("token"),
+			"token_hash":       writeonly.HashAttribute("token"),
+			"verify_secret": writeonly.VerifyAttribute("reading the bucket from InfluxDB with the configured token. " +
+				"Only applies to `influxdb-http` servers using `token`; write-only tokens are not available during refresh"),
+			"verify_on_apply": schema.BoolAttribute{
+				Description: "Check that the metrics server is reachable before it is created or updated.",
+				MarkdownDescription: "Check that the metrics server is reachable before it is created or updated, and fail the apply " +
+					"if it is not. For `influxdb-http`, a write without any points is sent to the bucket, which reports an " +
+					"unreachable server, a rejected token, a token without write access and a missing organization or bucket. " +
+					"For `influxdb-udp`, only name resolution of the host is checked, since PBS sends these metrics over UDP " +
+					"and delivery cannot be confirmed. PBS has no endpoint to test a metrics server, so the check runs from the machine " +
+					"running Terraform, not from PBS. Defaults to `false`.",
+				Optional: true,
+			},
 			"max_body_size": schema.Int64Attribute{
 				Description:         "Maximum body size for HTTP requests in bytes (InfluxDB HTTP only).",
 				MarkdownDescription: "Maximum body size for HTTP requests in bytes. Only applicable for `influxdb-http` type. Defaults to `25000000` (25MB).",
@@ -240,6 +256,7 @@ func (r *metricsServerResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	// Type-specific fields
+	plan.TokenHash = types.StringNull()
 	switch server.Type {
 	case metrics.MetricsServerTypeInfluxDBUDP:
 		// PBS 4.0: Protocol field removed (was always "udp" anyway)
@@ -250,9 +267,13 @@ func (r *metricsServerResource) Create(ctx context.Context, req resource.CreateR
 		if !plan.Bucket.IsNull() {
 			server.Bucket = plan.Bucket.ValueString()
 		}
-		if !plan.Token.IsNull() {
-			server.Token = plan.Token.ValueString()
+		token, diags := writeonly.Value(ctx, req.Config, "token", plan.Token)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		server.Token = token
+		plan.TokenHash = writeonly.CreateHash(token, &resp.Diagnostics)
 		if !plan.MaxBodySize.IsNull() {
 			maxBodySize := int(plan.MaxBodySize.ValueInt64())
 			server.MaxBodySize = &maxBodySize
@@ -264,12 +285,16 @@ func (r *metricsServerResource) Create(ctx context.Context, req resource.CreateR
 		// PBS 4.0: Timeout field removed
 	}
 
-	// Acquire mutex to prevent concurrent metrics server operations
-	// PBS holds an exclusive lock on the metrics config file
-	metricsServerMutex.Lock()
-	defer metricsServerMutex.Unlock()
+	if plan.VerifyOnApply.ValueBool() {
+		resp.Diagnostics.Append(verifyConnectivity(ctx, req.Config, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	err := r.client.Metrics.CreateMetricsServer(ctx, server)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainMetrics, func() error {
+		return r.client.Metrics.CreateMetricsServer(ctx, server)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating metrics server",
@@ -339,6 +364,16 @@ func (r *metricsServerResource) Read(ctx context.Context, req resource.ReadReque
 			state.VerifyTLS = types.BoolValue(*server.VerifyTLS)
 		}
 		// PBS 4.0: Timeout field removed
+
+		// PBS cannot test a metrics server, so the token is checked against InfluxDB
+		// directly. Write-only tokens are not in state and cannot be checked.
+		if !state.Token.IsNull() {
+			secret := writeonly.Secret{Attr: "token", Plain: &state.Token, Version: &state.TokenWOVersion, Hash: &state.TokenHash}
+			writeonly.Verify(ctx, state.VerifySecret, secret, "metrics server "+state.Name.ValueString(), func() error {
+				return metrics.CheckInfluxDBToken(ctx, influxDBURL(&state), state.Organization.ValueString(),
+					state.Bucket.ValueString(), state.Token.ValueString(), state.VerifyTLS.IsNull() || state.VerifyTLS.ValueBool())
+			}, &resp.Diagnostics)
+		}
 	}
 
 	// Set refreshed state
@@ -354,6 +389,12 @@ func (r *metricsServerResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	var state metricsServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update metrics server via API
 	server := &metrics.MetricsServer{
 		Name: plan.Name.ValueString(),
@@ -392,9 +433,14 @@ func (r *metricsServerResource) Update(ctx context.Context, req resource.UpdateR
 		if !plan.Bucket.IsNull() {
 			server.Bucket = plan.Bucket.ValueString()
 		}
-		if !plan.Token.IsNull() {
-			server.Token = plan.Token.ValueString()
+		// Write-only values are only sent when token_wo_version changes
+		token, diags := writeonly.UpdateValue(ctx, req.Config, "token", plan.Token, plan.TokenWOVersion, state.TokenWOVersion)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		server.Token = token
+		plan.TokenHash = writeonly.UpdateHash(token, state.TokenHash, &resp.Diagnostics)
 		if !plan.MaxBodySize.IsNull() {
 			maxBodySize := int(plan.MaxBodySize.ValueInt64())
 			server.MaxBodySize = &maxBodySize
@@ -406,11 +452,16 @@ func (r *metricsServerResource) Update(ctx context.Context, req resource.UpdateR
 		// PBS 4.0: Timeout field removed
 	}
 
-	// Acquire mutex to prevent concurrent metrics server operations
-	metricsServerMutex.Lock()
-	defer metricsServerMutex.Unlock()
+	if plan.VerifyOnApply.ValueBool() {
+		resp.Diagnostics.Append(verifyConnectivity(ctx, req.Config, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	err := r.client.Metrics.UpdateMetricsServer(ctx, serverType, plan.Name.ValueString(), server)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainMetrics, func() error {
+		return r.client.Metrics.UpdateMetricsServer(ctx, serverType, plan.Name.ValueString(), server)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating metrics server",
@@ -435,11 +486,9 @@ func (r *metricsServerResource) Delete(ctx context.Context, req resource.DeleteR
 	// Delete metrics server via API
 	serverType := metrics.MetricsServerType(state.Type.ValueString())
 
-	// Acquire mutex to prevent concurrent metrics server operations
-	metricsServerMutex.Lock()
-	defer metricsServerMutex.Unlock()
-
-	err := r.client.Metrics.DeleteMetricsServer(ctx, serverType, state.Name.ValueString())
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainMetrics, func() error {
+		return r.client.Metrics.DeleteMetricsServer(ctx, serverType, state.Name.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting metrics server",
@@ -454,3 +503,40 @@ func (r *metricsServerResource) ImportState(ctx context.Context, req resource.Im
 	// Import expects format: type/name (e.g., "influxdb-http/my-server")
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// influxDBURL returns the base URL of an InfluxDB HTTP metrics server
+func influxDBURL(state *metricsServerResourceModel) string {
+	if !state.URL.IsNull() && state.URL.ValueString() != "" {
+		return state.URL.ValueString()
+	}
+	return fmt.Sprintf("http://%s:%d", state.Server.ValueString(), state.Port.ValueInt64())
+}
+
+// verifyConnectivity checks that the metrics server configured in plan is
+// reachable and, for InfluxDB HTTP, that the token may write to the bucket.
+func verifyConnectivity(ctx context.Context, config tfsdk.Config, plan *metricsServerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	switch metrics.MetricsServerType(plan.Type.ValueString()) {
+	case metrics.MetricsServerTypeInfluxDBUDP:
+		err = metrics.ProbeInfluxDBUDP(ctx, plan.Server.ValueString(), int(plan.Port.ValueInt64()))
+	case metrics.MetricsServerTypeInfluxDBHTTP:
+		token, tokenDiags := writeonly.Value(ctx, config, "token", plan.Token)
+		diags.Append(tokenDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		err = metrics.ProbeInfluxDBWrite(ctx, influxDBURL(plan), plan.Organization.ValueString(),
+			plan.Bucket.ValueString(), token, plan.VerifyTLS.IsNull() || plan.VerifyTLS.ValueBool())
+	}
+
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("verify_on_apply"),
+			"Metrics server verification failed",
+			fmt.Sprintf("Metrics server %s is not usable: %s", plan.Name.ValueString(), err.Error()),
+		)
+	}
+	return diags
+}
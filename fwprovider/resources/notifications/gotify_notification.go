@@ -10,15 +10,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/writeonly"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/notifications"
 )
@@ -42,12 +45,17 @@ type gotifyNotificationResource struct {
 
 // gotifyNotificationResourceModel maps the resource schema data.
 type gotifyNotificationResourceModel struct {
-	Name    types.String `tfsdk:"name"`
-	Server  types.String `tfsdk:"server"`
-	Token   types.String `tfsdk:"token"`
-	Comment types.String `tfsdk:"comment"`
-	Disable types.Bool   `tfsdk:"disable"`
-	Origin  types.String `tfsdk:"origin"`
+	Name           types.String `tfsdk:"name"`
+	Server         types.String `tfsdk:"server"`
+	Token          types.String `tfsdk:"token"`
+	TokenWO        types.String `tfsdk:"token_wo"`
+	TokenWOVersion types.Int64  `tfsdk:"token_wo_version"`
+	TokenHash      types.String `tfsdk:"token_hash"`
+	VerifySecret   types.Bool   `tfsdk:"verify_secret"`
+	Comment        types.String `tfsdk:"comment"`
+	Disable        types.Bool   `tfsdk:"disable"`
+	Origin         types.String `tfsdk:"origin"`
+	TestOnApply    types.Bool   `tfsdk:"test_on_apply"`
 }
 
 // Metadata returns the resource type name.
@@ -80,9 +88,16 @@ verification tasks, and system events.`,
 			"token": schema.StringAttribute{
 				Description:         "Gotify application token.",
 				MarkdownDescription: "Gotify application token for authentication.",
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("token_wo")),
+				},
 			},
+			"token_wo":         writeonly.SecretAttribute("token", "Gotify application token."),
+			"token_wo_version": writeonly.VersionAttribute("token"),
+			"token_hash":       writeonly.HashAttribute("token"),
+			"verify_secret":    writeonly.VerifyByNotificationAttribute("which posts to the Gotify server with the stored token"),
 			"comment": schema.StringAttribute{
 				Description:         "A comment describing this notification target.",
 				MarkdownDescription: "A comment describing this notification target.",
@@ -95,6 +110,7 @@ verification tasks, and system events.`,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"test_on_apply": testOnApplyAttribute(),
 			"origin": schema.StringAttribute{
 				Description:         "Origin of this configuration as reported by PBS.",
 				MarkdownDescription: "Origin of this configuration as reported by PBS (e.g., `user`, `builtin`).",
@@ -131,10 +147,16 @@ func (r *gotifyNotificationResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	token, diags := writeonly.Value(ctx, req.Config, "token", plan.Token)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	target := &notifications.GotifyTarget{
 		Name:   plan.Name.ValueString(),
 		Server: plan.Server.ValueString(),
-		Token:  plan.Token.ValueString(),
+		Token:  token,
 	}
 
 	if !plan.Comment.IsNull() {
@@ -145,7 +167,9 @@ func (r *gotifyNotificationResource) Create(ctx context.Context, req resource.Cr
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.CreateGotifyTarget(ctx, target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.CreateGotifyTarget(ctx, target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Gotify notification target",
@@ -183,7 +207,14 @@ func (r *gotifyNotificationResource) Create(ctx context.Context, req resource.Cr
 		plan.Origin = types.StringNull()
 	}
 
+	plan.TokenHash = writeonly.CreateHash(token, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -223,6 +254,16 @@ func (r *gotifyNotificationResource) Read(ctx context.Context, req resource.Read
 		state.Origin = types.StringNull()
 	}
 
+	// test_on_apply is not stored by PBS; default it for imported resources
+	if state.TestOnApply.IsNull() {
+		state.TestOnApply = types.BoolValue(false)
+	}
+
+	secret := writeonly.Secret{Attr: "token", Plain: &state.Token, Version: &state.TokenWOVersion, Hash: &state.TokenHash}
+	writeonly.Verify(ctx, state.VerifySecret, secret, "Gotify notification target "+state.Name.ValueString(), func() error {
+		return r.client.Notifications.TestTarget(ctx, state.Name.ValueString())
+	}, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -235,10 +276,23 @@ func (r *gotifyNotificationResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	var state gotifyNotificationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only values are only sent when token_wo_version changes
+	token, diags := writeonly.UpdateValue(ctx, req.Config, "token", plan.Token, plan.TokenWOVersion, state.TokenWOVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	target := &notifications.GotifyTarget{
 		Name:   plan.Name.ValueString(),
 		Server: plan.Server.ValueString(),
-		Token:  plan.Token.ValueString(),
+		Token:  token,
 	}
 
 	if !plan.Comment.IsNull() {
@@ -249,7 +303,9 @@ func (r *gotifyNotificationResource) Update(ctx context.Context, req resource.Up
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.UpdateGotifyTarget(ctx, plan.Name.ValueString(), target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.UpdateGotifyTarget(ctx, plan.Name.ValueString(), target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Gotify notification target",
@@ -287,7 +343,14 @@ func (r *gotifyNotificationResource) Update(ctx context.Context, req resource.Up
 		plan.Origin = types.StringNull()
 	}
 
+	plan.TokenHash = writeonly.UpdateHash(token, state.TokenHash, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -299,7 +362,9 @@ func (r *gotifyNotificationResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	err := r.client.Notifications.DeleteGotifyTarget(ctx, state.Name.ValueString())
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.DeleteGotifyTarget(ctx, state.Name.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Gotify notification target",
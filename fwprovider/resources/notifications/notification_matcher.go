@@ -9,8 +9,12 @@ package notifications
 import (
 	"context"
 	"fmt"
+	"maps"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,15 +27,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/validators"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/notifications"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &notificationMatcherResource{}
-	_ resource.ResourceWithConfigure   = &notificationMatcherResource{}
-	_ resource.ResourceWithImportState = &notificationMatcherResource{}
+	_ resource.Resource                   = &notificationMatcherResource{}
+	_ resource.ResourceWithConfigure      = &notificationMatcherResource{}
+	_ resource.ResourceWithImportState    = &notificationMatcherResource{}
+	_ resource.ResourceWithValidateConfig = &notificationMatcherResource{}
+	_ resource.ResourceWithUpgradeState   = &notificationMatcherResource{}
 )
 
 // NewNotificationMatcherResource is a helper function to simplify the provider implementation.
@@ -58,6 +65,22 @@ type notificationMatcherResourceModel struct {
 	Origin        types.String `tfsdk:"origin"`
 }
 
+// matchFieldModel maps a single match_field block.
+type matchFieldModel struct {
+	Type   types.String `tfsdk:"type"`
+	Field  types.String `tfsdk:"field"`
+	Values types.List   `tfsdk:"values"`
+}
+
+// matchFieldObjectType is the object type of a match_field block element.
+var matchFieldObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"type":   types.StringType,
+		"field":  types.StringType,
+		"values": types.ListType{ElemType: types.StringType},
+	},
+}
+
 // Metadata returns the resource type name.
 func (r *notificationMatcherResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_notification_matcher"
@@ -66,13 +89,17 @@ func (r *notificationMatcherResource) Metadata(_ context.Context, req resource.M
 // Schema defines the schema for the resource.
 func (r *notificationMatcherResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a notification matcher (routing rule).",
 		MarkdownDescription: `Manages a notification matcher.
 
 Notification matchers define rules for routing notification events to specific targets or endpoints. 
 They can filter notifications based on severity, custom fields, and calendar schedules. This allows 
 for sophisticated notification routing, such as sending critical errors to on-call staff or filtering 
-informational messages to specific channels.`,
+informational messages to specific channels.
+
+The built-in ` + "`default-matcher`" + ` can be managed by using its name: it is adopted instead of
+created, and destroying the resource resets it to route all notifications to ` + "`mail-to-root`" + `.`,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description:         "The unique name identifier for the notification matcher.",
@@ -100,20 +127,14 @@ informational messages to specific channels.`,
 					listplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"match_field": schema.ListAttribute{
-				Description:         "List of field=value pairs to match against notification metadata.",
-				MarkdownDescription: "List of `field=value` pairs to match against notification metadata (e.g., `type=prune`, `hostname=server01`).",
-				ElementType:         types.StringType,
-				Optional:            true,
-				PlanModifiers: []planmodifier.List{
-					listplanmodifier.UseStateForUnknown(),
-				},
-			},
 			"match_calendar": schema.ListAttribute{
-				Description:         "List of calendar IDs to match for time-based routing.",
-				MarkdownDescription: "List of calendar IDs to match for time-based routing (requires calendar configuration in PBS).",
+				Description:         "List of time windows to match for time-based routing.",
+				MarkdownDescription: "List of time windows to match for time-based routing, in the form `[weekdays] start-end` (e.g., `8-17`, `mon..fri 9:00-17:30`).",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(validators.DailyDuration()),
+				},
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
 				},
@@ -153,6 +174,79 @@ informational messages to specific channels.`,
 				Computed:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"match_field": schema.ListNestedBlock{
+				Description:         "Matches a notification metadata field against one or more exact values or a regular expression.",
+				MarkdownDescription: "Matches a notification metadata field against one or more exact values or a regular expression. Known fields are `type`, `hostname`, `datastore` and `job-id`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description:         "Match type: exact or regex.",
+							MarkdownDescription: "Match type: `exact` (any of the listed values) or `regex` (a single pattern). Defaults to `exact`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(notifications.MatchFieldTypeExact),
+							Validators: []validator.String{
+								stringvalidator.OneOf(notifications.MatchFieldTypeExact, notifications.MatchFieldTypeRegex),
+							},
+						},
+						"field": schema.StringAttribute{
+							Description:         "Notification metadata field to match (type, hostname, datastore, job-id).",
+							MarkdownDescription: "Notification metadata field to match: `type`, `hostname`, `datastore` or `job-id`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(notifications.KnownMatchFields...),
+							},
+						},
+						"values": schema.ListAttribute{
+							Description:         "Values to match; exact matches accept several alternatives, regex matches take exactly one pattern.",
+							MarkdownDescription: "Values to match. `exact` matches accept several alternatives (e.g., `[\"gc\", \"prune\"]`); `regex` matches take exactly one pattern.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks match_field expressions at plan time so that typos and
+// broken patterns are reported before PBS silently accepts a matcher that never fires.
+func (r *notificationMatcherResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var matchFields types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("match_field"), &matchFields)...)
+	if resp.Diagnostics.HasError() || matchFields.IsNull() || matchFields.IsUnknown() {
+		return
+	}
+
+	var blocks []matchFieldModel
+	resp.Diagnostics.Append(matchFields.ElementsAs(ctx, &blocks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, block := range blocks {
+		if block.Type.IsUnknown() || block.Field.IsUnknown() || block.Values.IsUnknown() {
+			continue
+		}
+
+		m, diags := matchFieldFromModel(ctx, block)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() || m == nil {
+			continue
+		}
+
+		if err := m.Validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("match_field").AtListIndex(i),
+				"Invalid match_field",
+				err.Error(),
+			)
+		}
 	}
 }
 
@@ -208,15 +302,12 @@ func (r *notificationMatcherResource) Create(ctx context.Context, req resource.C
 		matcher.MatchSeverity = matchSeverity
 	}
 
-	if !plan.MatchField.IsNull() {
-		var matchField []string
-		diags := plan.MatchField.ElementsAs(ctx, &matchField, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		matcher.MatchField = matchField
+	matchFieldExprs, diags := matchFieldsToAPI(ctx, plan.MatchField)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	matcher.MatchField = matchFieldExprs
 
 	if !plan.MatchCalendar.IsNull() {
 		var matchCalendar []string
@@ -246,7 +337,18 @@ func (r *notificationMatcherResource) Create(ctx context.Context, req resource.C
 		matcher.Disable = &disable
 	}
 
-	err := r.client.Notifications.CreateNotificationMatcher(ctx, matcher)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		existing, err := r.client.Notifications.GetNotificationMatcher(ctx, plan.Name.ValueString())
+		if err != nil && !notifications.IsNotFoundError(err) {
+			return err
+		}
+		if err == nil && notifications.IsBuiltinOrigin(existing.Origin) {
+			// Built-in matchers always exist and cannot be created; take over the existing entry
+			matcher.Delete = adoptMatcherDeletes(&plan, existing)
+			return r.client.Notifications.UpdateNotificationMatcher(ctx, plan.Name.ValueString(), matcher)
+		}
+		return r.client.Notifications.CreateNotificationMatcher(ctx, matcher)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating notification matcher",
@@ -288,16 +390,12 @@ func (r *notificationMatcherResource) Create(ctx context.Context, req resource.C
 		plan.MatchSeverity = types.ListNull(types.StringType)
 	}
 
-	if len(created.MatchField) > 0 {
-		matchField, diags := types.ListValueFrom(ctx, types.StringType, created.MatchField)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.MatchField = matchField
-	} else {
-		plan.MatchField = types.ListNull(types.StringType)
+	matchField, diags := matchFieldsFromAPI(ctx, created.MatchField)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	plan.MatchField = matchField
 
 	if len(created.MatchCalendar) > 0 {
 		matchCalendar, diags := types.ListValueFrom(ctx, types.StringType, created.MatchCalendar)
@@ -386,16 +484,12 @@ func (r *notificationMatcherResource) Read(ctx context.Context, req resource.Rea
 		state.MatchSeverity = types.ListNull(types.StringType)
 	}
 
-	if len(matcher.MatchField) > 0 {
-		matchField, diags := types.ListValueFrom(ctx, types.StringType, matcher.MatchField)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		state.MatchField = matchField
-	} else {
-		state.MatchField = types.ListNull(types.StringType)
+	matchField, diags := matchFieldsFromAPI(ctx, matcher.MatchField)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	state.MatchField = matchField
 
 	if len(matcher.MatchCalendar) > 0 {
 		matchCalendar, diags := types.ListValueFrom(ctx, types.StringType, matcher.MatchCalendar)
@@ -485,15 +579,12 @@ func (r *notificationMatcherResource) Update(ctx context.Context, req resource.U
 		matcher.MatchSeverity = matchSeverity
 	}
 
-	if !plan.MatchField.IsNull() {
-		var matchField []string
-		diags := plan.MatchField.ElementsAs(ctx, &matchField, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		matcher.MatchField = matchField
+	matchFieldExprs, diags := matchFieldsToAPI(ctx, plan.MatchField)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	matcher.MatchField = matchFieldExprs
 
 	if !plan.MatchCalendar.IsNull() {
 		var matchCalendar []string
@@ -523,7 +614,9 @@ func (r *notificationMatcherResource) Update(ctx context.Context, req resource.U
 		matcher.Disable = &disable
 	}
 
-	err := r.client.Notifications.UpdateNotificationMatcher(ctx, plan.Name.ValueString(), matcher)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.UpdateNotificationMatcher(ctx, plan.Name.ValueString(), matcher)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating notification matcher",
@@ -565,16 +658,12 @@ func (r *notificationMatcherResource) Update(ctx context.Context, req resource.U
 		plan.MatchSeverity = types.ListNull(types.StringType)
 	}
 
-	if len(updated.MatchField) > 0 {
-		matchField, diags := types.ListValueFrom(ctx, types.StringType, updated.MatchField)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.MatchField = matchField
-	} else {
-		plan.MatchField = types.ListNull(types.StringType)
+	matchField, diags := matchFieldsFromAPI(ctx, updated.MatchField)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	plan.MatchField = matchField
 
 	if len(updated.MatchCalendar) > 0 {
 		matchCalendar, diags := types.ListValueFrom(ctx, types.StringType, updated.MatchCalendar)
@@ -629,7 +718,23 @@ func (r *notificationMatcherResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
-	err := r.client.Notifications.DeleteNotificationMatcher(ctx, state.Name.ValueString())
+	// Built-in matchers cannot be deleted; reset them to their shipped defaults instead
+	if notifications.IsBuiltinOrigin(state.Origin.ValueString()) {
+		err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+			return r.client.Notifications.ResetNotificationMatcher(ctx, state.Name.ValueString())
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error resetting built-in notification matcher",
+				fmt.Sprintf("Could not reset built-in notification matcher %s: %s", state.Name.ValueString(), err.Error()),
+			)
+		}
+		return
+	}
+
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.DeleteNotificationMatcher(ctx, state.Name.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting notification matcher",
@@ -664,7 +769,7 @@ func computeMatcherDeletes(plan, state *notificationMatcherResourceModel) []stri
 	if shouldDeleteListAttr(plan.MatchSeverity, state.MatchSeverity) {
 		deletes = append(deletes, "match-severity")
 	}
-	if shouldDeleteListAttr(plan.MatchField, state.MatchField) {
+	if len(plan.MatchField.Elements()) == 0 && len(state.MatchField.Elements()) > 0 {
 		deletes = append(deletes, "match-field")
 	}
 	if shouldDeleteListAttr(plan.MatchCalendar, state.MatchCalendar) {
@@ -676,3 +781,154 @@ func computeMatcherDeletes(plan, state *notificationMatcherResourceModel) []stri
 
 	return deletes
 }
+
+// adoptMatcherDeletes lists the properties of an adopted built-in matcher that the plan leaves unset.
+func adoptMatcherDeletes(plan *notificationMatcherResourceModel, existing *notifications.NotificationMatcher) []string {
+	var deletes []string
+
+	if plan.Targets.IsNull() && len(existing.Targets) > 0 {
+		deletes = append(deletes, "target")
+	}
+	if plan.MatchSeverity.IsNull() && len(existing.MatchSeverity) > 0 {
+		deletes = append(deletes, "match-severity")
+	}
+	if len(plan.MatchField.Elements()) == 0 && len(existing.MatchField) > 0 {
+		deletes = append(deletes, "match-field")
+	}
+	if plan.MatchCalendar.IsNull() && len(existing.MatchCalendar) > 0 {
+		deletes = append(deletes, "match-calendar")
+	}
+	if plan.Comment.IsNull() && existing.Comment != "" {
+		deletes = append(deletes, "comment")
+	}
+
+	return deletes
+}
+
+// UpgradeState converts state written before match_field became a block. The
+// previous schema stored match_field as a list of raw PBS expressions.
+func (r *notificationMatcherResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+
+	priorAttributes := maps.Clone(current.Schema.Attributes)
+	priorAttributes["match_field"] = schema.ListAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{Attributes: priorAttributes},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var state notificationMatcherResourceModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				var exprs []string
+				if !state.MatchField.IsNull() && !state.MatchField.IsUnknown() {
+					resp.Diagnostics.Append(state.MatchField.ElementsAs(ctx, &exprs, false)...)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+				}
+
+				matchField, diags := matchFieldsFromAPI(ctx, exprs)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				state.MatchField = matchField
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
+	}
+}
+
+// matchFieldFromModel converts a match_field block into its client representation.
+// It returns nil when any value is not yet known.
+func matchFieldFromModel(ctx context.Context, block matchFieldModel) (*notifications.MatchField, diag.Diagnostics) {
+	var values []types.String
+	diags := block.Values.ElementsAs(ctx, &values, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	m := &notifications.MatchField{
+		Type:  block.Type.ValueString(),
+		Field: block.Field.ValueString(),
+	}
+	for _, v := range values {
+		if v.IsUnknown() {
+			return nil, diags
+		}
+		m.Values = append(m.Values, v.ValueString())
+	}
+
+	return m, diags
+}
+
+// matchFieldsToAPI formats match_field blocks as PBS match-field expressions.
+func matchFieldsToAPI(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+
+	var blocks []matchFieldModel
+	diags.Append(list.ElementsAs(ctx, &blocks, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var exprs []string
+	for _, block := range blocks {
+		m, d := matchFieldFromModel(ctx, block)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if m != nil {
+			exprs = append(exprs, m.String())
+		}
+	}
+
+	return exprs, diags
+}
+
+// matchFieldsFromAPI parses PBS match-field expressions into match_field blocks.
+// An empty list is returned rather than null because blocks are never null in configuration.
+func matchFieldsFromAPI(ctx context.Context, exprs []string) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	blocks := make([]matchFieldModel, 0, len(exprs))
+
+	for _, expr := range exprs {
+		m, err := notifications.ParseMatchField(expr)
+		if err != nil {
+			diags.AddError(
+				"Error parsing notification matcher match-field",
+				fmt.Sprintf("Could not parse match-field %q returned by PBS: %s", expr, err.Error()),
+			)
+			return types.ListNull(matchFieldObjectType), diags
+		}
+
+		values, d := types.ListValueFrom(ctx, types.StringType, m.Values)
+		diags.Append(d...)
+		if diags.HasError() {
+			return types.ListNull(matchFieldObjectType), diags
+		}
+
+		blocks = append(blocks, matchFieldModel{
+			Type:   types.StringValue(m.Type),
+			Field:  types.StringValue(m.Field),
+			Values: values,
+		})
+	}
+
+	list, d := types.ListValueFrom(ctx, matchFieldObjectType, blocks)
+	diags.Append(d...)
+	return list, diags
+}
@@ -0,0 +1,45 @@
+package notifications
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs/notifications"
+)
+
+func TestAdoptMatcherDeletes(t *testing.T) {
+	existing := &notifications.NotificationMatcher{
+		Name:    notifications.BuiltinMatcherName,
+		Targets: []string{notifications.BuiltinSendmailTargetName},
+		Mode:    "all",
+		Comment: "Route all notifications to mail-to-root",
+		Origin:  notifications.OriginBuiltin,
+	}
+
+	plan := &notificationMatcherResourceModel{
+		Targets:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("gotify")}),
+		MatchSeverity: types.ListNull(types.StringType),
+		MatchField:    types.ListNull(matchFieldObjectType),
+		MatchCalendar: types.ListNull(types.StringType),
+		Comment:       types.StringNull(),
+	}
+	if got := adoptMatcherDeletes(plan, existing); !reflect.DeepEqual(got, []string{"comment"}) {
+		t.Errorf("adoptMatcherDeletes() = %v, want [comment]", got)
+	}
+
+	plan.Targets = types.ListNull(types.StringType)
+	plan.Comment = types.StringValue("managed by terraform")
+	if got := adoptMatcherDeletes(plan, existing); !reflect.DeepEqual(got, []string{"target"}) {
+		t.Errorf("adoptMatcherDeletes() = %v, want [target]", got)
+	}
+
+	existing.MatchSeverity = []string{"error"}
+	existing.MatchField = []string{"exact:type=gc"}
+	existing.MatchCalendar = []string{"weekday"}
+	if got := adoptMatcherDeletes(plan, existing); !reflect.DeepEqual(got, []string{"target", "match-severity", "match-field", "match-calendar"}) {
+		t.Errorf("adoptMatcherDeletes() = %v, want every unset list cleared", got)
+	}
+}
@@ -43,14 +43,15 @@ type sendmailNotificationResource struct {
 
 // sendmailNotificationResourceModel maps the resource schema data.
 type sendmailNotificationResourceModel struct {
-	Name       types.String `tfsdk:"name"`
-	From       types.String `tfsdk:"from_address"`
-	Mailto     types.List   `tfsdk:"mailto"`
-	MailtoUser types.List   `tfsdk:"mailto_user"`
-	Author     types.String `tfsdk:"author"`
-	Comment    types.String `tfsdk:"comment"`
-	Disable    types.Bool   `tfsdk:"disable"`
-	Origin     types.String `tfsdk:"origin"`
+	Name        types.String `tfsdk:"name"`
+	From        types.String `tfsdk:"from_address"`
+	Mailto      types.List   `tfsdk:"mailto"`
+	MailtoUser  types.List   `tfsdk:"mailto_user"`
+	Author      types.String `tfsdk:"author"`
+	Comment     types.String `tfsdk:"comment"`
+	Disable     types.Bool   `tfsdk:"disable"`
+	Origin      types.String `tfsdk:"origin"`
+	TestOnApply types.Bool   `tfsdk:"test_on_apply"`
 }
 
 // Metadata returns the resource type name.
@@ -65,7 +66,10 @@ func (r *sendmailNotificationResource) Schema(_ context.Context, _ resource.Sche
 		MarkdownDescription: `Manages a Sendmail notification target.
 
 Configure local sendmail to receive notifications from PBS about backup jobs,
-verification tasks, and system events.`,
+verification tasks, and system events.
+
+The built-in ` + "`mail-to-root`" + ` target can be managed by using its name: it is adopted instead of
+created, and destroying the resource resets it to mail ` + "`root@pam`" + `.`,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description:         "The unique name identifier for the Sendmail target.",
@@ -115,6 +119,7 @@ verification tasks, and system events.`,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"test_on_apply": testOnApplyAttribute(),
 			"origin": schema.StringAttribute{
 				Description:         "Origin of this configuration as reported by PBS.",
 				MarkdownDescription: "Origin of this configuration as reported by PBS (e.g., `user`, `builtin`).",
@@ -185,7 +190,18 @@ func (r *sendmailNotificationResource) Create(ctx context.Context, req resource.
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.CreateSendmailTarget(ctx, target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		existing, err := r.client.Notifications.GetSendmailTarget(ctx, plan.Name.ValueString())
+		if err != nil && !notifications.IsNotFoundError(err) {
+			return err
+		}
+		if err == nil && notifications.IsBuiltinOrigin(existing.Origin) {
+			// Built-in targets always exist and cannot be created; take over the existing entry
+			target.Delete = adoptSendmailDeletes(&plan, existing)
+			return r.client.Notifications.UpdateSendmailTarget(ctx, plan.Name.ValueString(), target)
+		}
+		return r.client.Notifications.CreateSendmailTarget(ctx, target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Sendmail notification target",
@@ -248,6 +264,11 @@ func (r *sendmailNotificationResource) Create(ctx context.Context, req resource.
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -310,6 +331,11 @@ func (r *sendmailNotificationResource) Read(ctx context.Context, req resource.Re
 		state.Origin = types.StringNull()
 	}
 
+	// test_on_apply is not stored by PBS; default it for imported resources
+	if state.TestOnApply.IsNull() {
+		state.TestOnApply = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -360,7 +386,9 @@ func (r *sendmailNotificationResource) Update(ctx context.Context, req resource.
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.UpdateSendmailTarget(ctx, plan.Name.ValueString(), target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.UpdateSendmailTarget(ctx, plan.Name.ValueString(), target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Sendmail notification target",
@@ -424,6 +452,11 @@ func (r *sendmailNotificationResource) Update(ctx context.Context, req resource.
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -435,7 +468,23 @@ func (r *sendmailNotificationResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
-	err := r.client.Notifications.DeleteSendmailTarget(ctx, state.Name.ValueString())
+	// Built-in targets cannot be deleted; reset them to their shipped defaults instead
+	if notifications.IsBuiltinOrigin(state.Origin.ValueString()) {
+		err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+			return r.client.Notifications.ResetSendmailTarget(ctx, state.Name.ValueString())
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error resetting built-in Sendmail notification target",
+				fmt.Sprintf("Could not reset built-in Sendmail notification target %s: %s", state.Name.ValueString(), err.Error()),
+			)
+		}
+		return
+	}
+
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.DeleteSendmailTarget(ctx, state.Name.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Sendmail notification target",
@@ -449,3 +498,23 @@ func (r *sendmailNotificationResource) Delete(ctx context.Context, req resource.
 func (r *sendmailNotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// adoptSendmailDeletes lists the properties of an adopted built-in target that the plan leaves unset.
+func adoptSendmailDeletes(plan *sendmailNotificationResourceModel, existing *notifications.SendmailTarget) []string {
+	var deletes []string
+
+	if plan.Mailto.IsNull() && len(existing.Mailto) > 0 {
+		deletes = append(deletes, "mailto")
+	}
+	if plan.MailtoUser.IsNull() && len(existing.MailtoUser) > 0 {
+		deletes = append(deletes, "mailto-user")
+	}
+	if plan.Author.IsNull() && existing.Author != "" {
+		deletes = append(deletes, "author")
+	}
+	if plan.Comment.IsNull() && existing.Comment != "" {
+		deletes = append(deletes, "comment")
+	}
+
+	return deletes
+}
@@ -0,0 +1,39 @@
+package notifications
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/micah/terraform-provider-pbs/pbs/notifications"
+)
+
+func TestAdoptSendmailDeletes(t *testing.T) {
+	existing := &notifications.SendmailTarget{
+		Name:       notifications.BuiltinSendmailTargetName,
+		MailtoUser: []string{"root@pam"},
+		Comment:    "Send mails to root@pam's email address",
+		Origin:     notifications.OriginBuiltin,
+	}
+
+	plan := &sendmailNotificationResourceModel{
+		Mailto:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("ops@example.com")}),
+		MailtoUser: types.ListNull(types.StringType),
+		Author:     types.StringNull(),
+		Comment:    types.StringValue("managed by terraform"),
+	}
+	if got := adoptSendmailDeletes(plan, existing); !reflect.DeepEqual(got, []string{"mailto-user"}) {
+		t.Errorf("adoptSendmailDeletes() = %v, want [mailto-user]", got)
+	}
+
+	plan.MailtoUser = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("admin@pbs")})
+	plan.Comment = types.StringNull()
+	existing.Mailto = []string{"root@example.com"}
+	existing.Author = "Proxmox Backup Server"
+	plan.Mailto = types.ListNull(types.StringType)
+	if got := adoptSendmailDeletes(plan, existing); !reflect.DeepEqual(got, []string{"mailto", "author", "comment"}) {
+		t.Errorf("adoptSendmailDeletes() = %v, want [mailto author comment]", got)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/writeonly"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/notifications"
 )
@@ -47,19 +48,24 @@ type smtpNotificationResource struct {
 
 // smtpNotificationResourceModel maps the resource schema data.
 type smtpNotificationResourceModel struct {
-	Name       types.String `tfsdk:"name"`
-	Server     types.String `tfsdk:"server"`
-	Port       types.Int64  `tfsdk:"port"`
-	Mode       types.String `tfsdk:"mode"`
-	From       types.String `tfsdk:"from_address"`
-	Mailto     types.List   `tfsdk:"mailto"`
-	MailtoUser types.List   `tfsdk:"mailto_user"`
-	Username   types.String `tfsdk:"username"`
-	Password   types.String `tfsdk:"password"`
-	Author     types.String `tfsdk:"author"`
-	Comment    types.String `tfsdk:"comment"`
-	Disable    types.Bool   `tfsdk:"disable"`
-	Origin     types.String `tfsdk:"origin"`
+	Name              types.String `tfsdk:"name"`
+	Server            types.String `tfsdk:"server"`
+	Port              types.Int64  `tfsdk:"port"`
+	Mode              types.String `tfsdk:"mode"`
+	From              types.String `tfsdk:"from_address"`
+	Mailto            types.List   `tfsdk:"mailto"`
+	MailtoUser        types.List   `tfsdk:"mailto_user"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	PasswordHash      types.String `tfsdk:"password_hash"`
+	VerifySecret      types.Bool   `tfsdk:"verify_secret"`
+	Author            types.String `tfsdk:"author"`
+	Comment           types.String `tfsdk:"comment"`
+	Disable           types.Bool   `tfsdk:"disable"`
+	Origin            types.String `tfsdk:"origin"`
+	TestOnApply       types.Bool   `tfsdk:"test_on_apply"`
 }
 
 // Metadata returns the resource type name.
@@ -140,6 +146,10 @@ verification tasks, and system events.`,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"password_wo":         writeonly.SecretAttribute("password", "SMTP authentication password."),
+			"password_wo_version": writeonly.VersionAttribute("password"),
+			"password_hash":       writeonly.HashAttribute("password"),
+			"verify_secret":       writeonly.VerifyByNotificationAttribute("which authenticates against the SMTP server with the stored password"),
 			"author": schema.StringAttribute{
 				Description:         "Author name for notification emails.",
 				MarkdownDescription: "Author name that will appear in the email headers.",
@@ -157,6 +167,7 @@ verification tasks, and system events.`,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"test_on_apply": testOnApplyAttribute(),
 			"origin": schema.StringAttribute{
 				Description:         "Origin of this configuration as reported by PBS (e.g., config file or built-in).",
 				MarkdownDescription: "Origin of this configuration as reported by PBS (e.g., `user`, `builtin`).",
@@ -233,9 +244,12 @@ func (r *smtpNotificationResource) Create(ctx context.Context, req resource.Crea
 	if !plan.Username.IsNull() {
 		target.Username = plan.Username.ValueString()
 	}
-	if !plan.Password.IsNull() {
-		target.Password = plan.Password.ValueString()
+	password, diags := writeonly.Value(ctx, req.Config, "password", plan.Password)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	target.Password = password
 	if !plan.Author.IsNull() {
 		target.Author = plan.Author.ValueString()
 	}
@@ -247,7 +261,9 @@ func (r *smtpNotificationResource) Create(ctx context.Context, req resource.Crea
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.CreateSMTPTarget(ctx, target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.CreateSMTPTarget(ctx, target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating SMTP notification target",
@@ -324,8 +340,15 @@ func (r *smtpNotificationResource) Create(ctx context.Context, req resource.Crea
 		plan.Origin = types.StringNull()
 	}
 
+	plan.PasswordHash = writeonly.CreateHash(password, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -407,6 +430,16 @@ func (r *smtpNotificationResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	// Set refreshed state
+	// test_on_apply is not stored by PBS; default it for imported resources
+	if state.TestOnApply.IsNull() {
+		state.TestOnApply = types.BoolValue(false)
+	}
+
+	secret := writeonly.Secret{Attr: "password", Plain: &state.Password, Version: &state.PasswordWOVersion, Hash: &state.PasswordHash}
+	writeonly.Verify(ctx, state.VerifySecret, secret, "SMTP notification target "+state.Name.ValueString(), func() error {
+		return r.client.Notifications.TestTarget(ctx, state.Name.ValueString())
+	}, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -419,6 +452,12 @@ func (r *smtpNotificationResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	var state smtpNotificationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update SMTP target via API
 	target := &notifications.SMTPTarget{
 		Name:   plan.Name.ValueString(),
@@ -455,9 +494,13 @@ func (r *smtpNotificationResource) Update(ctx context.Context, req resource.Upda
 	if !plan.Username.IsNull() {
 		target.Username = plan.Username.ValueString()
 	}
-	if !plan.Password.IsNull() {
-		target.Password = plan.Password.ValueString()
+	// Write-only values are only sent when password_wo_version changes
+	password, diags := writeonly.UpdateValue(ctx, req.Config, "password", plan.Password, plan.PasswordWOVersion, state.PasswordWOVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	target.Password = password
 	if !plan.Author.IsNull() {
 		target.Author = plan.Author.ValueString()
 	}
@@ -469,7 +512,9 @@ func (r *smtpNotificationResource) Update(ctx context.Context, req resource.Upda
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.UpdateSMTPTarget(ctx, plan.Name.ValueString(), target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.UpdateSMTPTarget(ctx, plan.Name.ValueString(), target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating SMTP notification target",
@@ -547,7 +592,14 @@ func (r *smtpNotificationResource) Update(ctx context.Context, req resource.Upda
 		plan.Origin = types.StringNull()
 	}
 
+	plan.PasswordHash = writeonly.UpdateHash(password, state.PasswordHash, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -560,7 +612,9 @@ func (r *smtpNotificationResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 	// Delete SMTP target via API
-	err := r.client.Notifications.DeleteSMTPTarget(ctx, state.Name.ValueString())
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.DeleteSMTPTarget(ctx, state.Name.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting SMTP notification target",
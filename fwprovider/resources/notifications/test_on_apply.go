@@ -0,0 +1,49 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+// testOnApplyAttribute returns the schema for the test_on_apply attribute shared by all notification targets.
+func testOnApplyAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description:         "Send a test notification after the target is created or updated.",
+		MarkdownDescription: "Send a test notification through this target after it is created or updated. A delivery failure is reported as a warning and does not fail the apply. Defaults to `false`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+}
+
+// testTargetOnApply sends a test notification through the named target when enabled is true.
+// It runs after the state has been saved, so a failed delivery is only a warning: an error would
+// taint a newly created target and make Terraform replace it on the next apply.
+func testTargetOnApply(ctx context.Context, client *pbs.Client, name string, enabled types.Bool, diags *diag.Diagnostics) {
+	if !enabled.ValueBool() {
+		return
+	}
+
+	tflog.Debug(ctx, "Sending test notification", map[string]any{"target": name})
+
+	if err := client.Notifications.TestTarget(ctx, name); err != nil {
+		diags.AddWarning(
+			"Test notification failed",
+			fmt.Sprintf("Notification target %s was saved, but sending a test notification failed: %s", name, err.Error()),
+		)
+	}
+}
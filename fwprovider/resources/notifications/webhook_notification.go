@@ -9,9 +9,14 @@ package notifications
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,18 +25,23 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/writeonly"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/notifications"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &webhookNotificationResource{}
-	_ resource.ResourceWithConfigure   = &webhookNotificationResource{}
-	_ resource.ResourceWithImportState = &webhookNotificationResource{}
+	_ resource.Resource                   = &webhookNotificationResource{}
+	_ resource.ResourceWithConfigure      = &webhookNotificationResource{}
+	_ resource.ResourceWithImportState    = &webhookNotificationResource{}
+	_ resource.ResourceWithValidateConfig = &webhookNotificationResource{}
+	_ resource.ResourceWithModifyPlan     = &webhookNotificationResource{}
 )
 
 // NewWebhookNotificationResource is a helper function to simplify the provider implementation.
@@ -46,15 +56,38 @@ type webhookNotificationResource struct {
 
 // webhookNotificationResourceModel maps the resource schema data.
 type webhookNotificationResourceModel struct {
-	Name    types.String `tfsdk:"name"`
-	URL     types.String `tfsdk:"url"`
-	Body    types.String `tfsdk:"body"`
-	Method  types.String `tfsdk:"method"`
-	Headers types.Map    `tfsdk:"headers"`
-	Secret  types.String `tfsdk:"secret"`
-	Comment types.String `tfsdk:"comment"`
-	Disable types.Bool   `tfsdk:"disable"`
-	Origin  types.String `tfsdk:"origin"`
+	Name             types.String `tfsdk:"name"`
+	URL              types.String `tfsdk:"url"`
+	Body             types.String `tfsdk:"body"`
+	Method           types.String `tfsdk:"method"`
+	Headers          types.Map    `tfsdk:"headers"`
+	Secret           types.String `tfsdk:"secret"`
+	SecretWO         types.String `tfsdk:"secret_wo"`
+	SecretWOVersion  types.Int64  `tfsdk:"secret_wo_version"`
+	SecretHash       types.String `tfsdk:"secret_hash"`
+	VerifySecret     types.Bool   `tfsdk:"verify_secret"`
+	SecretsWO        types.Map    `tfsdk:"secrets_wo"`
+	SecretsWOVersion types.Map    `tfsdk:"secrets_wo_version"`
+	Comment          types.String `tfsdk:"comment"`
+	Disable          types.Bool   `tfsdk:"disable"`
+	Origin           types.String `tfsdk:"origin"`
+	TestOnApply      types.Bool   `tfsdk:"test_on_apply"`
+	RenderedPreview  types.Object `tfsdk:"rendered_preview"`
+}
+
+// legacySecretName is the PBS secret name under which the deprecated secret attribute is stored.
+const legacySecretName = "secret"
+
+// webhookSecretNameRegexp matches the secret names accepted by PBS.
+var webhookSecretNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+// webhookPreviewObjectType is the object type of the rendered_preview attribute.
+var webhookPreviewObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"url":     types.StringType,
+		"body":    types.StringType,
+		"headers": types.MapType{ElemType: types.StringType},
+	},
 }
 
 // Metadata returns the resource type name.
@@ -69,7 +102,13 @@ func (r *webhookNotificationResource) Schema(_ context.Context, _ resource.Schem
 		MarkdownDescription: `Manages a Webhook notification target.
 
 Configure a webhook endpoint to receive HTTP notifications from PBS about backup jobs,
-verification tasks, and system events.`,
+verification tasks, and system events.
+
+The ` + "`url`" + `, ` + "`body`" + ` and ` + "`headers`" + ` values are Handlebars templates rendered by PBS for each
+notification, with the variables ` + "`title`" + `, ` + "`message`" + `, ` + "`severity`" + `, ` + "`timestamp`" + `, ` + "`fields`" + `
+and ` + "`secrets`" + ` and the helpers ` + "`json`" + `, ` + "`escape`" + ` and ` + "`url-encode`" + `. Templates are checked at
+plan time, and a JSON body must render to valid JSON for a sample notification. Use
+` + "`{{ escape message }}`" + ` inside JSON strings, since messages contain newlines and quotes.`,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description:         "The unique name identifier for the Webhook target.",
@@ -81,12 +120,12 @@ verification tasks, and system events.`,
 			},
 			"url": schema.StringAttribute{
 				Description:         "Webhook URL.",
-				MarkdownDescription: "Webhook URL where notifications will be sent (e.g., `https://hooks.example.com/notify`).",
+				MarkdownDescription: "Webhook URL where notifications will be sent (e.g., `https://hooks.example.com/notify`). May contain template expressions.",
 				Required:            true,
 			},
 			"body": schema.StringAttribute{
 				Description:         "Custom request body template.",
-				MarkdownDescription: "Custom request body template. Can use template variables for notification data.",
+				MarkdownDescription: "Custom request body template, e.g. `{\"text\": \"{{ escape title }}\"}`. Can use template variables for notification data.",
 				Optional:            true,
 			},
 			"method": schema.StringAttribute{
@@ -101,16 +140,45 @@ verification tasks, and system events.`,
 			},
 			"headers": schema.MapAttribute{
 				Description:         "Custom HTTP headers.",
-				MarkdownDescription: "Custom HTTP headers to include in webhook requests. Specify as key-value pairs.",
+				MarkdownDescription: "Custom HTTP headers to include in webhook requests. Specify as key-value pairs; values may contain template expressions.",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
 			"secret": schema.StringAttribute{
-				Description:         "Secret for HMAC signature.",
-				MarkdownDescription: "Secret for HMAC-SHA256 signature. The signature will be sent in the `X-PBS-Signature` header.",
+				Description:         "Single secret, stored in PBS as the secret named \"secret\".",
+				MarkdownDescription: "Single secret, stored in PBS as the secret named `secret` and available to templates as `{{ secrets.secret }}`.",
+				DeprecationMessage:  "Use secrets_wo and secrets_wo_version instead.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"secret_wo":         writeonly.SecretAttribute("secret", "Single secret, stored in PBS as the secret named \"secret\"."),
+			"secret_wo_version": writeonly.VersionAttribute("secret"),
+			"secret_hash":       writeonly.HashAttribute("secret"),
+			"verify_secret":     writeonly.VerifyByNotificationAttribute("which only detects drift when the receiver rejects the request as unauthorized"),
+			"secrets_wo": schema.MapAttribute{
+				Description: "Named secrets available to templates as {{ secrets.<name> }}. Write-only, never stored in state.",
+				MarkdownDescription: "Named secrets available to the `url`, `body` and `headers` templates as `{{ secrets.<name> }}`. " +
+					"Write-only (Terraform 1.11+): values are sent to PBS but never stored in state. Every name needs an entry in `secrets_wo_version`. " +
+					"Templates may also reference secrets set in PBS outside Terraform; such references are reported as a warning.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(webhookSecretNameRegexp, "must be a valid PBS secret name")),
+					mapvalidator.AlsoRequires(path.MatchRoot("secrets_wo_version")),
+				},
+			},
+			"secrets_wo_version": schema.MapAttribute{
+				Description: "Version of each secret in secrets_wo, keyed by name. Only secrets whose version changes are sent again.",
+				MarkdownDescription: "Version of each secret in `secrets_wo`, keyed by name. Only secrets that are new or whose version changes are sent; " +
+					"the others keep their value in PBS. Secrets removed from this map are deleted, while secrets configured outside Terraform are left alone.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(webhookSecretNameRegexp, "must be a valid PBS secret name")),
+				},
+			},
 			"comment": schema.StringAttribute{
 				Description:         "A comment describing this notification target.",
 				MarkdownDescription: "A comment describing this notification target.",
@@ -123,15 +191,84 @@ verification tasks, and system events.`,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"test_on_apply": testOnApplyAttribute(),
 			"origin": schema.StringAttribute{
 				Description:         "Origin of this configuration as reported by PBS.",
 				MarkdownDescription: "Origin of this configuration as reported by PBS (e.g., `user`, `builtin`).",
 				Computed:            true,
 			},
+			"rendered_preview": schema.SingleNestedAttribute{
+				Description: "The webhook request rendered for a sample notification.",
+				MarkdownDescription: "The webhook request rendered for a sample verification notification, to review templates " +
+					"before PBS sends real notifications. Secret values are shown as `<secret:name>` placeholders.",
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "Rendered URL.",
+						Computed:    true,
+					},
+					"body": schema.StringAttribute{
+						Description: "Rendered request body.",
+						Computed:    true,
+					},
+					"headers": schema.MapAttribute{
+						Description: "Rendered header values.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
+				},
+			},
 		},
 	}
 }
 
+// ValidateConfig parses the url, body and header templates at plan time so that
+// broken templates and JSON bodies are caught before PBS drops notifications.
+func (r *webhookNotificationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config webhookNotificationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateWebhookSecrets(&config)...)
+
+	if config.URL.IsUnknown() || config.Body.IsUnknown() || !isKnownMap(config.Headers) || !isKnownMap(config.SecretsWOVersion) {
+		return
+	}
+
+	_, problems, diags := previewWebhook(ctx, config.URL, config.Body, config.Headers, webhookSecretNames(&config))
+	resp.Diagnostics.Append(diags...)
+	for _, problem := range problems {
+		attrPath := path.Root(problem.Field)
+		if problem.Key != "" {
+			attrPath = attrPath.AtMapKey(problem.Key)
+		}
+		if problem.Warning {
+			resp.Diagnostics.AddAttributeWarning(attrPath, "Unmanaged webhook secret", problem.Message)
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(attrPath, "Invalid webhook template", problem.Message)
+	}
+}
+
+// ModifyPlan renders rendered_preview from the planned templates once they are known.
+func (r *webhookNotificationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan webhookNotificationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.URL.IsUnknown() || plan.Body.IsUnknown() || !isKnownMap(plan.Headers) {
+		return
+	}
+
+	preview, _, diags := previewWebhook(ctx, plan.URL, plan.Body, plan.Headers, webhookSecretNames(&plan))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rendered_preview"), preview)...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *webhookNotificationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -180,8 +317,15 @@ func (r *webhookNotificationResource) Create(ctx context.Context, req resource.C
 		}
 		target.Headers = headers
 	}
-	if !plan.Secret.IsNull() {
-		target.Secret = plan.Secret.ValueString()
+	secret, diags := writeonly.Value(ctx, req.Config, "secret", plan.Secret)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	target.Secrets, _, diags = webhookSecrets(ctx, req.Config, &plan, nil, secret)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	if !plan.Comment.IsNull() {
 		target.Comment = plan.Comment.ValueString()
@@ -191,7 +335,9 @@ func (r *webhookNotificationResource) Create(ctx context.Context, req resource.C
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.CreateWebhookTarget(ctx, target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.CreateWebhookTarget(ctx, target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Webhook notification target",
@@ -252,7 +398,17 @@ func (r *webhookNotificationResource) Create(ctx context.Context, req resource.C
 		plan.Origin = types.StringNull()
 	}
 
+	plan.RenderedPreview, _, diags = previewWebhook(ctx, plan.URL, plan.Body, plan.Headers, webhookSecretNames(&plan))
+	resp.Diagnostics.Append(diags...)
+
+	plan.SecretHash = writeonly.CreateHash(secret, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -294,7 +450,10 @@ func (r *webhookNotificationResource) Read(ctx context.Context, req resource.Rea
 	} else {
 		state.Headers = types.MapNull(types.StringType)
 	}
-	// Don't update secret from API (sensitive field)
+	// Secret values are never returned; forget versions of secrets deleted outside
+	// Terraform so that the next plan sends them again
+	state.SecretsWOVersion, diags = pruneSecretVersions(ctx, state.SecretsWOVersion, target.SecretNames())
+	resp.Diagnostics.Append(diags...)
 	if target.Comment != "" {
 		state.Comment = types.StringValue(target.Comment)
 	} else {
@@ -312,6 +471,19 @@ func (r *webhookNotificationResource) Read(ctx context.Context, req resource.Rea
 		state.Origin = types.StringNull()
 	}
 
+	// test_on_apply is not stored by PBS; default it for imported resources
+	if state.TestOnApply.IsNull() {
+		state.TestOnApply = types.BoolValue(false)
+	}
+
+	state.RenderedPreview, _, diags = previewWebhook(ctx, state.URL, state.Body, state.Headers, webhookSecretNames(&state))
+	resp.Diagnostics.Append(diags...)
+
+	secret := writeonly.Secret{Attr: "secret", Plain: &state.Secret, Version: &state.SecretWOVersion, Hash: &state.SecretHash}
+	writeonly.Verify(ctx, state.VerifySecret, secret, "Webhook notification target "+state.Name.ValueString(), func() error {
+		return r.client.Notifications.TestTarget(ctx, state.Name.ValueString())
+	}, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -324,6 +496,12 @@ func (r *webhookNotificationResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	var state webhookNotificationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	target := &notifications.WebhookTarget{
 		Name: plan.Name.ValueString(),
 		URL:  plan.URL.ValueString(),
@@ -336,17 +514,28 @@ func (r *webhookNotificationResource) Update(ctx context.Context, req resource.U
 		// PBS 4.0 requires lowercase method values
 		target.Method = strings.ToLower(plan.Method.ValueString())
 	}
-	if !plan.Headers.IsNull() {
-		headers := make(map[string]string)
-		diags = plan.Headers.ElementsAs(ctx, &headers, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+	// The header list is replaced as a whole, so it is only sent when it changed
+	if !plan.Headers.Equal(state.Headers) {
+		if len(plan.Headers.Elements()) == 0 {
+			target.Delete = append(target.Delete, "header")
+		} else {
+			target.Headers = make(map[string]string)
+			resp.Diagnostics.Append(plan.Headers.ElementsAs(ctx, &target.Headers, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
 		}
-		target.Headers = headers
 	}
-	if !plan.Secret.IsNull() {
-		target.Secret = plan.Secret.ValueString()
+	// Write-only values are only sent when secret_wo_version changes
+	secret, diags := writeonly.UpdateValue(ctx, req.Config, "secret", plan.Secret, plan.SecretWOVersion, state.SecretWOVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secrets, secretsChanged, diags := webhookSecrets(ctx, req.Config, &plan, &state, secret)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	if !plan.Comment.IsNull() {
 		target.Comment = plan.Comment.ValueString()
@@ -356,7 +545,21 @@ func (r *webhookNotificationResource) Update(ctx context.Context, req resource.U
 		target.Disable = &disable
 	}
 
-	err := r.client.Notifications.UpdateWebhookTarget(ctx, plan.Name.ValueString(), target)
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		if secretsChanged {
+			// The secret list is replaced as a whole; keep secrets managed outside Terraform
+			current, err := r.client.Notifications.GetWebhookTarget(ctx, plan.Name.ValueString())
+			if err != nil {
+				return err
+			}
+			target.Secrets = keepUnmanagedSecrets(secrets, current.SecretNames(), &state)
+			if len(target.Secrets) == 0 {
+				target.Secrets = nil
+				target.Delete = append(target.Delete, "secret")
+			}
+		}
+		return r.client.Notifications.UpdateWebhookTarget(ctx, plan.Name.ValueString(), target)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Webhook notification target",
@@ -417,7 +620,17 @@ func (r *webhookNotificationResource) Update(ctx context.Context, req resource.U
 		plan.Origin = types.StringNull()
 	}
 
+	plan.RenderedPreview, _, diags = previewWebhook(ctx, plan.URL, plan.Body, plan.Headers, webhookSecretNames(&plan))
+	resp.Diagnostics.Append(diags...)
+
+	plan.SecretHash = writeonly.UpdateHash(secret, state.SecretHash, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	testTargetOnApply(ctx, r.client, plan.Name.ValueString(), plan.TestOnApply, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -429,7 +642,9 @@ func (r *webhookNotificationResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
-	err := r.client.Notifications.DeleteWebhookTarget(ctx, state.Name.ValueString())
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainNotifications, func() error {
+		return r.client.Notifications.DeleteWebhookTarget(ctx, state.Name.ValueString())
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting Webhook notification target",
@@ -443,3 +658,229 @@ func (r *webhookNotificationResource) Delete(ctx context.Context, req resource.D
 func (r *webhookNotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// previewWebhook renders the webhook templates for a sample notification. The
+// preview is null when a template is invalid; the problems describe why.
+func previewWebhook(ctx context.Context, url, body types.String, headers types.Map, secretNames []string) (types.Object, []notifications.TemplateProblem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	target := &notifications.WebhookTarget{URL: url.ValueString(), Body: body.ValueString()}
+	if !headers.IsNull() {
+		diags.Append(headers.ElementsAs(ctx, &target.Headers, false)...)
+		if diags.HasError() {
+			return types.ObjectNull(webhookPreviewObjectType.AttrTypes), nil, diags
+		}
+	}
+
+	preview, problems := notifications.PreviewWebhook(target, secretNames)
+	if preview == nil {
+		return types.ObjectNull(webhookPreviewObjectType.AttrTypes), problems, diags
+	}
+
+	previewHeaders, d := types.MapValueFrom(ctx, types.StringType, preview.Headers)
+	diags.Append(d...)
+	obj, d := types.ObjectValue(webhookPreviewObjectType.AttrTypes, map[string]attr.Value{
+		"url":     types.StringValue(preview.URL),
+		"body":    types.StringValue(preview.Body),
+		"headers": previewHeaders,
+	})
+	diags.Append(d...)
+	return obj, problems, diags
+}
+
+// isKnownMap reports whether m and all of its elements are known
+func isKnownMap(m types.Map) bool {
+	if m.IsUnknown() {
+		return false
+	}
+	for _, v := range m.Elements() {
+		if v.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// legacySecretConfigured reports whether the deprecated secret attribute is in use
+func legacySecretConfigured(m *webhookNotificationResourceModel) bool {
+	return !m.Secret.IsNull() || !m.SecretWOVersion.IsNull()
+}
+
+// webhookSecretNames returns the names of the secrets a target's templates may reference
+func webhookSecretNames(m *webhookNotificationResourceModel) []string {
+	var names []string
+	for name := range m.SecretsWOVersion.Elements() {
+		names = append(names, name)
+	}
+	if legacySecretConfigured(m) {
+		names = append(names, legacySecretName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateWebhookSecrets checks that secrets_wo and secrets_wo_version name the
+// same secrets and do not clash with the deprecated secret attribute.
+func validateWebhookSecrets(config *webhookNotificationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	versions := config.SecretsWOVersion.Elements()
+	if legacySecretConfigured(config) {
+		if _, ok := versions[legacySecretName]; ok {
+			diags.AddAttributeError(
+				path.Root("secrets_wo_version").AtMapKey(legacySecretName),
+				"Conflicting secret",
+				"The secret named \"secret\" is managed by the deprecated secret attribute. Move it to secrets_wo instead of configuring both.",
+			)
+		}
+	}
+
+	if config.SecretsWO.IsUnknown() || config.SecretsWOVersion.IsUnknown() {
+		return diags
+	}
+	values := config.SecretsWO.Elements()
+	for name := range values {
+		if _, ok := versions[name]; !ok {
+			diags.AddAttributeError(
+				path.Root("secrets_wo_version"),
+				"Missing secret version",
+				fmt.Sprintf("Secret %q in secrets_wo needs a version in secrets_wo_version, since write-only values cannot be compared between plans.", name),
+			)
+		}
+	}
+	if !config.SecretsWO.IsNull() {
+		for name := range versions {
+			if _, ok := values[name]; !ok {
+				diags.AddAttributeError(
+					path.Root("secrets_wo_version").AtMapKey(name),
+					"Missing secret value",
+					fmt.Sprintf("Secret %q has a version but no value in secrets_wo.", name),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+// webhookSecrets builds the secret list for plan. Secrets that are new or whose
+// version changed since prior are sent with their configured value; the others
+// are sent by name only so that PBS keeps their value. legacy is the value of
+// the deprecated secret attribute to send, if any. The result reports whether
+// any secret was added, changed or removed; prior is nil on create.
+func webhookSecrets(ctx context.Context, config tfsdk.Config, plan, prior *webhookNotificationResourceModel, legacy string) ([]notifications.WebhookSecret, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var configured types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("secrets_wo"), &configured)...)
+	values := map[string]string{}
+	if !configured.IsNull() {
+		diags.Append(configured.ElementsAs(ctx, &values, false)...)
+	}
+
+	planVersions, priorVersions := map[string]int64{}, map[string]int64{}
+	if !plan.SecretsWOVersion.IsNull() {
+		diags.Append(plan.SecretsWOVersion.ElementsAs(ctx, &planVersions, false)...)
+	}
+	if prior != nil && !prior.SecretsWOVersion.IsNull() {
+		diags.Append(prior.SecretsWOVersion.ElementsAs(ctx, &priorVersions, false)...)
+	}
+	if diags.HasError() {
+		return nil, false, diags
+	}
+
+	var secrets []notifications.WebhookSecret
+	changed := false
+	for _, name := range sortedNames(planVersions) {
+		if version, ok := priorVersions[name]; ok && version == planVersions[name] {
+			secrets = append(secrets, notifications.WebhookSecret{Name: name})
+			continue
+		}
+		value, ok := values[name]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("secrets_wo").AtMapKey(name),
+				"Missing secret value",
+				fmt.Sprintf("Secret %q is new or its version changed, but secrets_wo has no value for it.", name),
+			)
+			continue
+		}
+		secrets = append(secrets, notifications.WebhookSecret{Name: name, Value: &value})
+		changed = true
+	}
+	for name := range priorVersions {
+		if _, ok := planVersions[name]; !ok {
+			changed = true
+		}
+	}
+
+	switch {
+	case legacy != "":
+		secrets = append(secrets, notifications.WebhookSecret{Name: legacySecretName, Value: &legacy})
+		changed = true
+	case legacySecretConfigured(plan):
+		secrets = append(secrets, notifications.WebhookSecret{Name: legacySecretName})
+	case prior != nil && legacySecretConfigured(prior):
+		changed = true
+	}
+
+	return secrets, changed, diags
+}
+
+// keepUnmanagedSecrets appends the secrets in current that were never managed by
+// the resource by name only, so that replacing the list keeps their values.
+func keepUnmanagedSecrets(secrets []notifications.WebhookSecret, current []string, prior *webhookNotificationResourceModel) []notifications.WebhookSecret {
+	managed := prior.SecretsWOVersion.Elements()
+	for _, name := range current {
+		if _, ok := managed[name]; ok {
+			continue
+		}
+		if name == legacySecretName && legacySecretConfigured(prior) {
+			continue
+		}
+		if containsSecret(secrets, name) {
+			continue
+		}
+		secrets = append(secrets, notifications.WebhookSecret{Name: name})
+	}
+	return secrets
+}
+
+// pruneSecretVersions drops the versions of secrets that no longer exist in PBS
+func pruneSecretVersions(ctx context.Context, versions types.Map, existing []string) (types.Map, diag.Diagnostics) {
+	if versions.IsNull() || versions.IsUnknown() {
+		return versions, nil
+	}
+
+	kept := map[string]attr.Value{}
+	for name, version := range versions.Elements() {
+		for _, e := range existing {
+			if e == name {
+				kept[name] = version
+				break
+			}
+		}
+	}
+	if len(kept) == len(versions.Elements()) {
+		return versions, nil
+	}
+
+	tflog.Debug(ctx, "Webhook secrets deleted outside of Terraform", map[string]any{"managed": len(versions.Elements()), "existing": len(kept)})
+	return types.MapValue(types.Int64Type, kept)
+}
+
+func containsSecret(secrets []notifications.WebhookSecret, name string) bool {
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,193 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/micah/terraform-provider-pbs/pbs/notifications"
+)
+
+// webhookTestConfig returns a webhook configuration holding only secrets_wo
+func webhookTestConfig(t *testing.T, values map[string]string) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&webhookNotificationResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	data := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if values != nil {
+		require.False(t, data.SetAttribute(ctx, path.Root("secrets_wo"), values).HasError())
+	}
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: data.Raw}
+}
+
+// webhookTestModel returns a model with the given secret versions and legacy secret
+func webhookTestModel(t *testing.T, versions map[string]int64, legacy types.String) *webhookNotificationResourceModel {
+	t.Helper()
+	m := &webhookNotificationResourceModel{
+		Secret:           legacy,
+		SecretWOVersion:  types.Int64Null(),
+		SecretsWOVersion: types.MapNull(types.Int64Type),
+	}
+	if versions != nil {
+		v, diags := types.MapValueFrom(context.Background(), types.Int64Type, versions)
+		require.False(t, diags.HasError())
+		m.SecretsWOVersion = v
+	}
+	return m
+}
+
+// secretList renders secrets as "name" when sent by name only and "name=value" otherwise
+func secretList(secrets []notifications.WebhookSecret) []string {
+	out := []string{}
+	for _, s := range secrets {
+		if s.Value == nil {
+			out = append(out, s.Name)
+		} else {
+			out = append(out, s.Name+"="+*s.Value)
+		}
+	}
+	return out
+}
+
+func TestWebhookSecrets(t *testing.T) {
+	none := types.StringNull()
+	legacySet := types.StringValue("legacy")
+
+	tests := map[string]struct {
+		values      map[string]string
+		plan        map[string]int64
+		planLegacy  types.String
+		prior       map[string]int64
+		priorLegacy types.String
+		create      bool
+		legacy      string
+		want        []string
+		wantChanged bool
+		wantError   bool
+	}{
+		"create sends values": {
+			values: map[string]string{"api": "a", "db": "d"}, plan: map[string]int64{"db": 1, "api": 1},
+			planLegacy: none, create: true,
+			want: []string{"api=a", "db=d"}, wantChanged: true,
+		},
+		"unchanged secret sent by name": {
+			plan: map[string]int64{"api": 1}, planLegacy: none,
+			prior: map[string]int64{"api": 1}, priorLegacy: none,
+			want: []string{"api"}, wantChanged: false,
+		},
+		"changed version sent with value": {
+			values: map[string]string{"api": "a2", "db": "d"}, plan: map[string]int64{"api": 2, "db": 1}, planLegacy: none,
+			prior: map[string]int64{"api": 1, "db": 1}, priorLegacy: none,
+			want: []string{"api=a2", "db"}, wantChanged: true,
+		},
+		"added secret sent with value": {
+			values: map[string]string{"db": "d"}, plan: map[string]int64{"api": 1, "db": 1}, planLegacy: none,
+			prior: map[string]int64{"api": 1}, priorLegacy: none,
+			want: []string{"api", "db=d"}, wantChanged: true,
+		},
+		"removed secret": {
+			plan: map[string]int64{"api": 1}, planLegacy: none,
+			prior: map[string]int64{"api": 1, "db": 1}, priorLegacy: none,
+			want: []string{"api"}, wantChanged: true,
+		},
+		"changed version without value": {
+			plan: map[string]int64{"api": 2}, planLegacy: none,
+			prior: map[string]int64{"api": 1}, priorLegacy: none,
+			want: []string{}, wantError: true,
+		},
+		"legacy secret set": {
+			planLegacy: legacySet, create: true, legacy: "s3cret",
+			want: []string{"secret=s3cret"}, wantChanged: true,
+		},
+		"legacy secret kept": {
+			planLegacy: legacySet, priorLegacy: legacySet,
+			want: []string{"secret"}, wantChanged: false,
+		},
+		"legacy secret removed": {
+			planLegacy: none, priorLegacy: legacySet,
+			want: []string{}, wantChanged: true,
+		},
+		"legacy secret migrated to secrets_wo": {
+			values: map[string]string{"token": "t"}, plan: map[string]int64{"token": 1}, planLegacy: none,
+			priorLegacy: legacySet,
+			want:        []string{"token=t"}, wantChanged: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := webhookTestModel(t, tc.plan, tc.planLegacy)
+			var prior *webhookNotificationResourceModel
+			if !tc.create {
+				prior = webhookTestModel(t, tc.prior, tc.priorLegacy)
+			}
+
+			secrets, changed, diags := webhookSecrets(context.Background(), webhookTestConfig(t, tc.values), plan, prior, tc.legacy)
+			require.Equal(t, tc.wantError, diags.HasError(), "diagnostics: %v", diags)
+			require.Equal(t, tc.want, secretList(secrets))
+			if !tc.wantError {
+				require.Equal(t, tc.wantChanged, changed)
+			}
+		})
+	}
+}
+
+func TestKeepUnmanagedSecrets(t *testing.T) {
+	tests := map[string]struct {
+		secrets     []string
+		current     []string
+		prior       map[string]int64
+		priorLegacy types.String
+		want        []string
+	}{
+		"unmanaged secret kept by name": {
+			secrets: []string{"api"}, current: []string{"api", "manual"},
+			prior: map[string]int64{"api": 1}, priorLegacy: types.StringNull(),
+			want: []string{"api", "manual"},
+		},
+		"removed managed secret dropped": {
+			secrets: []string{"api"}, current: []string{"api", "db"},
+			prior: map[string]int64{"api": 1, "db": 1}, priorLegacy: types.StringNull(),
+			want: []string{"api"},
+		},
+		"secret already in the list not repeated": {
+			secrets: []string{"api", "manual"}, current: []string{"api", "manual"},
+			prior: map[string]int64{"api": 1}, priorLegacy: types.StringNull(),
+			want: []string{"api", "manual"},
+		},
+		"removed legacy secret dropped": {
+			secrets: []string{"token"}, current: []string{"secret", "token"},
+			prior: map[string]int64{"token": 1}, priorLegacy: types.StringValue("legacy"),
+			want: []string{"token"},
+		},
+		"secret named like the legacy one kept when unmanaged": {
+			secrets: []string{}, current: []string{"secret"},
+			priorLegacy: types.StringNull(),
+			want:        []string{"secret"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var secrets []notifications.WebhookSecret
+			for _, s := range tc.secrets {
+				secrets = append(secrets, notifications.WebhookSecret{Name: s})
+			}
+			got := keepUnmanagedSecrets(secrets, tc.current, webhookTestModel(t, tc.prior, tc.priorLegacy))
+			require.Equal(t, tc.want, secretList(got))
+		})
+	}
+}
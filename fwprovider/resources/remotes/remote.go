@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/fwprovider/writeonly"
 	"github.com/micah/terraform-provider-pbs/pbs"
 	"github.com/micah/terraform-provider-pbs/pbs/remotes"
 )
@@ -49,14 +50,18 @@ type remoteResource struct {
 
 // remoteResourceModel maps the resource schema data.
 type remoteResourceModel struct {
-	Name        types.String `tfsdk:"name"`
-	Host        types.String `tfsdk:"host"`
-	Port        types.Int64  `tfsdk:"port"`
-	AuthID      types.String `tfsdk:"auth_id"`
-	Password    types.String `tfsdk:"password"`
-	Fingerprint types.String `tfsdk:"fingerprint"`
-	Comment     types.String `tfsdk:"comment"`
-	Digest      types.String `tfsdk:"digest"`
+	Name              types.String `tfsdk:"name"`
+	Host              types.String `tfsdk:"host"`
+	Port              types.Int64  `tfsdk:"port"`
+	AuthID            types.String `tfsdk:"auth_id"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	PasswordHash      types.String `tfsdk:"password_hash"`
+	VerifySecret      types.Bool   `tfsdk:"verify_secret"`
+	Fingerprint       types.String `tfsdk:"fingerprint"`
+	Comment           types.String `tfsdk:"comment"`
+	Digest            types.String `tfsdk:"digest"`
 }
 
 // Metadata returns the resource type name.
@@ -74,7 +79,8 @@ Remote configurations allow PBS to connect to other PBS servers for pulling or p
 Remotes are referenced by sync jobs to replicate data between PBS instances.
 
 **Note:** The password is stored in Terraform state, but is write-only from the API perspective (the API does not return the password on GET requests). 
-Updates to the password will always be sent to the API but cannot be verified by reading back the configuration.`,
+Updates to the password will always be sent to the API but cannot be verified by reading back the configuration.
+Use ` + "`password_wo`" + ` with ` + "`password_wo_version`" + ` (Terraform 1.11+) to keep the password out of state.`,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description:         "The unique identifier for the remote (3-32 characters).",
@@ -120,12 +126,17 @@ Updates to the password will always be sent to the API but cannot be verified by
 				Description: "Password or authentication token for the remote server.",
 				MarkdownDescription: "Password or authentication token for the remote server. " +
 					"This value is write-only from the API perspective (not returned on GET), but will be stored in Terraform state as a sensitive value.",
-				Required:  true,
+				Optional:  true,
 				Sensitive: true,
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 1024),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("password_wo")),
 				},
 			},
+			"password_wo":         writeonly.SecretAttribute("password", "Password or authentication token for the remote server."),
+			"password_wo_version": writeonly.VersionAttribute("password"),
+			"password_hash":       writeonly.HashAttribute("password"),
+			"verify_secret":       writeonly.VerifyAttribute("scanning the datastores of the remote, which logs in with the stored password"),
 			"fingerprint": schema.StringAttribute{
 				Description: "X509 certificate fingerprint (SHA256) for TLS verification.",
 				MarkdownDescription: "X509 certificate fingerprint (SHA256) for TLS verification. " +
@@ -181,11 +192,17 @@ func (r *remoteResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	password, diags := writeonly.Value(ctx, req.Config, "password", plan.Password)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	remote := &remotes.Remote{
 		Name:     plan.Name.ValueString(),
 		Host:     plan.Host.ValueString(),
 		AuthID:   plan.AuthID.ValueString(),
-		Password: plan.Password.ValueString(),
+		Password: password,
 	}
 
 	if !plan.Port.IsNull() && !plan.Port.IsUnknown() {
@@ -199,7 +216,10 @@ func (r *remoteResource) Create(ctx context.Context, req resource.CreateRequest,
 		remote.Comment = plan.Comment.ValueString()
 	}
 
-	if err := r.client.Remotes.CreateRemote(ctx, remote); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainRemote, func() error {
+		return r.client.Remotes.CreateRemote(ctx, remote)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating remote",
 			fmt.Sprintf("Could not create remote %s: %s", plan.Name.ValueString(), err.Error()),
@@ -217,6 +237,8 @@ func (r *remoteResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	plan.PasswordHash = writeonly.CreateHash(password, &resp.Diagnostics)
+
 	var state remoteResourceModel
 	setRemoteState(createdRemote, &state, &plan)
 
@@ -242,6 +264,12 @@ func (r *remoteResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	setRemoteState(remote, &state, &state)
 
+	secret := writeonly.Secret{Attr: "password", Plain: &state.Password, Version: &state.PasswordWOVersion, Hash: &state.PasswordHash}
+	writeonly.Verify(ctx, state.VerifySecret, secret, "remote "+state.Name.ValueString(), func() error {
+		_, err := r.client.Remotes.ListRemoteStores(ctx, state.Name.ValueString())
+		return err
+	}, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -264,11 +292,18 @@ func (r *remoteResource) Update(ctx context.Context, req resource.UpdateRequest,
 		plan.Digest = state.Digest
 	}
 
+	// Write-only passwords are only sent when password_wo_version changes
+	password, diags := writeonly.UpdateValue(ctx, req.Config, "password", plan.Password, plan.PasswordWOVersion, state.PasswordWOVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	remote := &remotes.Remote{
 		Name:     plan.Name.ValueString(),
 		Host:     plan.Host.ValueString(),
 		AuthID:   plan.AuthID.ValueString(),
-		Password: plan.Password.ValueString(),
+		Password: password,
 		Digest:   plan.Digest.ValueString(),
 	}
 
@@ -286,7 +321,10 @@ func (r *remoteResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Compute delete array for fields that were cleared
 	remote.Delete = computeRemoteDeletes(&plan, &state)
 
-	if err := r.client.Remotes.UpdateRemote(ctx, plan.Name.ValueString(), remote); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainRemote, func() error {
+		return r.client.Remotes.UpdateRemote(ctx, plan.Name.ValueString(), remote)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating remote",
 			fmt.Sprintf("Could not update remote %s: %s", plan.Name.ValueString(), err.Error()),
@@ -304,6 +342,7 @@ func (r *remoteResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	plan.PasswordHash = writeonly.UpdateHash(password, state.PasswordHash, &resp.Diagnostics)
 	setRemoteState(updatedRemote, &state, &plan)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -322,7 +361,10 @@ func (r *remoteResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		digest = state.Digest.ValueString()
 	}
 
-	if err := r.client.Remotes.DeleteRemote(ctx, state.Name.ValueString(), digest); err != nil {
+	err := r.client.WithConfigLock(ctx, pbs.LockDomainRemote, func() error {
+		return r.client.Remotes.DeleteRemote(ctx, state.Name.ValueString(), digest)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting remote",
 			fmt.Sprintf("Could not delete remote %s: %s", state.Name.ValueString(), err.Error()),
@@ -338,7 +380,7 @@ func (r *remoteResource) ImportState(ctx context.Context, req resource.ImportSta
 	// Add a warning about password
 	resp.Diagnostics.AddWarning(
 		"Password not imported",
-		"The remote password/token is not included in the import. You must set the password in your configuration and run 'terraform apply' to update it.",
+		"The remote password/token is not included in the import. You must set the password (or password_wo with password_wo_version) in your configuration and run 'terraform apply' to update it.",
 	)
 }
 
@@ -377,6 +419,12 @@ func setRemoteState(remote *remotes.Remote, state *remoteResourceModel, plan *re
 		// On import or if not in plan, set to null to trigger update
 		state.Password = types.StringNull()
 	}
+	state.PasswordWO = types.StringNull()
+	if plan != nil {
+		state.PasswordWOVersion = plan.PasswordWOVersion
+		state.PasswordHash = plan.PasswordHash
+		state.VerifySecret = plan.VerifySecret
+	}
 }
 
 // computeRemoteDeletes determines which optional fields should be deleted
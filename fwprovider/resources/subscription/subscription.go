@@ -0,0 +1,307 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package subscription provides Terraform resources for PBS subscription management
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/fwprovider/config"
+	"github.com/micah/terraform-provider-pbs/pbs"
+	"github.com/micah/terraform-provider-pbs/pbs/subscription"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &subscriptionResource{}
+	_ resource.ResourceWithConfigure   = &subscriptionResource{}
+	_ resource.ResourceWithImportState = &subscriptionResource{}
+)
+
+// NewSubscriptionResource is a helper function to simplify the provider implementation.
+func NewSubscriptionResource() resource.Resource {
+	return &subscriptionResource{}
+}
+
+// subscriptionResource is the resource implementation.
+type subscriptionResource struct {
+	client *pbs.Client
+}
+
+// subscriptionResourceModel maps the resource schema data.
+type subscriptionResourceModel struct {
+	Node        types.String `tfsdk:"node"`
+	Key         types.String `tfsdk:"key"`
+	Status      types.String `tfsdk:"status"`
+	Level       types.String `tfsdk:"level"`
+	ProductName types.String `tfsdk:"product_name"`
+	ServerID    types.String `tfsdk:"server_id"`
+	RegDate     types.String `tfsdk:"registration_date"`
+	NextDueDate types.String `tfsdk:"next_due_date"`
+	CheckTime   types.Int64  `tfsdk:"check_time"`
+	Message     types.String `tfsdk:"message"`
+}
+
+// Metadata returns the resource type name.
+func (r *subscriptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscription"
+}
+
+// Schema defines the schema for the resource.
+func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the subscription key of a PBS node.",
+		MarkdownDescription: `Manages the subscription key of a PBS node.
+
+The key is uploaded and immediately checked against the Proxmox shop server, so ` + "`status`" + `
+reflects the validated state after apply. Destroying the resource removes the key from the node.`,
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Description:         "The PBS node to license. Defaults to the only node of the PBS instance.",
+				MarkdownDescription: "The PBS node to license. Defaults to the only node of the PBS instance; required when there are several.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Description:         "The subscription key.",
+				MarkdownDescription: "The subscription key (e.g., `pbsc-0123456789`).",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						subscription.KeyRegex,
+						"must be a PBS subscription key of the form pbs<level>-<10 hex digits>",
+					),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description:         "The subscription status.",
+				MarkdownDescription: "The subscription status (`new`, `notfound`, `active`, `invalid`, `expired`, `suspended`).",
+				Computed:            true,
+			},
+			"level": schema.StringAttribute{
+				Description:         "The subscription level.",
+				MarkdownDescription: "The subscription level derived from the key (`community`, `basic`, `standard`, `premium`).",
+				Computed:            true,
+			},
+			"product_name": schema.StringAttribute{
+				Description:         "The subscribed product name.",
+				MarkdownDescription: "The subscribed product name.",
+				Computed:            true,
+			},
+			"server_id": schema.StringAttribute{
+				Description:         "The server ID the subscription is bound to.",
+				MarkdownDescription: "The server ID the subscription is bound to.",
+				Computed:            true,
+			},
+			"registration_date": schema.StringAttribute{
+				Description:         "The subscription registration date.",
+				MarkdownDescription: "The subscription registration date.",
+				Computed:            true,
+			},
+			"next_due_date": schema.StringAttribute{
+				Description:         "The next subscription due date.",
+				MarkdownDescription: "The next subscription due date.",
+				Computed:            true,
+			},
+			"check_time": schema.Int64Attribute{
+				Description:         "Unix timestamp of the last subscription check.",
+				MarkdownDescription: "Unix timestamp of the last subscription check.",
+				Computed:            true,
+			},
+			"message": schema.StringAttribute{
+				Description:         "The status message returned by the last check.",
+				MarkdownDescription: "The status message returned by the last check.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *subscriptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *config.Resource, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+// Create uploads and checks the subscription key, then sets the initial Terraform state.
+func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan subscriptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node, err := r.client.Nodes.ResolveNode(ctx, plan.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("node"), "Error resolving node", err.Error())
+		return
+	}
+	plan.Node = types.StringValue(node)
+
+	info, err := r.setAndCheck(ctx, plan.Node.ValueString(), plan.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting subscription",
+			fmt.Sprintf("Could not set subscription on node %s: %s", plan.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	setSubscriptionState(info, &plan)
+	addStatusWarning(info, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *subscriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state subscriptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := r.client.Subscription.GetSubscription(ctx, state.Node.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading subscription",
+			fmt.Sprintf("Could not read subscription on node %s: %s", state.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if info.Status == "notfound" || info.Key == "" {
+		tflog.Info(ctx, "Subscription key no longer set, removing from state", map[string]any{"node": state.Node.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Surface out-of-band key changes as drift
+	state.Key = types.StringValue(info.Key)
+	setSubscriptionState(info, &state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update uploads and checks the new subscription key.
+func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan subscriptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := r.setAndCheck(ctx, plan.Node.ValueString(), plan.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating subscription",
+			fmt.Sprintf("Could not update subscription on node %s: %s", plan.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	setSubscriptionState(info, &plan)
+	addStatusWarning(info, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the subscription key from the node.
+func (r *subscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state subscriptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Subscription.DeleteSubscription(ctx, state.Node.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting subscription",
+			fmt.Sprintf("Could not delete subscription on node %s: %s", state.Node.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource by node name.
+func (r *subscriptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("node"), req, resp)
+}
+
+// setAndCheck uploads the key, triggers a forced check and returns the resulting subscription state.
+func (r *subscriptionResource) setAndCheck(ctx context.Context, node, key string) (*subscription.Info, error) {
+	if err := r.client.Subscription.SetSubscription(ctx, node, key); err != nil {
+		return nil, err
+	}
+
+	if err := r.client.Subscription.CheckSubscription(ctx, node, true); err != nil {
+		return nil, err
+	}
+
+	return r.client.Subscription.GetSubscription(ctx, node)
+}
+
+func setSubscriptionState(info *subscription.Info, state *subscriptionResourceModel) {
+	state.Status = types.StringValue(info.Status)
+	state.Level = stringValueOrNull(info.Level())
+	state.ProductName = stringValueOrNull(info.ProductName)
+	state.ServerID = stringValueOrNull(info.ServerID)
+	state.RegDate = stringValueOrNull(info.RegDate)
+	state.NextDueDate = stringValueOrNull(info.NextDueDate)
+	state.CheckTime = types.Int64PointerValue(info.CheckTime)
+	state.Message = stringValueOrNull(info.Message)
+}
+
+// addStatusWarning warns when the shop server did not accept the key, without failing the apply.
+func addStatusWarning(info *subscription.Info, diags *diag.Diagnostics) {
+	if strings.EqualFold(info.Status, "active") {
+		return
+	}
+
+	detail := fmt.Sprintf("The subscription key was set but its status is %q.", info.Status)
+	if info.Message != "" {
+		detail += " " + info.Message
+	}
+	diags.AddWarning("Subscription not active", detail)
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,15 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationValidator(t *testing.T) {
+	require.False(t, validateString(Duration(), types.StringValue("1h30m")).Diagnostics.HasError())
+	require.False(t, validateString(Duration(), types.StringNull()).Diagnostics.HasError())
+	require.True(t, validateString(Duration(), types.StringValue("90")).Diagnostics.HasError())
+	require.True(t, validateString(Duration(), types.StringValue("0s")).Diagnostics.HasError())
+}
@@ -0,0 +1,83 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package validators provides Terraform attribute validators shared by PBS resources
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/micah/terraform-provider-pbs/pbs/calendar"
+	"github.com/micah/terraform-provider-pbs/pbs/notifications"
+)
+
+var (
+	_ validator.String = calendarEventValidator{}
+	_ validator.String = dailyDurationValidator{}
+)
+
+// CalendarEvent returns a validator that checks a string is a PBS calendar event
+// such as "daily", "*/15" or "mon..fri 02:00".
+func CalendarEvent() validator.String {
+	return calendarEventValidator{}
+}
+
+type calendarEventValidator struct{}
+
+func (v calendarEventValidator) Description(_ context.Context) string {
+	return "value must be a valid PBS calendar event"
+}
+
+func (v calendarEventValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v calendarEventValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := calendar.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Calendar Event",
+			fmt.Sprintf("PBS would reject this schedule: %s", err.Error()),
+		)
+	}
+}
+
+// DailyDuration returns a validator that checks a string is a notification
+// matcher calendar window such as "8-17" or "mon..fri 9:00-17:30".
+func DailyDuration() validator.String {
+	return dailyDurationValidator{}
+}
+
+type dailyDurationValidator struct{}
+
+func (v dailyDurationValidator) Description(_ context.Context) string {
+	return "value must be a valid PBS time window ([weekdays] start-end)"
+}
+
+func (v dailyDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dailyDurationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := notifications.ParseDailyDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Calendar Window",
+			fmt.Sprintf("PBS would reject this match_calendar entry: %s", err.Error()),
+		)
+	}
+}
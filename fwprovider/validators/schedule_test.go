@@ -0,0 +1,30 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func validateString(v validator.String, value types.String) *validator.StringResponse {
+	req := validator.StringRequest{Path: path.Root("schedule"), ConfigValue: value}
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), req, resp)
+	return resp
+}
+
+func TestCalendarEventValidator(t *testing.T) {
+	require.False(t, validateString(CalendarEvent(), types.StringValue("mon..fri 02:00")).Diagnostics.HasError())
+	require.False(t, validateString(CalendarEvent(), types.StringNull()).Diagnostics.HasError())
+	require.False(t, validateString(CalendarEvent(), types.StringUnknown()).Diagnostics.HasError())
+	require.True(t, validateString(CalendarEvent(), types.StringValue("0 3 * * 0")).Diagnostics.HasError())
+}
+
+func TestDailyDurationValidator(t *testing.T) {
+	require.False(t, validateString(DailyDuration(), types.StringValue("mon-fri 9:00-17:00")).Diagnostics.HasError())
+	require.True(t, validateString(DailyDuration(), types.StringValue("17-9")).Diagnostics.HasError())
+}
@@ -0,0 +1,223 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package writeonly
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/micah/terraform-provider-pbs/pbs"
+)
+
+const hashPrefix = "sha256:"
+
+// HashName returns the hash attribute name for a secret attribute
+func HashName(attr string) string {
+	return attr + "_hash"
+}
+
+// Hash returns a salted SHA-256 hash of secret in the form sha256:<salt>:<digest>
+func Hash(secret string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return hashWithSalt(salt, secret), nil
+}
+
+func hashWithSalt(salt []byte, secret string) string {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), secret...))
+	return hashPrefix + hex.EncodeToString(salt) + ":" + hex.EncodeToString(sum[:])
+}
+
+// HashMatches reports whether hash was produced by Hash for secret
+func HashMatches(hash, secret string) bool {
+	parts := strings.Split(strings.TrimPrefix(hash, hashPrefix), ":")
+	if !strings.HasPrefix(hash, hashPrefix) || len(parts) != 2 {
+		return false
+	}
+	salt, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashWithSalt(salt, secret)), []byte(hash)) == 1
+}
+
+// HashAttribute returns the computed attribute recording the hash of the last
+// secret sent to PBS for attr. It only changes when the secret is sent again.
+func HashAttribute(attr string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("Salted SHA-256 hash of the last %s sent to PBS. PBS never returns the secret, so this records what was applied.", attr),
+		MarkdownDescription: fmt.Sprintf("Salted SHA-256 hash of the last `%s` sent to PBS. PBS never returns the secret, so this records what was applied; "+
+			"it is cleared when `verify_secret` detects drift.", attr),
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			hashPlanModifier{attr: attr},
+		},
+	}
+}
+
+// VerifyAttribute returns the verify_secret attribute. check describes how the
+// stored secret is verified.
+func VerifyAttribute(check string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Verify the secret stored in PBS during refresh by %s. "+
+			"A rejected credential is reported as drift and the secret is sent again on the next apply.", check),
+		MarkdownDescription: fmt.Sprintf("Verify the secret stored in PBS during refresh by %s. "+
+			"A rejected credential is reported as drift and the secret is sent again on the next apply. Defaults to `false`.", check),
+		Optional: true,
+	}
+}
+
+// VerifyByNotificationAttribute returns the verify_secret attribute for notification
+// targets, whose only check is sending a test notification. The description makes
+// clear that enabling it delivers a real message on every refresh.
+func VerifyByNotificationAttribute(check string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Verify the secret stored in PBS during refresh by sending a test notification, %s. "+
+			"PBS has no check without side effects, so this delivers a real message through the target on every refresh, "+
+			"i.e. during every plan and apply. A rejected credential is reported as drift and the secret is sent again on the next apply.", check),
+		MarkdownDescription: fmt.Sprintf("Verify the secret stored in PBS during refresh by sending a test notification, %s. "+
+			"PBS has no check without side effects, so **this delivers a real message through the target on every refresh**, "+
+			"i.e. during every `terraform plan` and `terraform apply`; only enable it where that is acceptable. "+
+			"A rejected credential is reported as drift and the secret is sent again on the next apply. Defaults to `false`.", check),
+		Optional: true,
+	}
+}
+
+// UpdateHash returns the hash to record after an update. sent is the secret
+// sent to PBS, or empty if the stored secret was kept. The previous hash is kept
+// when the same secret is sent again, matching the plan of HashAttribute.
+func UpdateHash(sent string, previous types.String, diags *diag.Diagnostics) types.String {
+	if sent == "" || HashMatches(previous.ValueString(), sent) {
+		return previous
+	}
+	return newHash(sent, diags)
+}
+
+// CreateHash returns the hash to record after create
+func CreateHash(sent string, diags *diag.Diagnostics) types.String {
+	if sent == "" {
+		return types.StringNull()
+	}
+	return newHash(sent, diags)
+}
+
+// newHash hashes a secret that was sent to PBS. The secret has already been
+// applied at this point, so a failure only leaves the hash unset and is reported
+// as a warning rather than failing the apply.
+func newHash(sent string, diags *diag.Diagnostics) types.String {
+	hash, err := Hash(sent)
+	if err != nil {
+		diags.AddWarning(
+			"Could not record secret hash",
+			fmt.Sprintf("The secret was sent to PBS, but its hash could not be computed: %s", err.Error()),
+		)
+		return types.StringNull()
+	}
+	return types.StringValue(hash)
+}
+
+// hashPlanModifier keeps the prior hash unless the secret or its write-only
+// version changes.
+type hashPlanModifier struct {
+	attr string
+}
+
+func (m hashPlanModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Keeps the prior hash unless %s or %s changes.", m.attr, VersionName(m.attr))
+}
+
+func (m hashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m hashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planSecret, stateSecret types.String
+	var planVersion, stateVersion types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(m.attr), &planSecret)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(m.attr), &stateSecret)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(VersionName(m.attr)), &planVersion)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(VersionName(m.attr)), &stateVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planSecret.Equal(stateSecret) && planVersion.Equal(stateVersion) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// Secret points at the state values of a secret attribute and its companions
+type Secret struct {
+	Attr    string
+	Plain   *types.String
+	Version *types.Int64
+	Hash    *types.String
+}
+
+// applied reports whether a secret was sent to PBS through this resource
+func (s Secret) applied() bool {
+	return !s.Plain.IsNull() || !s.Version.IsNull() || !s.Hash.IsNull()
+}
+
+// markDrift clears the state values so the next plan sends the secret again:
+// a plain secret differs from the configuration, a write-only secret gets a
+// version change.
+func (s Secret) markDrift() {
+	if !s.Plain.IsNull() {
+		*s.Plain = types.StringNull()
+	} else {
+		*s.Version = types.Int64Null()
+	}
+	*s.Hash = types.StringNull()
+}
+
+// Verify runs check when verify is set and a secret has been applied. If the
+// credential is rejected the secret is marked as drifted; other failures only
+// produce a warning, since they say nothing about the secret.
+func Verify(ctx context.Context, verify types.Bool, s Secret, resource string, check func() error, diags *diag.Diagnostics) {
+	if !verify.ValueBool() || !s.applied() {
+		return
+	}
+
+	err := check()
+	if err == nil {
+		tflog.Debug(ctx, "Verified stored secret", map[string]any{"resource": resource, "attribute": s.Attr})
+		return
+	}
+
+	if !pbs.IsCredentialError(err) {
+		diags.AddWarning(
+			"Could not verify secret",
+			fmt.Sprintf("Verifying %s of %s failed for a reason unrelated to the credential, so drift could not be determined: %s", s.Attr, resource, err.Error()),
+		)
+		return
+	}
+
+	s.markDrift()
+	diags.AddWarning(
+		"Secret drift detected",
+		fmt.Sprintf("The %s stored in PBS for %s was rejected: %s\n\nIt was probably changed outside of Terraform. The next apply sends the configured value again.", s.Attr, resource, err.Error()),
+	)
+}
@@ -0,0 +1,90 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package writeonly provides helpers for secret attributes that PBS never returns.
+//
+// A secret attribute "password" gets companions: "password_wo", which is never
+// persisted to plan or state (Terraform 1.11+), "password_wo_version", a plain
+// number whose change tells the provider to send the write-only value again, and
+// "password_hash", a salted hash of the last value sent. With "verify_secret" the
+// stored secret is checked during refresh and drift is reported.
+package writeonly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Name returns the write-only attribute name for a secret attribute
+func Name(attr string) string {
+	return attr + "_wo"
+}
+
+// VersionName returns the version attribute name for a secret attribute
+func VersionName(attr string) string {
+	return attr + "_wo_version"
+}
+
+// SecretAttribute returns the write-only variant of the secret attribute attr.
+// It conflicts with attr and requires the version attribute.
+func SecretAttribute(attr, description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("Write-only variant of %s: %s Not stored in Terraform state; requires Terraform 1.11 or later.", attr, description),
+		MarkdownDescription: fmt.Sprintf("Write-only variant of `%s`: %s Not stored in Terraform state; requires Terraform 1.11 or later. "+
+			"Change `%s` to send a new value.", attr, description, VersionName(attr)),
+		Optional:  true,
+		Sensitive: true,
+		WriteOnly: true,
+		Validators: []validator.String{
+			stringvalidator.ConflictsWith(path.MatchRoot(attr)),
+			stringvalidator.AlsoRequires(path.MatchRoot(VersionName(attr))),
+		},
+	}
+}
+
+// VersionAttribute returns the version attribute that triggers sending the
+// write-only value of attr.
+func VersionAttribute(attr string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description:         fmt.Sprintf("Version of %s. Change it to rotate the secret.", Name(attr)),
+		MarkdownDescription: fmt.Sprintf("Version of `%s`. The write-only value is only sent to PBS when this changes, so bump it to rotate the secret.", Name(attr)),
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRoot(Name(attr))),
+		},
+	}
+}
+
+// Value returns the secret configured for attr: the plain attribute if set,
+// otherwise the write-only value from the configuration.
+func Value(ctx context.Context, config tfsdk.Config, attr string, plain types.String) (string, diag.Diagnostics) {
+	if !plain.IsNull() && !plain.IsUnknown() {
+		return plain.ValueString(), nil
+	}
+
+	var wo types.String
+	diags := config.GetAttribute(ctx, path.Root(Name(attr)), &wo)
+	return wo.ValueString(), diags
+}
+
+// UpdateValue returns the secret to send on update, or an empty string to keep
+// the secret stored in PBS. Plain secrets are always sent; write-only secrets
+// only when the version attribute changed.
+func UpdateValue(ctx context.Context, config tfsdk.Config, attr string, plain types.String, planVersion, stateVersion types.Int64) (string, diag.Diagnostics) {
+	if plain.IsNull() && planVersion.Equal(stateVersion) {
+		return "", nil
+	}
+	return Value(ctx, config, attr, plain)
+}
@@ -0,0 +1,174 @@
+package writeonly
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(t *testing.T, plain, wo *string, version *int64) tfsdk.Config {
+	t.Helper()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"password":            schema.StringAttribute{Optional: true, Sensitive: true},
+			"password_wo":         SecretAttribute("password", "The password."),
+			"password_wo_version": VersionAttribute("password"),
+		},
+	}
+
+	strVal := func(v *string) tftypes.Value {
+		if v == nil {
+			return tftypes.NewValue(tftypes.String, nil)
+		}
+		return tftypes.NewValue(tftypes.String, *v)
+	}
+	numVal := tftypes.NewValue(tftypes.Number, nil)
+	if version != nil {
+		numVal = tftypes.NewValue(tftypes.Number, *version)
+	}
+
+	return tfsdk.Config{
+		Schema: s,
+		Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+			"password":            strVal(plain),
+			"password_wo":         strVal(wo),
+			"password_wo_version": numVal,
+		}),
+	}
+}
+
+func TestSchemaAttributes(t *testing.T) {
+	wo := SecretAttribute("token", "The API token.")
+	require.True(t, wo.IsWriteOnly())
+	require.True(t, wo.IsSensitive())
+	require.False(t, wo.IsComputed())
+
+	version := VersionAttribute("token")
+	require.True(t, version.IsOptional())
+	require.False(t, version.IsWriteOnly())
+
+	require.Equal(t, "token_wo", Name("token"))
+	require.Equal(t, "token_wo_version", VersionName("token"))
+}
+
+func TestValue(t *testing.T) {
+	ctx := context.Background()
+	plain, secret := "plain-secret", "vault-secret"
+	version := int64(1)
+
+	got, diags := Value(ctx, testConfig(t, &plain, nil, nil), "password", types.StringValue(plain))
+	require.False(t, diags.HasError())
+	require.Equal(t, plain, got)
+
+	got, diags = Value(ctx, testConfig(t, nil, &secret, &version), "password", types.StringNull())
+	require.False(t, diags.HasError())
+	require.Equal(t, secret, got)
+}
+
+func TestUpdateValue(t *testing.T) {
+	ctx := context.Background()
+	secret := "vault-secret"
+	version := int64(2)
+	cfg := testConfig(t, nil, &secret, &version)
+
+	// Unchanged version keeps the secret stored in PBS
+	got, diags := UpdateValue(ctx, cfg, "password", types.StringNull(), types.Int64Value(2), types.Int64Value(2))
+	require.False(t, diags.HasError())
+	require.Empty(t, got)
+
+	// Bumped version sends the write-only value
+	got, diags = UpdateValue(ctx, cfg, "password", types.StringNull(), types.Int64Value(2), types.Int64Value(1))
+	require.False(t, diags.HasError())
+	require.Equal(t, secret, got)
+
+	// Plain secrets are always sent
+	plain := "plain-secret"
+	got, diags = UpdateValue(ctx, testConfig(t, &plain, nil, nil), "password", types.StringValue(plain), types.Int64Null(), types.Int64Null())
+	require.False(t, diags.HasError())
+	require.Equal(t, plain, got)
+}
+
+func TestHash(t *testing.T) {
+	h1, err := Hash("s3cret")
+	require.NoError(t, err)
+	h2, err := Hash("s3cret")
+	require.NoError(t, err)
+
+	require.NotEqual(t, h1, h2, "hashes should be salted")
+	require.True(t, HashMatches(h1, "s3cret"))
+	require.True(t, HashMatches(h2, "s3cret"))
+	require.False(t, HashMatches(h1, "other"))
+	require.False(t, HashMatches("", "s3cret"))
+	require.False(t, HashMatches("sha256:zz:00", "s3cret"))
+}
+
+func TestUpdateHash(t *testing.T) {
+	var diags diag.Diagnostics
+	previous := CreateHash("old", &diags)
+
+	require.Equal(t, previous, UpdateHash("", previous, &diags), "kept secret keeps the hash")
+	require.Equal(t, previous, UpdateHash("old", previous, &diags), "resent secret keeps the hash")
+
+	updated := UpdateHash("new", previous, &diags)
+	require.True(t, HashMatches(updated.ValueString(), "new"))
+
+	require.True(t, CreateHash("", &diags).IsNull())
+	require.Empty(t, diags)
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	credentialErr := errors.New("API error 400: authentication failed - invalid credentials")
+
+	t.Run("plain secret drift", func(t *testing.T) {
+		plain, version, hash := types.StringValue("pw"), types.Int64Null(), CreateHash("pw", &diag.Diagnostics{})
+		var diags diag.Diagnostics
+
+		Verify(ctx, types.BoolValue(true), Secret{Attr: "password", Plain: &plain, Version: &version, Hash: &hash}, "remote r1",
+			func() error { return credentialErr }, &diags)
+
+		require.Equal(t, 1, diags.WarningsCount())
+		require.True(t, plain.IsNull())
+		require.True(t, hash.IsNull())
+	})
+
+	t.Run("write-only secret drift", func(t *testing.T) {
+		plain, version, hash := types.StringNull(), types.Int64Value(3), CreateHash("pw", &diag.Diagnostics{})
+		var diags diag.Diagnostics
+
+		Verify(ctx, types.BoolValue(true), Secret{Attr: "password", Plain: &plain, Version: &version, Hash: &hash}, "remote r1",
+			func() error { return credentialErr }, &diags)
+
+		require.True(t, version.IsNull())
+		require.True(t, hash.IsNull())
+	})
+
+	t.Run("unrelated failure", func(t *testing.T) {
+		plain, version, hash := types.StringValue("pw"), types.Int64Null(), CreateHash("pw", &diag.Diagnostics{})
+		var diags diag.Diagnostics
+
+		Verify(ctx, types.BoolValue(true), Secret{Attr: "password", Plain: &plain, Version: &version, Hash: &hash}, "remote r1",
+			func() error { return errors.New("connection refused") }, &diags)
+
+		require.Equal(t, 1, diags.WarningsCount())
+		require.Equal(t, "pw", plain.ValueString())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		plain, version, hash := types.StringValue("pw"), types.Int64Null(), CreateHash("pw", &diag.Diagnostics{})
+		called := false
+
+		Verify(ctx, types.BoolNull(), Secret{Attr: "password", Plain: &plain, Version: &version, Hash: &hash}, "remote r1",
+			func() error { called = true; return nil }, &diag.Diagnostics{})
+
+		require.False(t, called)
+	})
+}
@@ -262,6 +262,11 @@ func (c *Client) DoRequest(ctx context.Context, method, apiPath string, body int
 	return &apiResp, nil
 }
 
+// Endpoint returns the base URL of the PBS server this client talks to
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
 // Get performs a GET request
 func (c *Client) Get(ctx context.Context, path string) (*APIResponse, error) {
 	return c.DoRequest(ctx, "GET", path, nil)
@@ -0,0 +1,85 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// taskLogTailLines is the number of task log lines included in a failed task error
+const taskLogTailLines = 20
+
+// taskLogFetchLimit bounds the number of task log lines fetched for a failed task
+const taskLogFetchLimit = 5000
+
+// TaskLogLine is a single line of a task log
+type TaskLogLine struct {
+	N int    `json:"n"`
+	T string `json:"t"`
+}
+
+// UPIDNode returns the node a task runs on from its UPID, which has the form
+// "UPID:<node>:<pid>:<pstart>:<task id>:<start time>:<type>:<id>:<auth id>:"
+func UPIDNode(upid string) (string, error) {
+	parts := strings.Split(upid, ":")
+	if len(parts) < 2 || parts[0] != "UPID" || parts[1] == "" {
+		return "", fmt.Errorf("invalid UPID %q", upid)
+	}
+	return parts[1], nil
+}
+
+// GetTaskLog retrieves up to limit lines of a task log, starting at line start
+func (c *Client) GetTaskLog(ctx context.Context, node, upid string, start, limit int) ([]TaskLogLine, error) {
+	path := fmt.Sprintf("/nodes/%s/tasks/%s/log?start=%d&limit=%d", url.PathEscape(node), url.PathEscape(upid), start, limit)
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task log: %w", err)
+	}
+
+	var lines []TaskLogLine
+	if err := json.Unmarshal(resp.Data, &lines); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task log: %w", err)
+	}
+
+	return lines, nil
+}
+
+// WaitForTaskWithLog waits for a task identified only by its UPID. When the
+// task fails the tail of its task log is appended to the error.
+func (c *Client) WaitForTaskWithLog(ctx context.Context, upid string, timeout time.Duration) error {
+	node, err := UPIDNode(upid)
+	if err != nil {
+		return err
+	}
+
+	if err := c.WaitForTask(ctx, node, upid, timeout); err != nil {
+		lines, logErr := c.GetTaskLog(ctx, node, upid, 0, taskLogFetchLimit)
+		if logErr != nil || len(lines) == 0 {
+			return fmt.Errorf("task %s failed: %w", upid, err)
+		}
+		return fmt.Errorf("task %s failed: %w\n\nTask log:\n%s", upid, err, taskLogTail(lines, taskLogTailLines))
+	}
+
+	return nil
+}
+
+// taskLogTail joins the last n lines of a task log
+func taskLogTail(lines []TaskLogLine, n int) string {
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	text := make([]string, len(lines))
+	for i, line := range lines {
+		text[i] = line.T
+	}
+	return strings.Join(text, "\n")
+}
@@ -0,0 +1,31 @@
+package api
+
+import "testing"
+
+func TestUPIDNode(t *testing.T) {
+	node, err := UPIDNode("UPID:pbs1:00001234:00005678:00000000:68E00000:syncjob:sync-a:root@pam:")
+	if err != nil || node != "pbs1" {
+		t.Fatalf("UPIDNode() = %q, %v", node, err)
+	}
+
+	for _, upid := range []string{"", "not-a-upid", "UPID::00001234"} {
+		if _, err := UPIDNode(upid); err == nil {
+			t.Errorf("UPIDNode(%q) expected error", upid)
+		}
+	}
+}
+
+func TestTaskLogTail(t *testing.T) {
+	lines := []TaskLogLine{
+		{N: 1, T: "starting garbage collection"},
+		{N: 2, T: "Start GC phase1 (mark used chunks)"},
+		{N: 3, T: "TASK ERROR: atime safety check failed"},
+	}
+
+	if got := taskLogTail(lines, 2); got != "Start GC phase1 (mark used chunks)\nTASK ERROR: atime safety check failed" {
+		t.Errorf("taskLogTail(2) = %q", got)
+	}
+	if got := taskLogTail(lines, 10); got != "starting garbage collection\nStart GC phase1 (mark used chunks)\nTASK ERROR: atime safety check failed" {
+		t.Errorf("taskLogTail(10) = %q", got)
+	}
+}
@@ -0,0 +1,256 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package apt provides API client functionality for PBS APT repository and update management
+package apt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/micah/terraform-provider-pbs/pbs/api"
+)
+
+// aptUpdateTimeout bounds how long an `apt update` task may run
+const aptUpdateTimeout = 5 * time.Minute
+
+// standardRepoComponents maps standard repository handles to the APT component they configure
+var standardRepoComponents = map[string]string{
+	"enterprise":      "pbs-enterprise",
+	"no-subscription": "pbs-no-subscription",
+	"test":            "pbstest",
+}
+
+// StandardRepoHandles lists the standard repository handles supported by PBS
+var StandardRepoHandles = []string{"enterprise", "no-subscription", "test"}
+
+// Client represents the APT API client
+type Client struct {
+	api *api.Client
+}
+
+// NewClient creates a new APT API client
+func NewClient(apiClient *api.Client) *Client {
+	return &Client{api: apiClient}
+}
+
+// Repository represents a single repository entry within an APT sources file
+type Repository struct {
+	Types      []string `json:"Types"`
+	URIs       []string `json:"URIs"`
+	Suites     []string `json:"Suites"`
+	Components []string `json:"Components,omitempty"`
+	Comment    string   `json:"Comment,omitempty"`
+	FileType   string   `json:"FileType"`
+	Enabled    bool     `json:"Enabled"`
+}
+
+// RepositoryFile represents an APT sources file and the repositories it contains
+type RepositoryFile struct {
+	Path         string       `json:"path,omitempty"`
+	FileType     string       `json:"file-type"`
+	Repositories []Repository `json:"repositories"`
+	Digest       []int        `json:"digest,omitempty"`
+}
+
+// StandardRepository describes the configuration state of a standard Proxmox repository
+type StandardRepository struct {
+	Handle string `json:"handle"`
+	Name   string `json:"name"`
+	// Status is nil when the repository is not configured, otherwise whether it is enabled
+	Status *bool `json:"status,omitempty"`
+}
+
+// Repositories is the response of GET /nodes/{node}/apt/repositories
+type Repositories struct {
+	Files         []RepositoryFile     `json:"files"`
+	Digest        string               `json:"digest"`
+	StandardRepos []StandardRepository `json:"standard-repos"`
+}
+
+// RepositoryLocation identifies a repository entry by its sources file and index
+type RepositoryLocation struct {
+	Path  string
+	Index int
+}
+
+// UpdateInfo represents a pending package update
+type UpdateInfo struct {
+	Package     string `json:"Package"`
+	Title       string `json:"Title"`
+	Arch        string `json:"Arch"`
+	Description string `json:"Description"`
+	Version     string `json:"Version"`
+	OldVersion  string `json:"OldVersion"`
+	Origin      string `json:"Origin"`
+	Priority    string `json:"Priority"`
+	Section     string `json:"Section"`
+	ExtraInfo   string `json:"ExtraInfo,omitempty"`
+}
+
+// GetRepositories returns the APT repository configuration of a node
+func (c *Client) GetRepositories(ctx context.Context, node string) (*Repositories, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/apt/repositories", url.PathEscape(node))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get APT repositories on node %s: %w", node, err)
+	}
+
+	var repos Repositories
+	if err := json.Unmarshal(resp.Data, &repos); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal APT repositories on node %s: %w", node, err)
+	}
+
+	return &repos, nil
+}
+
+// AddStandardRepository adds a standard repository (enabled) to the node's sources
+func (c *Client) AddStandardRepository(ctx context.Context, node, handle, digest string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+	if _, ok := standardRepoComponents[handle]; !ok {
+		return fmt.Errorf("unknown standard repository handle %q", handle)
+	}
+
+	body := map[string]interface{}{
+		"handle": handle,
+	}
+	if digest != "" {
+		body["digest"] = digest
+	}
+
+	path := fmt.Sprintf("/nodes/%s/apt/repositories", url.PathEscape(node))
+	if _, err := c.api.Put(ctx, path, body); err != nil {
+		return fmt.Errorf("failed to add APT repository %s on node %s: %w", handle, node, err)
+	}
+
+	return nil
+}
+
+// SetRepositoryEnabled enables or disables the repository at the given location
+func (c *Client) SetRepositoryEnabled(ctx context.Context, node string, loc RepositoryLocation, enabled bool, digest string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+
+	body := map[string]interface{}{
+		"path":    loc.Path,
+		"index":   loc.Index,
+		"enabled": enabled,
+	}
+	if digest != "" {
+		body["digest"] = digest
+	}
+
+	path := fmt.Sprintf("/nodes/%s/apt/repositories", url.PathEscape(node))
+	if _, err := c.api.Post(ctx, path, body); err != nil {
+		return fmt.Errorf("failed to update APT repository %s[%d] on node %s: %w", loc.Path, loc.Index, node, err)
+	}
+
+	return nil
+}
+
+// StandardRepository returns the configuration state of a standard repository, or nil if PBS does not report it
+func (r *Repositories) StandardRepository(handle string) *StandardRepository {
+	for i := range r.StandardRepos {
+		if r.StandardRepos[i].Handle == handle {
+			return &r.StandardRepos[i]
+		}
+	}
+	return nil
+}
+
+// FindStandardRepository locates the sources entry configuring a standard repository.
+// The first enabled match wins so that a stale disabled duplicate does not mask the active entry.
+func (r *Repositories) FindStandardRepository(handle string) (*RepositoryLocation, *Repository) {
+	component, ok := standardRepoComponents[handle]
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		firstLoc  *RepositoryLocation
+		firstRepo *Repository
+	)
+	for _, file := range r.Files {
+		for i := range file.Repositories {
+			repo := &file.Repositories[i]
+			if !hasComponent(repo, component) {
+				continue
+			}
+			loc := &RepositoryLocation{Path: file.Path, Index: i}
+			if repo.Enabled {
+				return loc, repo
+			}
+			if firstLoc == nil {
+				firstLoc, firstRepo = loc, repo
+			}
+		}
+	}
+
+	return firstLoc, firstRepo
+}
+
+func hasComponent(repo *Repository, component string) bool {
+	for _, c := range repo.Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// ListUpdates returns the pending package updates from the node's package cache
+func (c *Client) ListUpdates(ctx context.Context, node string) ([]UpdateInfo, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/apt/update", url.PathEscape(node))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list APT updates on node %s: %w", node, err)
+	}
+
+	var updates []UpdateInfo
+	if err := json.Unmarshal(resp.Data, &updates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal APT updates on node %s: %w", node, err)
+	}
+
+	return updates, nil
+}
+
+// RefreshUpdates runs `apt update` on the node and waits for the task to finish
+func (c *Client) RefreshUpdates(ctx context.Context, node string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/apt/update", url.PathEscape(node))
+	resp, err := c.api.Post(ctx, path, map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("failed to refresh APT package database on node %s: %w", node, err)
+	}
+
+	var upid string
+	if err := json.Unmarshal(resp.Data, &upid); err != nil {
+		return fmt.Errorf("failed to parse UPID from APT update response: %w", err)
+	}
+
+	if err := c.api.WaitForTask(ctx, node, upid, aptUpdateTimeout); err != nil {
+		return fmt.Errorf("APT update task failed (UPID: %s): %w", upid, err)
+	}
+
+	return nil
+}
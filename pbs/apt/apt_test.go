@@ -0,0 +1,69 @@
+package apt
+
+import (
+	"testing"
+)
+
+func TestFindStandardRepository(t *testing.T) {
+	repos := &Repositories{
+		Files: []RepositoryFile{
+			{
+				Path: "/etc/apt/sources.list",
+				Repositories: []Repository{
+					{URIs: []string{"http://deb.debian.org/debian"}, Components: []string{"main", "contrib"}, Enabled: true},
+					{URIs: []string{"http://download.proxmox.com/debian/pbs"}, Components: []string{"pbs-no-subscription"}, Enabled: false},
+				},
+			},
+			{
+				Path: "/etc/apt/sources.list.d/pbs.list",
+				Repositories: []Repository{
+					{URIs: []string{"http://download.proxmox.com/debian/pbs"}, Components: []string{"pbs-no-subscription"}, Enabled: true},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		handle    string
+		wantPath  string
+		wantIndex int
+		wantFound bool
+	}{
+		{
+			name:      "enabled entry preferred over disabled duplicate",
+			handle:    "no-subscription",
+			wantPath:  "/etc/apt/sources.list.d/pbs.list",
+			wantIndex: 0,
+			wantFound: true,
+		},
+		{
+			name:      "not configured",
+			handle:    "enterprise",
+			wantFound: false,
+		},
+		{
+			name:      "unknown handle",
+			handle:    "bogus",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, repo := repos.FindStandardRepository(tt.handle)
+			if !tt.wantFound {
+				if loc != nil || repo != nil {
+					t.Fatalf("expected no match, got %+v", loc)
+				}
+				return
+			}
+			if loc == nil || repo == nil {
+				t.Fatalf("expected a match for %s", tt.handle)
+			}
+			if loc.Path != tt.wantPath || loc.Index != tt.wantIndex {
+				t.Fatalf("got %s[%d], want %s[%d]", loc.Path, loc.Index, tt.wantPath, tt.wantIndex)
+			}
+		})
+	}
+}
@@ -0,0 +1,418 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package calendar implements the PBS (systemd-style) calendar event grammar
+// used by job and garbage collection schedules.
+package calendar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embedded zone database so schedules with time zones validate on hosts without tzdata
+	_ "time/tzdata"
+)
+
+// maxSearchYears bounds the search for the next trigger time of events that
+// only fire in specific (possibly past) years.
+const maxSearchYears = 200
+
+// shortcuts maps the named schedules accepted by PBS to their full form.
+var shortcuts = map[string]string{
+	"minutely":      "*-*-* *:*:00",
+	"hourly":        "*-*-* *:00:00",
+	"daily":         "*-*-* 00:00:00",
+	"weekly":        "mon *-*-* 00:00:00",
+	"monthly":       "*-*-01 00:00:00",
+	"yearly":        "*-01-01 00:00:00",
+	"annually":      "*-01-01 00:00:00",
+	"quarterly":     "*-01,04,07,10-01 00:00:00",
+	"semiannually":  "*-01,07-01 00:00:00",
+	"semi-annually": "*-01,07-01 00:00:00",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Event is a parsed calendar event such as "mon..fri *-*-* 02:00" or "*/15".
+type Event struct {
+	expr     string
+	weekdays [7]bool
+	anyDay   bool
+	year     field
+	month    field
+	day      field
+	hour     field
+	minute   field
+	second   field
+	location *time.Location
+}
+
+// field is the set of values accepted for one date or time component.
+type field struct {
+	min, max int
+	items    []item // empty means any value
+}
+
+// item is a single value, range or repetition within a field.
+type item struct {
+	start, end, step int
+}
+
+func (f field) matches(v int) bool {
+	if len(f.items) == 0 {
+		return true
+	}
+	for _, it := range f.items {
+		if v >= it.start && v <= it.end && (v-it.start)%it.step == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses a calendar event in the form
+//
+//	[WEEKDAYS] [[YEAR-]MONTH-DAY] [[HOUR:]MINUTE[:SECOND]] [TIMEZONE]
+//
+// or one of the named schedules (minutely, hourly, daily, weekly, monthly,
+// yearly, quarterly, semiannually). Components accept "*", lists ("1,15"),
+// ranges ("1..5") and repetitions ("*/15", "8/2", "0..30/10"). A bare minute
+// specification such as "*/15" applies to every hour. Omitted times default to
+// midnight and omitted dates to every day.
+func Parse(expr string) (*Event, error) {
+	e := &Event{
+		expr:   expr,
+		anyDay: true,
+		year:   field{min: 1970, max: 9999},
+		month:  field{min: 1, max: 12},
+		day:    field{min: 1, max: 31},
+		hour:   field{min: 0, max: 23},
+		minute: field{min: 0, max: 59},
+		second: field{min: 0, max: 59},
+	}
+
+	tokens := strings.Fields(expr)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty calendar event")
+	}
+
+	// A trailing time zone may follow any form, including named schedules
+	if len(tokens) > 1 {
+		last := tokens[len(tokens)-1]
+		if loc, ok := parseLocation(last); ok {
+			e.location = loc
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+
+	if len(tokens) == 1 {
+		if full, ok := shortcuts[strings.ToLower(tokens[0])]; ok {
+			tokens = strings.Fields(full)
+		}
+	}
+
+	hasTime := false
+	hasDate := false
+
+	if len(tokens) > 0 && isWeekdaySpec(tokens[0]) {
+		if err := e.parseWeekdays(tokens[0]); err != nil {
+			return nil, fmt.Errorf("invalid calendar event %q: %w", expr, err)
+		}
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) > 0 && strings.Contains(tokens[0], "-") {
+		if err := e.parseDate(tokens[0]); err != nil {
+			return nil, fmt.Errorf("invalid calendar event %q: %w", expr, err)
+		}
+		hasDate = true
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) > 0 {
+		if err := e.parseTime(tokens[0]); err != nil {
+			return nil, fmt.Errorf("invalid calendar event %q: %w", expr, err)
+		}
+		hasTime = true
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) > 0 {
+		return nil, fmt.Errorf("invalid calendar event %q: unexpected %q", expr, strings.Join(tokens, " "))
+	}
+
+	if e.anyDay && !hasDate && !hasTime {
+		return nil, fmt.Errorf("invalid calendar event %q: expected a weekday, date or time", expr)
+	}
+
+	if !hasTime {
+		e.hour.items = []item{{0, 0, 1}}
+		e.minute.items = []item{{0, 0, 1}}
+		e.second.items = []item{{0, 0, 1}}
+	}
+
+	return e, nil
+}
+
+// String returns the expression the event was parsed from.
+func (e *Event) String() string {
+	return e.expr
+}
+
+// Location returns the time zone given in the event, or nil if none was specified.
+func (e *Event) Location() *time.Location {
+	return e.location
+}
+
+func parseLocation(s string) (*time.Location, bool) {
+	if strings.EqualFold(s, "utc") {
+		return time.UTC, true
+	}
+	// Zone names always start with a letter and never contain calendar syntax
+	if s == "" || !isLetter(s[0]) || strings.ContainsAny(s, ":*,.") {
+		return nil, false
+	}
+	if _, isDay := weekdays[strings.ToLower(s)]; isDay {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isWeekdaySpec(token string) bool {
+	return token != "" && isLetter(token[0])
+}
+
+func (e *Event) parseWeekdays(spec string) error {
+	e.anyDay = false
+	for _, part := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(part, "..")
+
+		start, ok := weekdays[strings.ToLower(from)]
+		if !ok {
+			return fmt.Errorf("unknown weekday %q", from)
+		}
+		end := start
+		if isRange {
+			if end, ok = weekdays[strings.ToLower(to)]; !ok {
+				return fmt.Errorf("unknown weekday %q", to)
+			}
+		}
+
+		// Ranges may wrap around the end of the week, e.g. "sat..mon"
+		for day := start; ; day = (day + 1) % 7 {
+			e.weekdays[day] = true
+			if day == end {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Event) parseDate(spec string) error {
+	parts := strings.Split(spec, "-")
+
+	var err error
+	switch len(parts) {
+	case 3:
+		if e.year, err = parseField(parts[0], e.year, "year"); err != nil {
+			return err
+		}
+		parts = parts[1:]
+	case 2:
+	default:
+		return fmt.Errorf("invalid date %q: expected [YEAR-]MONTH-DAY", spec)
+	}
+
+	if e.month, err = parseField(parts[0], e.month, "month"); err != nil {
+		return err
+	}
+	if e.day, err = parseField(parts[1], e.day, "day"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (e *Event) parseTime(spec string) error {
+	parts := strings.Split(spec, ":")
+
+	var err error
+	switch len(parts) {
+	case 1:
+		// Minute only, every hour
+		e.second.items = []item{{0, 0, 1}}
+		e.minute, err = parseField(parts[0], e.minute, "minute")
+		return err
+	case 2, 3:
+		if e.hour, err = parseField(parts[0], e.hour, "hour"); err != nil {
+			return err
+		}
+		if e.minute, err = parseField(parts[1], e.minute, "minute"); err != nil {
+			return err
+		}
+		if len(parts) == 3 {
+			e.second, err = parseField(parts[2], e.second, "second")
+		} else {
+			e.second.items = []item{{0, 0, 1}}
+		}
+		return err
+	default:
+		return fmt.Errorf("invalid time %q: expected [HOUR:]MINUTE[:SECOND]", spec)
+	}
+}
+
+// parseField parses a comma-separated list of values, ranges and repetitions.
+func parseField(spec string, f field, name string) (field, error) {
+	if spec == "" {
+		return f, fmt.Errorf("empty %s", name)
+	}
+	if spec == "*" {
+		return f, nil
+	}
+
+	f.items = nil
+	for _, part := range strings.Split(spec, ",") {
+		it := item{step: 1}
+		value, stepStr, hasStep := strings.Cut(part, "/")
+
+		if value == "*" {
+			it.start, it.end = f.min, f.max
+		} else if from, to, isRange := strings.Cut(value, ".."); isRange {
+			var err error
+			if it.start, err = parseNumber(from, f, name); err != nil {
+				return f, err
+			}
+			if it.end, err = parseNumber(to, f, name); err != nil {
+				return f, err
+			}
+			if it.end < it.start {
+				return f, fmt.Errorf("invalid %s range %q", name, value)
+			}
+		} else {
+			n, err := parseNumber(value, f, name)
+			if err != nil {
+				return f, err
+			}
+			it.start, it.end = n, n
+			if hasStep {
+				// "start/step" repeats until the end of the component range
+				it.end = f.max
+			}
+		}
+
+		if hasStep {
+			step, err := strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return f, fmt.Errorf("invalid %s repetition %q", name, part)
+			}
+			it.step = step
+		}
+
+		f.items = append(f.items, it)
+	}
+
+	return f, nil
+}
+
+func parseNumber(s string, f field, name string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s %d out of range %d..%d", name, n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// Matches reports whether the event triggers at t (with second precision).
+// The time is converted to the event's time zone when one was given.
+func (e *Event) Matches(t time.Time) bool {
+	if e.location != nil {
+		t = t.In(e.location)
+	}
+	return e.dateMatches(t) && e.hour.matches(t.Hour()) && e.minute.matches(t.Minute()) && e.second.matches(t.Second())
+}
+
+func (e *Event) dateMatches(t time.Time) bool {
+	if !e.anyDay && !e.weekdays[t.Weekday()] {
+		return false
+	}
+	return e.year.matches(t.Year()) && e.month.matches(int(t.Month())) && e.day.matches(t.Day())
+}
+
+// Next returns the first trigger time strictly after the given time. Times are
+// computed in the event's time zone, or in the location of after when the event
+// has none. The second return value is false when the event never fires again.
+func (e *Event) Next(after time.Time) (time.Time, bool) {
+	loc := after.Location()
+	if e.location != nil {
+		loc = e.location
+	}
+
+	t := after.In(loc).Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + maxSearchYears
+
+	for t.Year() <= limit {
+		var next time.Time
+		switch {
+		case !e.year.matches(t.Year()):
+			next = time.Date(t.Year()+1, time.January, 1, 0, 0, 0, 0, loc)
+		case !e.month.matches(int(t.Month())):
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !e.dateMatches(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !e.hour.matches(t.Hour()):
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !e.minute.matches(t.Minute()):
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+		case !e.second.matches(t.Second()):
+			next = t.Add(time.Second)
+		default:
+			return t, true
+		}
+
+		// Wall clock arithmetic can step backwards across a DST change; always move forward
+		if !next.After(t) {
+			next = t.Add(time.Second)
+		}
+		t = next
+	}
+
+	return time.Time{}, false
+}
+
+// NextN returns up to n consecutive trigger times after the given time.
+func (e *Event) NextN(after time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for len(times) < n {
+		next, ok := e.Next(after)
+		if !ok {
+			break
+		}
+		times = append(times, next)
+		after = next
+	}
+	return times
+}
@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseValid(t *testing.T) {
+	valid := []string{
+		"daily",
+		"hourly",
+		"weekly",
+		"monthly",
+		"quarterly",
+		"semi-annually",
+		"*/15",
+		"*:00/15",
+		"0/5",
+		"mon..fri",
+		"Mon..Fri *-*-* 02:00:00",
+		"Mon,Wed,Fri 02:00",
+		"sat..mon 22:30",
+		"*-*-01 03:00",
+		"2025-01..06-15 12:00:00",
+		"*:0..30/10",
+		"daily UTC",
+		"mon 02:00 Europe/Berlin",
+	}
+	for _, expr := range valid {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", expr, err)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"dailly",
+		"funday 02:00",
+		"25:00",
+		"*:61",
+		"*-13-01",
+		"*/0",
+		"10..5:00",
+		"mon 02:00 extra",
+		"1-2-3-4",
+		"0 3 * * 0",
+		"02:00 Mars/Olympus",
+	}
+	for _, expr := range invalid {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
+
+func TestNextN(t *testing.T) {
+	// Wednesday 2025-01-15 10:07:30 UTC
+	from := time.Date(2025, 1, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"*/15", []string{"2025-01-15T10:15:00Z", "2025-01-15T10:30:00Z", "2025-01-15T10:45:00Z"}},
+		{"daily", []string{"2025-01-16T00:00:00Z", "2025-01-17T00:00:00Z", "2025-01-18T00:00:00Z"}},
+		{"hourly", []string{"2025-01-15T11:00:00Z", "2025-01-15T12:00:00Z", "2025-01-15T13:00:00Z"}},
+		{"mon..fri 02:00", []string{"2025-01-16T02:00:00Z", "2025-01-17T02:00:00Z", "2025-01-20T02:00:00Z"}},
+		{"sat,sun 8/6:00", []string{"2025-01-18T08:00:00Z", "2025-01-18T14:00:00Z", "2025-01-18T20:00:00Z"}},
+		{"monthly", []string{"2025-02-01T00:00:00Z", "2025-03-01T00:00:00Z", "2025-04-01T00:00:00Z"}},
+		{"*-02-29 00:00", []string{"2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z", "2036-02-29T00:00:00Z"}},
+		{"02:00 Europe/Berlin", []string{"2025-01-16T02:00:00+01:00", "2025-01-17T02:00:00+01:00", "2025-01-18T02:00:00+01:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := e.NextN(from, len(tt.want))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d times, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].Format(time.RFC3339) != tt.want[i] {
+					t.Errorf("run %d = %s, want %s", i, got[i].Format(time.RFC3339), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNextNeverFires(t *testing.T) {
+	e, err := Parse("2020-01-01 00:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := e.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Fatalf("expected past-only event to never fire")
+	}
+	if got := e.NextN(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), 3); len(got) != 1 {
+		t.Fatalf("expected exactly one run, got %d", len(got))
+	}
+}
+
+func TestMatches(t *testing.T) {
+	e, err := Parse("mon..fri 08..17:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !e.Matches(time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Wednesday 09:00 to match")
+	}
+	if e.Matches(time.Date(2025, 1, 18, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Saturday 09:00 not to match")
+	}
+	if e.Matches(time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected 09:30 not to match")
+	}
+}
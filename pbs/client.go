@@ -9,23 +9,32 @@ package pbs
 
 import (
 	"github.com/micah/terraform-provider-pbs/pbs/api"
+	"github.com/micah/terraform-provider-pbs/pbs/apt"
 	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+	"github.com/micah/terraform-provider-pbs/pbs/disks"
 	"github.com/micah/terraform-provider-pbs/pbs/endpoints"
 	"github.com/micah/terraform-provider-pbs/pbs/jobs"
 	"github.com/micah/terraform-provider-pbs/pbs/metrics"
+	"github.com/micah/terraform-provider-pbs/pbs/nodes"
 	"github.com/micah/terraform-provider-pbs/pbs/notifications"
 	"github.com/micah/terraform-provider-pbs/pbs/remotes"
+	"github.com/micah/terraform-provider-pbs/pbs/subscription"
 )
 
 // Client represents the main PBS client interface
 type Client struct {
 	api           *api.Client
+	locks         *LockManager
 	Endpoints     *endpoints.Client
 	Datastores    *datastores.Client
 	Metrics       *metrics.Client
 	Notifications *notifications.Client
 	Jobs          *jobs.Client
 	Remotes       *remotes.Client
+	Disks         *disks.Client
+	APT           *apt.Client
+	Subscription  *subscription.Client
+	Nodes         *nodes.Client
 }
 
 // NewClient creates a new PBS client
@@ -37,11 +46,16 @@ func NewClient(creds api.Credentials, opts api.ClientOptions) (*Client, error) {
 
 	return &Client{
 		api:           apiClient,
+		locks:         sharedLocks,
 		Endpoints:     endpoints.NewClient(apiClient),
 		Datastores:    datastores.NewClient(apiClient),
 		Metrics:       metrics.NewClient(apiClient),
 		Notifications: notifications.NewClient(apiClient),
 		Jobs:          jobs.NewClient(apiClient),
 		Remotes:       remotes.NewClient(apiClient),
+		Disks:         disks.NewClient(apiClient),
+		APT:           apt.NewClient(apiClient),
+		Subscription:  subscription.NewClient(apiClient),
+		Nodes:         nodes.NewClient(apiClient),
 	}, nil
 }
@@ -0,0 +1,120 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package datastores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MaxNamespaceDepth is the deepest namespace nesting PBS allows below the root
+const MaxNamespaceDepth = 7
+
+var namespaceComponentRegexp = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+// BackupGroup is a backup group in a datastore namespace
+type BackupGroup struct {
+	BackupType  string `json:"backup-type"`
+	BackupID    string `json:"backup-id"`
+	BackupCount int    `json:"backup-count,omitempty"`
+	LastBackup  int64  `json:"last-backup,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// Namespace is a namespace of a datastore; the root namespace has an empty name
+type Namespace struct {
+	Namespace string `json:"ns"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// NamespaceDepth returns the nesting depth of a namespace such as "a/b" (2); the root is 0
+func NamespaceDepth(ns string) int {
+	ns = strings.Trim(ns, "/")
+	if ns == "" {
+		return 0
+	}
+	return strings.Count(ns, "/") + 1
+}
+
+// ListGroups lists the backup groups of a datastore namespace; an empty
+// namespace lists the root namespace.
+func (c *Client) ListGroups(ctx context.Context, store, namespace string) ([]BackupGroup, error) {
+	if store == "" {
+		return nil, fmt.Errorf("datastore name is required")
+	}
+
+	path := fmt.Sprintf("/admin/datastore/%s/groups", url.PathEscape(store))
+	if namespace != "" {
+		path = fmt.Sprintf("%s?ns=%s", path, url.QueryEscape(namespace))
+	}
+
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup groups of datastore %s: %w", store, err)
+	}
+
+	var groups []BackupGroup
+	if err := json.Unmarshal(resp.Data, &groups); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal backup groups of datastore %s: %w", store, err)
+	}
+
+	return groups, nil
+}
+
+// ListNamespaces lists parent and the namespaces below it, up to maxDepth levels
+// deep when maxDepth is not nil.
+func (c *Client) ListNamespaces(ctx context.Context, store, parent string, maxDepth *int) ([]Namespace, error) {
+	if store == "" {
+		return nil, fmt.Errorf("datastore name is required")
+	}
+
+	query := url.Values{}
+	if parent != "" {
+		query.Set("parent", parent)
+	}
+	if maxDepth != nil {
+		query.Set("max-depth", fmt.Sprintf("%d", *maxDepth))
+	}
+
+	path := fmt.Sprintf("/admin/datastore/%s/namespace", url.PathEscape(store))
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces of datastore %s: %w", store, err)
+	}
+
+	var namespaces []Namespace
+	if err := json.Unmarshal(resp.Data, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal namespaces of datastore %s: %w", store, err)
+	}
+
+	return namespaces, nil
+}
+
+// ValidateNamespace checks that ns is a namespace path PBS accepts, such as "a/b"
+func ValidateNamespace(ns string) error {
+	if ns == "" {
+		return nil
+	}
+	for _, part := range strings.Split(ns, "/") {
+		if !namespaceComponentRegexp.MatchString(part) {
+			return fmt.Errorf("invalid namespace %q: component %q must start with a letter, digit or underscore and only contain letters, digits, '.', '_' and '-'", ns, part)
+		}
+	}
+	if depth := NamespaceDepth(ns); depth > MaxNamespaceDepth {
+		return fmt.Errorf("namespace %q is %d levels deep, PBS allows at most %d", ns, depth, MaxNamespaceDepth)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package datastores
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/micah/terraform-provider-pbs/pbs/api"
+)
+
+func TestDeleteDatastoreWithOptionsParameters(t *testing.T) {
+	var gotMethod, gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotQuery = r.Method, r.URL.Path, r.URL.RawQuery
+		// Fail the request so the test does not wait on a deletion task
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"data":null,"message":"stop"}`))
+	}))
+	defer srv.Close()
+
+	apiClient, err := api.NewClient(api.Credentials{APIToken: "root@pam!test:secret"}, api.ClientOptions{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client := NewClient(apiClient)
+
+	tests := map[string]struct {
+		destroyData bool
+		wantQuery   string
+	}{
+		"keep data":    {destroyData: false, wantQuery: ""},
+		"destroy data": {destroyData: true, wantQuery: "destroy-data=1"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_ = client.DeleteDatastoreWithOptions(context.Background(), "backups", tc.destroyData)
+			if gotMethod != http.MethodDelete || gotPath != "/api2/json/config/datastore/backups" {
+				t.Errorf("request = %s %s, want DELETE /api2/json/config/datastore/backups", gotMethod, gotPath)
+			}
+			if gotQuery != tc.wantQuery {
+				t.Errorf("query = %q, want %q", gotQuery, tc.wantQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package datastores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// VerifyOptions selects the snapshots verified by StartVerify
+type VerifyOptions struct {
+	IgnoreVerified *bool
+	OutdatedAfter  *int
+	Namespace      string
+	MaxDepth       *int
+}
+
+// DatastorePruneOptions selects the snapshots pruned by StartPrune
+type DatastorePruneOptions struct {
+	PruneOptions
+	Namespace string
+	MaxDepth  *int
+	DryRun    bool
+}
+
+// StartGarbageCollection starts garbage collection on a datastore and returns the UPID of the task
+func (c *Client) StartGarbageCollection(ctx context.Context, store string) (string, error) {
+	return c.startTask(ctx, store, "gc", "garbage collection", map[string]interface{}{})
+}
+
+// StartVerify starts verification of a datastore and returns the UPID of the task
+func (c *Client) StartVerify(ctx context.Context, store string, opts VerifyOptions) (string, error) {
+	body := map[string]interface{}{}
+	if opts.IgnoreVerified != nil {
+		body["ignore-verified"] = *opts.IgnoreVerified
+	}
+	if opts.OutdatedAfter != nil {
+		body["outdated-after"] = *opts.OutdatedAfter
+	}
+	if opts.Namespace != "" {
+		body["ns"] = opts.Namespace
+	}
+	if opts.MaxDepth != nil {
+		body["max-depth"] = *opts.MaxDepth
+	}
+
+	return c.startTask(ctx, store, "verify", "verification", body)
+}
+
+// StartPrune prunes all backup groups of a datastore namespace and returns the
+// UPID of the task. The /prune endpoint only prunes a single group, so this
+// uses /prune-datastore, which is what prune jobs run.
+func (c *Client) StartPrune(ctx context.Context, store string, opts DatastorePruneOptions) (string, error) {
+	body := map[string]interface{}{}
+	setInt := func(key string, value *int) {
+		if value != nil {
+			body[key] = *value
+		}
+	}
+	setInt("keep-last", opts.KeepLast)
+	setInt("keep-hourly", opts.KeepHourly)
+	setInt("keep-daily", opts.KeepDaily)
+	setInt("keep-weekly", opts.KeepWeekly)
+	setInt("keep-monthly", opts.KeepMonthly)
+	setInt("keep-yearly", opts.KeepYearly)
+	setInt("max-depth", opts.MaxDepth)
+	if opts.Namespace != "" {
+		body["ns"] = opts.Namespace
+	}
+	if opts.DryRun {
+		body["dry-run"] = true
+	}
+
+	return c.startTask(ctx, store, "prune-datastore", "prune", body)
+}
+
+// WaitForTask waits for a task started on a datastore. When the task fails the
+// tail of its task log is appended to the error.
+func (c *Client) WaitForTask(ctx context.Context, upid string, timeout time.Duration) error {
+	return c.api.WaitForTaskWithLog(ctx, upid, timeout)
+}
+
+func (c *Client) startTask(ctx context.Context, store, endpoint, description string, body map[string]interface{}) (string, error) {
+	path := fmt.Sprintf("/admin/datastore/%s/%s", url.PathEscape(store), endpoint)
+	resp, err := c.api.Post(ctx, path, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to start %s of datastore %s: %w", description, store, err)
+	}
+
+	var upid string
+	if err := json.Unmarshal(resp.Data, &upid); err != nil {
+		return "", fmt.Errorf("failed to unmarshal %s task of datastore %s: %w", description, store, err)
+	}
+
+	return upid, nil
+}
@@ -0,0 +1,187 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package datastores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Reasons reported by SimulatePrune for each snapshot
+const (
+	PruneReasonKeepAll     = "keep-all"
+	PruneReasonKeepLast    = "keep-last"
+	PruneReasonKeepHourly  = "keep-hourly"
+	PruneReasonKeepDaily   = "keep-daily"
+	PruneReasonKeepWeekly  = "keep-weekly"
+	PruneReasonKeepMonthly = "keep-monthly"
+	PruneReasonKeepYearly  = "keep-yearly"
+	PruneReasonProtected   = "protected"
+	PruneReasonRemove      = "remove"
+)
+
+// PruneOptions holds the retention settings shared by datastores and prune jobs
+type PruneOptions struct {
+	KeepLast    *int
+	KeepHourly  *int
+	KeepDaily   *int
+	KeepWeekly  *int
+	KeepMonthly *int
+	KeepYearly  *int
+}
+
+// keepsAll reports whether no retention option is set, in which case PBS keeps every snapshot.
+func (o PruneOptions) keepsAll() bool {
+	for _, v := range []*int{o.KeepLast, o.KeepHourly, o.KeepDaily, o.KeepWeekly, o.KeepMonthly, o.KeepYearly} {
+		if v != nil && *v > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PruneEntry is a snapshot decision returned by a prune dry run
+type PruneEntry struct {
+	BackupType string `json:"backup-type"`
+	BackupID   string `json:"backup-id"`
+	BackupTime int64  `json:"backup-time"`
+	Keep       bool   `json:"keep"`
+	Protected  bool   `json:"protected,omitempty"`
+}
+
+// PruneDecision is the result of the local prune selection for one snapshot
+type PruneDecision struct {
+	Time   time.Time
+	Keep   bool
+	Reason string
+}
+
+// PruneGroupDryRun asks PBS which snapshots of a backup group the options would
+// keep or remove, without removing anything.
+func (c *Client) PruneGroupDryRun(ctx context.Context, store, namespace, backupType, backupID string, opts PruneOptions) ([]PruneEntry, error) {
+	body := map[string]interface{}{
+		"backup-type": backupType,
+		"backup-id":   backupID,
+		"dry-run":     true,
+	}
+	if namespace != "" {
+		body["ns"] = namespace
+	}
+
+	setInt := func(key string, value *int) {
+		if value != nil {
+			body[key] = *value
+		}
+	}
+	setInt("keep-last", opts.KeepLast)
+	setInt("keep-hourly", opts.KeepHourly)
+	setInt("keep-daily", opts.KeepDaily)
+	setInt("keep-weekly", opts.KeepWeekly)
+	setInt("keep-monthly", opts.KeepMonthly)
+	setInt("keep-yearly", opts.KeepYearly)
+
+	path := fmt.Sprintf("/admin/datastore/%s/prune", url.PathEscape(store))
+	resp, err := c.api.Post(ctx, path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate prune of %s/%s on datastore %s: %w", backupType, backupID, store, err)
+	}
+
+	var entries []PruneEntry
+	if err := json.Unmarshal(resp.Data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prune simulation for datastore %s: %w", store, err)
+	}
+
+	return entries, nil
+}
+
+// SimulatePrune runs the PBS prune selection locally. Snapshots are processed
+// newest first; each keep-* option keeps the newest snapshot of each of the most
+// recent N periods (hour, day, ISO week, month, year) that are not already covered
+// by a snapshot kept by an earlier option. Periods are evaluated in loc.
+// Decisions are returned newest first.
+func SimulatePrune(times []time.Time, opts PruneOptions, loc *time.Location) []PruneDecision {
+	sorted := make([]time.Time, len(times))
+	copy(sorted, times)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].After(sorted[j]) })
+
+	decisions := make([]PruneDecision, len(sorted))
+	for i, t := range sorted {
+		decisions[i] = PruneDecision{Time: t, Reason: PruneReasonRemove}
+	}
+
+	if opts.keepsAll() {
+		for i := range decisions {
+			decisions[i].Keep = true
+			decisions[i].Reason = PruneReasonKeepAll
+		}
+		return decisions
+	}
+
+	marked := make([]bool, len(sorted))
+
+	markSelections := func(keep *int, reason string, selectID func(t time.Time) string) {
+		if keep == nil || *keep <= 0 {
+			return
+		}
+
+		// Periods already covered by snapshots kept through an earlier option
+		alreadyIncluded := make(map[string]bool)
+		for i, t := range sorted {
+			if decisions[i].Keep {
+				alreadyIncluded[selectID(t)] = true
+			}
+		}
+
+		included := make(map[string]bool)
+		for i, t := range sorted {
+			if marked[i] {
+				continue
+			}
+			id := selectID(t)
+			if alreadyIncluded[id] {
+				continue
+			}
+			if included[id] {
+				marked[i] = true
+				continue
+			}
+			if len(included) >= *keep {
+				break
+			}
+			included[id] = true
+			marked[i] = true
+			decisions[i].Keep = true
+			decisions[i].Reason = reason
+		}
+	}
+
+	markSelections(opts.KeepLast, PruneReasonKeepLast, func(t time.Time) string {
+		return t.Format(time.RFC3339Nano)
+	})
+	markSelections(opts.KeepHourly, PruneReasonKeepHourly, func(t time.Time) string {
+		return t.In(loc).Format("2006/01/02/15")
+	})
+	markSelections(opts.KeepDaily, PruneReasonKeepDaily, func(t time.Time) string {
+		return t.In(loc).Format("2006/01/02")
+	})
+	markSelections(opts.KeepWeekly, PruneReasonKeepWeekly, func(t time.Time) string {
+		year, week := t.In(loc).ISOWeek()
+		return fmt.Sprintf("%d/%02d", year, week)
+	})
+	markSelections(opts.KeepMonthly, PruneReasonKeepMonthly, func(t time.Time) string {
+		return t.In(loc).Format("2006/01")
+	})
+	markSelections(opts.KeepYearly, PruneReasonKeepYearly, func(t time.Time) string {
+		return t.In(loc).Format("2006")
+	})
+
+	return decisions
+}
@@ -0,0 +1,90 @@
+package datastores
+
+import (
+	"testing"
+	"time"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestSimulatePruneKeepsAllWithoutOptions(t *testing.T) {
+	times := []time.Time{
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, d := range SimulatePrune(times, PruneOptions{}, time.UTC) {
+		if !d.Keep || d.Reason != PruneReasonKeepAll {
+			t.Fatalf("expected %s to be kept by keep-all, got %+v", d.Time, d)
+		}
+	}
+}
+
+func TestSimulatePrune(t *testing.T) {
+	// Two snapshots per day for ten days, at 01:00 and 13:00 UTC
+	var times []time.Time
+	for day := 1; day <= 10; day++ {
+		times = append(times,
+			time.Date(2025, 1, day, 1, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, day, 13, 0, 0, 0, time.UTC),
+		)
+	}
+
+	decisions := SimulatePrune(times, PruneOptions{KeepLast: intPtr(3), KeepDaily: intPtr(3), KeepWeekly: intPtr(2)}, time.UTC)
+	if len(decisions) != len(times) {
+		t.Fatalf("got %d decisions, want %d", len(decisions), len(times))
+	}
+
+	kept := map[string]string{}
+	for _, d := range decisions {
+		if d.Keep {
+			kept[d.Time.Format(time.RFC3339)] = d.Reason
+		}
+	}
+
+	want := map[string]string{
+		// keep-last: the three newest snapshots
+		"2025-01-10T13:00:00Z": PruneReasonKeepLast,
+		"2025-01-10T01:00:00Z": PruneReasonKeepLast,
+		"2025-01-09T13:00:00Z": PruneReasonKeepLast,
+		// keep-daily: the newest snapshot of the next three days not yet covered
+		"2025-01-08T13:00:00Z": PruneReasonKeepDaily,
+		"2025-01-07T13:00:00Z": PruneReasonKeepDaily,
+		"2025-01-06T13:00:00Z": PruneReasonKeepDaily,
+		// keep-weekly: 2025-01-06..10 is ISO week 2 and already covered, leaving only week 1
+		"2025-01-05T13:00:00Z": PruneReasonKeepWeekly,
+	}
+
+	if len(kept) != len(want) {
+		t.Fatalf("kept %v, want %v", kept, want)
+	}
+	for ts, reason := range want {
+		if kept[ts] != reason {
+			t.Errorf("%s kept by %q, want %q", ts, kept[ts], reason)
+		}
+	}
+
+	// Decisions are ordered newest first
+	if !decisions[0].Time.Equal(time.Date(2025, 1, 10, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("first decision is %s, want newest snapshot", decisions[0].Time)
+	}
+}
+
+func TestSimulatePruneUsesLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	// 23:00 UTC on Jan 1 is already Jan 2 in UTC+2, the same day as the newer snapshot
+	times := []time.Time{
+		time.Date(2025, 1, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+	}
+
+	utc := SimulatePrune(times, PruneOptions{KeepDaily: intPtr(2)}, time.UTC)
+	if !utc[0].Keep || !utc[1].Keep {
+		t.Errorf("expected both snapshots kept in UTC, got %+v", utc)
+	}
+
+	shifted := SimulatePrune(times, PruneOptions{KeepDaily: intPtr(2)}, loc)
+	if !shifted[0].Keep || shifted[1].Keep {
+		t.Errorf("expected only the newer snapshot kept in UTC+2, got %+v", shifted)
+	}
+}
@@ -0,0 +1,444 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package disks provides API client functionality for PBS node disk management
+package disks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/micah/terraform-provider-pbs/pbs/api"
+)
+
+// diskTaskTimeout bounds how long disk tasks (mkfs, zpool create, GPT init) may run
+const diskTaskTimeout = 10 * time.Minute
+
+// Client represents the disks API client
+type Client struct {
+	api *api.Client
+}
+
+// NewClient creates a new disks API client
+func NewClient(apiClient *api.Client) *Client {
+	return &Client{api: apiClient}
+}
+
+// Disk represents a block device reported by /nodes/{node}/disks/list
+type Disk struct {
+	Name        string   `json:"name"`
+	Used        string   `json:"used"` // unused, partitions, lvm, zfs, mounted, filesystem, device-mapper
+	DiskType    string   `json:"disk-type,omitempty"`
+	Vendor      string   `json:"vendor,omitempty"`
+	Model       string   `json:"model,omitempty"`
+	WWN         string   `json:"wwn,omitempty"`
+	Size        int64    `json:"size"`
+	Serial      string   `json:"serial,omitempty"`
+	DevPath     string   `json:"devpath,omitempty"`
+	GPT         bool     `json:"gpt,omitempty"`
+	RPM         *int64   `json:"rpm,omitempty"`
+	Wearout     *float64 `json:"wearout,omitempty"`
+	Status      string   `json:"status,omitempty"` // SMART status: passed, failed, unknown
+	IsPartition bool     `json:"is-partition,omitempty"`
+}
+
+// ListDisksOptions holds the optional query parameters for ListDisks
+type ListDisksOptions struct {
+	IncludePartitions bool
+	SkipSmart         bool
+	UsageType         string
+}
+
+// ZFSPool represents a ZFS pool as reported by /nodes/{node}/disks/zfs
+type ZFSPool struct {
+	Name   string  `json:"name"`
+	Size   int64   `json:"size"`
+	Alloc  int64   `json:"alloc"`
+	Free   int64   `json:"free"`
+	Frag   int64   `json:"frag"`
+	Dedup  float64 `json:"dedup"`
+	Health string  `json:"health,omitempty"`
+}
+
+// ZFSPoolDetails is the status of a single ZFS pool from /nodes/{node}/disks/zfs/{name}
+type ZFSPoolDetails struct {
+	Name     string    `json:"name"`
+	State    string    `json:"state"`
+	Children []ZFSVdev `json:"children,omitempty"`
+}
+
+// ZFSVdev is a node of the vdev tree reported in the ZFS pool status
+type ZFSVdev struct {
+	Name     string    `json:"name"`
+	Leaf     bool      `json:"leaf,omitempty"`
+	Children []ZFSVdev `json:"children,omitempty"`
+}
+
+// zfsAuxiliaryVdevs are vdev groups that do not hold pool data
+var zfsAuxiliaryVdevs = map[string]bool{"logs": true, "cache": true, "spares": true, "special": true, "dedup": true}
+
+// Layout derives the RAID level and data disks of the pool from its vdev
+// tree, using the raid level names accepted by CreateZFSPool.
+func (d *ZFSPoolDetails) Layout() (string, []string) {
+	vdevs := d.Children
+	// The status tree may start with the pool itself
+	if len(vdevs) == 1 && vdevs[0].Name == d.Name && len(vdevs[0].Children) > 0 {
+		vdevs = vdevs[0].Children
+	}
+
+	var devices []string
+	groups := map[string]int{}
+	for _, vdev := range vdevs {
+		if zfsAuxiliaryVdevs[vdev.Name] {
+			continue
+		}
+		if vdev.Leaf || len(vdev.Children) == 0 {
+			groups["single"]++
+			devices = append(devices, vdev.Name)
+			continue
+		}
+		kind, _, _ := strings.Cut(vdev.Name, "-")
+		if kind == "raidz1" {
+			kind = "raidz"
+		}
+		groups[kind]++
+		for _, child := range vdev.Children {
+			devices = append(devices, child.Name)
+		}
+	}
+
+	switch {
+	case groups["mirror"] > 1:
+		return "raid10", devices
+	case groups["mirror"] == 1:
+		return "mirror", devices
+	case groups["raidz"] > 0:
+		return "raidz", devices
+	case groups["raidz2"] > 0:
+		return "raidz2", devices
+	case groups["raidz3"] > 0:
+		return "raidz3", devices
+	default:
+		return "single", devices
+	}
+}
+
+// ZFSPoolCreate holds the parameters used to create a ZFS pool
+type ZFSPoolCreate struct {
+	Name         string
+	Devices      []string
+	RaidLevel    string // single, mirror, raid10, raidz, raidz2, raidz3
+	Ashift       *int
+	Compression  string // gzip, lz4, lzjb, zle, zstd, on, off
+	AddDatastore *bool
+}
+
+// Directory represents a mounted datastore filesystem from /nodes/{node}/disks/directory
+type Directory struct {
+	Name       string `json:"name"`
+	Device     string `json:"device"`
+	Filesystem string `json:"filesystem,omitempty"`
+	Path       string `json:"path"`
+	UnitFile   string `json:"unitfile,omitempty"`
+	Options    string `json:"options,omitempty"`
+	Removable  bool   `json:"removable,omitempty"`
+}
+
+// DirectoryCreate holds the parameters used to create a directory-backed filesystem
+type DirectoryCreate struct {
+	Name         string
+	Disk         string
+	Filesystem   string // ext4, xfs
+	AddDatastore *bool
+}
+
+// ListDisks lists the block devices on a node
+func (c *Client) ListDisks(ctx context.Context, node string, opts ListDisksOptions) ([]Disk, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	query := url.Values{}
+	if opts.IncludePartitions {
+		query.Set("include-partitions", "1")
+	}
+	if opts.SkipSmart {
+		query.Set("skipsmart", "1")
+	}
+	if opts.UsageType != "" {
+		query.Set("usage-type", opts.UsageType)
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/list", url.PathEscape(node))
+	if len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
+	}
+
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list disks on node %s: %w", node, err)
+	}
+
+	var disks []Disk
+	if err := json.Unmarshal(resp.Data, &disks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal disks on node %s: %w", node, err)
+	}
+
+	return disks, nil
+}
+
+// InitializeGPT writes a new GPT partition table to an unused disk and waits for the task
+func (c *Client) InitializeGPT(ctx context.Context, node, disk, uuid string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+	if disk == "" {
+		return fmt.Errorf("disk is required")
+	}
+
+	body := map[string]interface{}{
+		"disk": disk,
+	}
+	if uuid != "" {
+		body["uuid"] = uuid
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/initgpt", url.PathEscape(node))
+	resp, err := c.api.Post(ctx, path, body)
+	if err != nil {
+		return fmt.Errorf("failed to initialize GPT on disk %s: %w", disk, err)
+	}
+
+	return c.waitForUPID(ctx, node, resp, fmt.Sprintf("GPT initialization of disk %s", disk))
+}
+
+// WipeDisk removes all partition tables and filesystem signatures from a disk and waits for the task
+func (c *Client) WipeDisk(ctx context.Context, node, disk string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+	if disk == "" {
+		return fmt.Errorf("disk is required")
+	}
+
+	body := map[string]interface{}{
+		"disk": disk,
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/wipedisk", url.PathEscape(node))
+	resp, err := c.api.Put(ctx, path, body)
+	if err != nil {
+		return fmt.Errorf("failed to wipe disk %s: %w", disk, err)
+	}
+
+	return c.waitForUPID(ctx, node, resp, fmt.Sprintf("wipe of disk %s", disk))
+}
+
+// ListZFSPools lists the ZFS pools on a node
+func (c *Client) ListZFSPools(ctx context.Context, node string) ([]ZFSPool, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/zfs", url.PathEscape(node))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ZFS pools on node %s: %w", node, err)
+	}
+
+	var pools []ZFSPool
+	if err := json.Unmarshal(resp.Data, &pools); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ZFS pools on node %s: %w", node, err)
+	}
+
+	return pools, nil
+}
+
+// GetZFSPool looks up a single ZFS pool by name, returning nil if it does not exist
+func (c *Client) GetZFSPool(ctx context.Context, node, name string) (*ZFSPool, error) {
+	pools, err := c.ListZFSPools(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pools {
+		if pools[i].Name == name {
+			return &pools[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetZFSPoolDetails returns the status and vdev layout of a ZFS pool
+func (c *Client) GetZFSPoolDetails(ctx context.Context, node, name string) (*ZFSPoolDetails, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/zfs/%s", url.PathEscape(node), url.PathEscape(name))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ZFS pool %s on node %s: %w", name, node, err)
+	}
+
+	var details ZFSPoolDetails
+	if err := json.Unmarshal(resp.Data, &details); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ZFS pool %s on node %s: %w", name, node, err)
+	}
+
+	return &details, nil
+}
+
+// CreateZFSPool creates a ZFS pool and waits for the creation task
+func (c *Client) CreateZFSPool(ctx context.Context, node string, pool *ZFSPoolCreate) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+	if pool.Name == "" {
+		return fmt.Errorf("pool name is required")
+	}
+	if len(pool.Devices) == 0 {
+		return fmt.Errorf("at least one device is required")
+	}
+	if pool.RaidLevel == "" {
+		return fmt.Errorf("raid level is required")
+	}
+
+	body := map[string]interface{}{
+		"name":      pool.Name,
+		"devices":   strings.Join(pool.Devices, ","),
+		"raidlevel": pool.RaidLevel,
+	}
+
+	if pool.Ashift != nil {
+		body["ashift"] = *pool.Ashift
+	}
+	if pool.Compression != "" {
+		body["compression"] = pool.Compression
+	}
+	if pool.AddDatastore != nil {
+		body["add-datastore"] = *pool.AddDatastore
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/zfs", url.PathEscape(node))
+	resp, err := c.api.Post(ctx, path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create ZFS pool %s: %w", pool.Name, err)
+	}
+
+	return c.waitForUPID(ctx, node, resp, fmt.Sprintf("ZFS pool %s creation", pool.Name))
+}
+
+// ListDirectories lists the datastore filesystems mounted under /mnt/datastore on a node
+func (c *Client) ListDirectories(ctx context.Context, node string) ([]Directory, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/directory", url.PathEscape(node))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directories on node %s: %w", node, err)
+	}
+
+	var dirs []Directory
+	if err := json.Unmarshal(resp.Data, &dirs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal directories on node %s: %w", node, err)
+	}
+
+	return dirs, nil
+}
+
+// GetDirectory looks up a single directory filesystem by name, returning nil if it does not exist
+func (c *Client) GetDirectory(ctx context.Context, node, name string) (*Directory, error) {
+	dirs, err := c.ListDirectories(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range dirs {
+		if dirs[i].Name == name {
+			return &dirs[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// CreateDirectory formats a disk, mounts it under /mnt/datastore/{name} and waits for the task
+func (c *Client) CreateDirectory(ctx context.Context, node string, dir *DirectoryCreate) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+	if dir.Name == "" {
+		return fmt.Errorf("directory name is required")
+	}
+	if dir.Disk == "" {
+		return fmt.Errorf("disk is required")
+	}
+
+	body := map[string]interface{}{
+		"name": dir.Name,
+		"disk": dir.Disk,
+	}
+
+	if dir.Filesystem != "" {
+		body["filesystem"] = dir.Filesystem
+	}
+	if dir.AddDatastore != nil {
+		body["add-datastore"] = *dir.AddDatastore
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/directory", url.PathEscape(node))
+	resp, err := c.api.Post(ctx, path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir.Name, err)
+	}
+
+	return c.waitForUPID(ctx, node, resp, fmt.Sprintf("directory %s creation", dir.Name))
+}
+
+// DeleteDirectory removes the mount unit for a directory filesystem
+func (c *Client) DeleteDirectory(ctx context.Context, node, name string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+	if name == "" {
+		return fmt.Errorf("directory name is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/disks/directory/%s", url.PathEscape(node), url.PathEscape(name))
+	resp, err := c.api.Delete(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to delete directory %s: %w", name, err)
+	}
+
+	// Older PBS releases remove the mount synchronously and return no UPID
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return nil
+	}
+
+	return c.waitForUPID(ctx, node, resp, fmt.Sprintf("directory %s removal", name))
+}
+
+// waitForUPID parses the UPID returned by a disk API call and waits for the task to finish
+func (c *Client) waitForUPID(ctx context.Context, node string, resp *api.APIResponse, description string) error {
+	var upid string
+	if err := json.Unmarshal(resp.Data, &upid); err != nil {
+		return fmt.Errorf("failed to parse UPID from %s response: %w", description, err)
+	}
+
+	if err := c.api.WaitForTask(ctx, node, upid, diskTaskTimeout); err != nil {
+		return fmt.Errorf("%s task failed (UPID: %s): %w", description, upid, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package disks
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestZFSPoolDetailsLayout(t *testing.T) {
+	tests := map[string]struct {
+		data      string
+		raidLevel string
+		devices   []string
+	}{
+		"single": {
+			data:      `{"name":"tank","children":[{"name":"sdb","leaf":true}]}`,
+			raidLevel: "single",
+			devices:   []string{"sdb"},
+		},
+		"mirror under pool root": {
+			data:      `{"name":"tank","children":[{"name":"tank","children":[{"name":"mirror-0","children":[{"name":"sdb","leaf":true},{"name":"sdc","leaf":true}]}]}]}`,
+			raidLevel: "mirror",
+			devices:   []string{"sdb", "sdc"},
+		},
+		"raid10 with cache": {
+			data: `{"name":"tank","children":[
+				{"name":"mirror-0","children":[{"name":"sdb","leaf":true},{"name":"sdc","leaf":true}]},
+				{"name":"mirror-1","children":[{"name":"sdd","leaf":true},{"name":"sde","leaf":true}]},
+				{"name":"cache","children":[{"name":"nvme0n1","leaf":true}]}]}`,
+			raidLevel: "raid10",
+			devices:   []string{"sdb", "sdc", "sdd", "sde"},
+		},
+		"raidz2": {
+			data:      `{"name":"tank","children":[{"name":"raidz2-0","children":[{"name":"sdb","leaf":true},{"name":"sdc","leaf":true},{"name":"sdd","leaf":true},{"name":"sde","leaf":true}]}]}`,
+			raidLevel: "raidz2",
+			devices:   []string{"sdb", "sdc", "sdd", "sde"},
+		},
+		"raidz1": {
+			data:      `{"name":"tank","children":[{"name":"raidz1-0","children":[{"name":"sdb","leaf":true},{"name":"sdc","leaf":true},{"name":"sdd","leaf":true}]}]}`,
+			raidLevel: "raidz",
+			devices:   []string{"sdb", "sdc", "sdd"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var details ZFSPoolDetails
+			if err := json.Unmarshal([]byte(tc.data), &details); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			raidLevel, devices := details.Layout()
+			if raidLevel != tc.raidLevel || !reflect.DeepEqual(devices, tc.devices) {
+				t.Errorf("Layout() = %q, %v, want %q, %v", raidLevel, devices, tc.raidLevel, tc.devices)
+			}
+		})
+	}
+}
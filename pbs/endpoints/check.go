@@ -0,0 +1,127 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// QuirkSkipIfNoneMatchHeader disables conditional PUT requests for providers
+// that do not implement the If-None-Match header (e.g. Backblaze B2).
+const QuirkSkipIfNoneMatchHeader = "skip-if-none-match-header"
+
+// DefaultCheckStorePrefix is used by CheckS3Endpoint when no prefix is given,
+// keeping the test object away from datastore contents.
+const DefaultCheckStorePrefix = "terraform-provider-pbs-check"
+
+// Categories reported by DiagnoseS3Error
+const (
+	S3ProblemCredentials    = "credentials"
+	S3ProblemBucket         = "bucket"
+	S3ProblemConditionalPut = "conditional-put"
+	S3ProblemAddressing     = "addressing"
+	S3ProblemCertificate    = "certificate"
+)
+
+// S3Diagnosis explains an S3 failure in terms of the endpoint settings that fix it
+type S3Diagnosis struct {
+	Problem string
+	// Quirks lists provider_quirks that need to be enabled
+	Quirks []string
+	// PathStyle is set when path_style needs to be changed
+	PathStyle *bool
+	Hint      string
+}
+
+// IsProviderQuirk reports whether the failure is a known incompatibility of the
+// object store that provider_quirks work around, rather than a configuration error.
+func (d *S3Diagnosis) IsProviderQuirk() bool {
+	return d != nil && len(d.Quirks) > 0
+}
+
+// CheckS3Endpoint asks PBS to run its sanity check against a bucket using the
+// endpoint's settings. PBS uploads, reads and deletes a small test object below
+// storePrefix, which exercises credentials, bucket access and conditional PUT.
+func (c *Client) CheckS3Endpoint(ctx context.Context, id, bucket, storePrefix string) error {
+	if id == "" {
+		return fmt.Errorf("endpoint ID is required")
+	}
+	if bucket == "" {
+		return fmt.Errorf("bucket is required")
+	}
+
+	body := map[string]interface{}{
+		"bucket":       bucket,
+		"store-prefix": storePrefix,
+	}
+	if storePrefix == "" {
+		body["store-prefix"] = DefaultCheckStorePrefix
+	}
+
+	path := fmt.Sprintf("/admin/s3/%s/check", url.PathEscape(id))
+	if _, err := c.api.Put(ctx, path, body); err != nil {
+		return fmt.Errorf("failed to check S3 endpoint %s against bucket %s: %w", id, bucket, err)
+	}
+
+	return nil
+}
+
+// DiagnoseS3Error maps an error returned by PBS for an S3 operation to the
+// settings that commonly cause it. It returns nil when the error is not recognised.
+func DiagnoseS3Error(err error) *S3Diagnosis {
+	if err == nil {
+		return nil
+	}
+
+	msg := strings.ToLower(err.Error())
+	containsAny := func(needles ...string) bool {
+		for _, n := range needles {
+			if strings.Contains(msg, n) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Match S3 error codes and status lines rather than bare words, which also
+	// appear in unrelated PBS errors
+	switch {
+	case containsAny("if-none-match", "501 not implemented", "<code>notimplemented</code>", "code: notimplemented"):
+		return &S3Diagnosis{
+			Problem: S3ProblemConditionalPut,
+			Quirks:  []string{QuirkSkipIfNoneMatchHeader},
+			Hint:    fmt.Sprintf("The object store does not support conditional PUT requests; add %q to provider_quirks.", QuirkSkipIfNoneMatchHeader),
+		}
+	case containsAny("invalidaccesskeyid", "signaturedoesnotmatch", "accessdenied"):
+		return &S3Diagnosis{
+			Problem: S3ProblemCredentials,
+			Hint:    "The object store rejected the credentials; check access_key, secret_key and region, and that the key may access the bucket.",
+		}
+	case containsAny("nosuchbucket", "bucket does not exist"):
+		return &S3Diagnosis{
+			Problem: S3ProblemBucket,
+			Hint:    "The bucket does not exist or is not visible to these credentials; see the pbs_s3_buckets data source for accessible buckets.",
+		}
+	case containsAny("dns error", "failed to lookup address", "name or service not known", "no such host"):
+		pathStyle := true
+		return &S3Diagnosis{
+			Problem:   S3ProblemAddressing,
+			PathStyle: &pathStyle,
+			Hint:      "The bucket host name could not be resolved; providers without virtual-host bucket addressing need path_style = true.",
+		}
+	case containsAny("certificate verify failed", "self-signed certificate", "self signed certificate", "fingerprint mismatch"):
+		return &S3Diagnosis{
+			Problem: S3ProblemCertificate,
+			Hint:    "The TLS certificate of the object store was not trusted; set fingerprint for self-signed certificates.",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDiagnoseS3Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantProblem string
+		wantQuirk   string
+		wantPath    bool
+	}{
+		{
+			name:        "backblaze conditional put",
+			err:         errors.New("task failed: UPID:pbs:0001 access time safety check failed: 501 Not Implemented"),
+			wantProblem: S3ProblemConditionalPut,
+			wantQuirk:   QuirkSkipIfNoneMatchHeader,
+		},
+		{
+			name:        "bad secret",
+			err:         errors.New("API error 400: put object failed - SignatureDoesNotMatch"),
+			wantProblem: S3ProblemCredentials,
+		},
+		{
+			name:        "missing bucket",
+			err:         errors.New("API error 400: head bucket failed - NoSuchBucket"),
+			wantProblem: S3ProblemBucket,
+		},
+		{
+			name:        "vhost addressing",
+			err:         errors.New("client error (Connect): dns error: failed to lookup address information"),
+			wantProblem: S3ProblemAddressing,
+			wantPath:    true,
+		},
+		{
+			name:        "missing if-none-match support",
+			err:         errors.New("API error 400: put object failed - header If-None-Match not supported"),
+			wantProblem: S3ProblemConditionalPut,
+			wantQuirk:   QuirkSkipIfNoneMatchHeader,
+		},
+		{
+			name:        "safety check with bad credentials",
+			err:         errors.New("task failed: UPID:pbs:0001 access time safety check failed: AccessDenied"),
+			wantProblem: S3ProblemCredentials,
+		},
+		{
+			name:        "self-signed",
+			err:         errors.New("error trying to connect: certificate verify failed"),
+			wantProblem: S3ProblemCertificate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiagnoseS3Error(tt.err)
+			if d == nil {
+				t.Fatalf("expected diagnosis")
+			}
+			if d.Problem != tt.wantProblem {
+				t.Errorf("problem = %s, want %s", d.Problem, tt.wantProblem)
+			}
+			if tt.wantQuirk != "" && (len(d.Quirks) != 1 || d.Quirks[0] != tt.wantQuirk) {
+				t.Errorf("quirks = %v, want [%s]", d.Quirks, tt.wantQuirk)
+			}
+			if tt.wantPath != (d.PathStyle != nil && *d.PathStyle) {
+				t.Errorf("path style suggestion = %v, want %v", d.PathStyle, tt.wantPath)
+			}
+		})
+	}
+
+	for _, msg := range []string{
+		"API error 500: internal error",
+		"API request failed with status 403: 403 Forbidden: permission check failed",
+		"API request failed with status 400: removable datastores are not implemented for S3 backends",
+		"task failed: UPID:pbs:0001 access time safety check failed: connection reset by peer",
+		"API request failed with status 400: parameter verification failed - 'fingerprint': certificate fingerprint is invalid",
+	} {
+		if d := DiagnoseS3Error(errors.New(msg)); d != nil {
+			t.Errorf("expected no diagnosis for %q, got %+v", msg, d)
+		}
+	}
+	if !DiagnoseS3Error(errors.New("501 Not Implemented")).IsProviderQuirk() {
+		t.Error("expected conditional PUT failure to be a provider quirk")
+	}
+	if DiagnoseS3Error(errors.New("NoSuchBucket")).IsProviderQuirk() {
+		t.Error("expected missing bucket not to be a provider quirk")
+	}
+
+	if d := DiagnoseS3Error(nil); d != nil {
+		t.Errorf("expected no diagnosis for nil error, got %+v", d)
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/micah/terraform-provider-pbs/pbs/api"
 )
@@ -40,6 +42,47 @@ type S3Endpoint struct {
 	PutRateLimit   *int     `json:"put-rate-limit,omitempty"`
 }
 
+// S3Bucket represents a bucket visible to an S3 endpoint's credentials
+type S3Bucket struct {
+	Name         string     `json:"name"`
+	CreationDate BucketTime `json:"creation-date,omitempty"`
+}
+
+// BucketTime is a bucket creation date. PBS passes through whatever the S3
+// provider returned, which is either an RFC 3339 string or a Unix epoch.
+type BucketTime struct {
+	time.Time
+}
+
+// UnmarshalJSON accepts RFC 3339 strings, epoch seconds and null
+func (t *BucketTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" || string(data) == `""` {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var epoch int64
+	if err := json.Unmarshal(data, &epoch); err == nil {
+		t.Time = time.Unix(epoch, 0).UTC()
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid bucket creation date %s", string(data))
+	}
+	if epoch, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t.Time = time.Unix(epoch, 0).UTC()
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("invalid bucket creation date %q: %w", s, err)
+	}
+	t.Time = parsed
+	return nil
+}
+
 // ListS3Endpoints lists all S3 endpoint configurations
 func (c *Client) ListS3Endpoints(ctx context.Context) ([]S3Endpoint, error) {
 	resp, err := c.api.Get(ctx, "/config/s3")
@@ -172,3 +215,23 @@ func (c *Client) DeleteS3Endpoint(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// ListBuckets lists the buckets accessible with an S3 endpoint's credentials
+func (c *Client) ListBuckets(ctx context.Context, id string) ([]S3Bucket, error) {
+	if id == "" {
+		return nil, fmt.Errorf("endpoint ID is required")
+	}
+
+	path := fmt.Sprintf("/config/s3/%s/list-buckets", url.PathEscape(id))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets for S3 endpoint %s: %w", id, err)
+	}
+
+	var buckets []S3Bucket
+	if err := json.Unmarshal(resp.Data, &buckets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal buckets for S3 endpoint %s: %w", id, err)
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,41 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestS3BucketUnmarshal(t *testing.T) {
+	data := []byte(`[
+		{"name": "rfc3339", "creation-date": "2024-03-01T12:30:00Z"},
+		{"name": "epoch", "creation-date": 1709296200},
+		{"name": "epoch-string", "creation-date": "1709296200"},
+		{"name": "missing"},
+		{"name": "null", "creation-date": null}
+	]`)
+
+	var buckets []S3Bucket
+	if err := json.Unmarshal(data, &buckets); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	for _, b := range buckets[:3] {
+		if !b.CreationDate.Equal(want) {
+			t.Errorf("%s: creation date = %s, want %s", b.Name, b.CreationDate, want)
+		}
+	}
+	for _, b := range buckets[3:] {
+		if !b.CreationDate.IsZero() {
+			t.Errorf("%s: expected zero creation date, got %s", b.Name, b.CreationDate)
+		}
+	}
+}
+
+func TestS3BucketUnmarshalInvalidDate(t *testing.T) {
+	var bucket S3Bucket
+	if err := json.Unmarshal([]byte(`{"name": "b", "creation-date": "yesterday"}`), &bucket); err == nil {
+		t.Fatal("expected error for unparseable creation date")
+	}
+}
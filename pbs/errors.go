@@ -0,0 +1,61 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package pbs
+
+import "strings"
+
+// pbsAuthErrorMarkers identify errors of PBS's own authentication and permission
+// checks, e.g. an expired provider ticket or an API token without the privilege
+// for the call. They say nothing about the secret stored in PBS.
+var pbsAuthErrorMarkers = []string{
+	"api request failed with status 401",
+	"api request failed with status 403",
+	"login failed with status",
+	"no authentication credentials provided",
+}
+
+// credentialErrorMarkers are fragments of errors relayed by PBS from a remote,
+// object store or notification endpoint, or returned by InfluxDB, when a stored
+// credential was rejected. Status codes are only matched next to their reason
+// phrase or an HTTP status prefix, since bare numbers also occur in ports,
+// addresses and IDs.
+var credentialErrorMarkers = []string{
+	"status: 401",
+	"401 unauthorized",
+	"rejected the token",
+	"authentication failed",
+	"authentication failure",
+	"authentication credentials invalid",
+	"invalid credentials",
+	"535 ", // SMTP authentication failed
+	"invalidaccesskeyid",
+	"signaturedoesnotmatch",
+	"accessdenied",
+	"access denied",
+}
+
+// IsCredentialError reports whether err means a stored password, key or token
+// was rejected by the system it is used for, as opposed to a network or
+// configuration problem or PBS refusing the provider's own request.
+func IsCredentialError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, marker := range pbsAuthErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return false
+		}
+	}
+	for _, marker := range credentialErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package pbs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsCredentialError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("API error 400: remote scan failed - authentication failed - invalid credentials"), true},
+		{errors.New("failed to list buckets for S3 endpoint aws: API error 400: SignatureDoesNotMatch"), true},
+		{errors.New("could not send mail: 535 5.7.8 Authentication credentials invalid"), true},
+		{errors.New("gotify returned 401 Unauthorized"), true},
+		{errors.New("InfluxDB at https://influx:8086 rejected the token: 401 Unauthorized"), true},
+		{errors.New("webhook request failed - HTTP status: 401"), true},
+		{errors.New("error trying to connect: tcp connect error: Connection refused"), false},
+		{errors.New("dns error: failed to lookup address information"), false},
+		{errors.New("dial tcp 10.0.0.5:4010: connection refused"), false},
+		{errors.New("API request failed with status 500: unable to read backup group vm/401"), false},
+		{errors.New("influxdb write failed: 4401 points dropped"), false},
+		{errors.New("failed to scan remote r1: API request failed with status 403: {\"data\":null,\"message\":\"permission check failed\"}"), false},
+		{errors.New("failed to list buckets: API request failed with status 403: permission check failed - missing Sys.Audit on /system/s3"), false},
+		{errors.New("failed to send test notification via target mail: API request failed with status 401: authentication failed - invalid ticket"), false},
+		{errors.New("API request failed with status 401: {\"data\":null}"), false},
+		{errors.New("authentication failed: login failed with status 401: authentication failure"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCredentialError(tt.err); got != tt.want {
+			t.Errorf("IsCredentialError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Job types that can be run on demand through /admin/{type}/{id}/run
+const (
+	JobTypePrune  = "prune"
+	JobTypeSync   = "sync"
+	JobTypeVerify = "verify"
+)
+
+// RunJob starts a prune, sync or verify job immediately, independent of its
+// schedule, and returns the UPID of the started task
+func (c *Client) RunJob(ctx context.Context, jobType, id string) (string, error) {
+	switch jobType {
+	case JobTypePrune, JobTypeSync, JobTypeVerify:
+	default:
+		return "", fmt.Errorf("unsupported job type %q", jobType)
+	}
+
+	resp, err := c.api.Post(ctx, fmt.Sprintf("/admin/%s/%s/run", jobType, url.PathEscape(id)), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s job %s: %w", jobType, id, err)
+	}
+
+	var upid string
+	if err := json.Unmarshal(resp.Data, &upid); err != nil {
+		return "", fmt.Errorf("failed to unmarshal %s job run response: %w", jobType, err)
+	}
+
+	return upid, nil
+}
+
+// WaitForJobRun waits for a job task started by RunJob. When the task fails
+// the tail of its task log is appended to the error.
+func (c *Client) WaitForJobRun(ctx context.Context, upid string, timeout time.Duration) error {
+	return c.api.WaitForTaskWithLog(ctx, upid, timeout)
+}
@@ -0,0 +1,22 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micah/terraform-provider-pbs/pbs/api"
+)
+
+func TestRunJobRejectsUnknownType(t *testing.T) {
+	c := &Client{}
+	if _, err := c.RunJob(context.Background(), "tape-backup", "job1"); err == nil {
+		t.Fatal("expected error for unsupported job type")
+	}
+}
+
+func TestWaitForJobRunRejectsInvalidUPID(t *testing.T) {
+	c := &Client{api: &api.Client{}}
+	if err := c.WaitForJobRun(context.Background(), "not-a-upid", 0); err == nil {
+		t.Fatal("expected error for invalid UPID")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// JobStateOK is the last-run-state of a job whose last run succeeded
+const JobStateOK = "OK"
+
+// JobStatus is the run status PBS reports for a scheduled job in the
+// /admin/prune, /admin/sync and /admin/verify listings
+type JobStatus struct {
+	ID             string `json:"id"`
+	NextRun        *int64 `json:"next-run,omitempty"`
+	LastRunState   string `json:"last-run-state,omitempty"`
+	LastRunUPID    string `json:"last-run-upid,omitempty"`
+	LastRunEndtime *int64 `json:"last-run-endtime,omitempty"`
+	Duration       *int64 `json:"duration,omitempty"`
+}
+
+// Failed reports whether the last run ended with an error. Runs that finished
+// with warnings ("WARNINGS: <n>") and jobs that never ran are not failures.
+func (s *JobStatus) Failed() bool {
+	state := s.LastRunState
+	return state != "" && state != JobStateOK && !strings.HasPrefix(state, "WARNINGS")
+}
+
+// ListPruneJobStatus lists the run status of all prune jobs
+func (c *Client) ListPruneJobStatus(ctx context.Context) ([]JobStatus, error) {
+	return c.listJobStatus(ctx, "/admin/prune", "prune")
+}
+
+// ListSyncJobStatus lists the run status of sync jobs in one direction. PBS
+// lists pull jobs unless push is requested; releases without push support
+// reject the parameter, so it is only sent for push.
+func (c *Client) ListSyncJobStatus(ctx context.Context, direction string) ([]JobStatus, error) {
+	path := "/admin/sync"
+	if direction == SyncDirectionPush {
+		path = fmt.Sprintf("%s?sync-direction=%s", path, url.QueryEscape(direction))
+	}
+	return c.listJobStatus(ctx, path, "sync")
+}
+
+// ListVerifyJobStatus lists the run status of all verification jobs
+func (c *Client) ListVerifyJobStatus(ctx context.Context) ([]JobStatus, error) {
+	return c.listJobStatus(ctx, "/admin/verify", "verify")
+}
+
+// GetPruneJobStatus returns the run status of a prune job, or nil if PBS does not list it
+func (c *Client) GetPruneJobStatus(ctx context.Context, id string) (*JobStatus, error) {
+	statuses, err := c.ListPruneJobStatus(ctx)
+	return findJobStatus(statuses, id), err
+}
+
+// GetSyncJobStatus returns the run status of a sync job, or nil if PBS does not list it
+func (c *Client) GetSyncJobStatus(ctx context.Context, id, direction string) (*JobStatus, error) {
+	statuses, err := c.ListSyncJobStatus(ctx, direction)
+	return findJobStatus(statuses, id), err
+}
+
+// GetVerifyJobStatus returns the run status of a verification job, or nil if PBS does not list it
+func (c *Client) GetVerifyJobStatus(ctx context.Context, id string) (*JobStatus, error) {
+	statuses, err := c.ListVerifyJobStatus(ctx)
+	return findJobStatus(statuses, id), err
+}
+
+func (c *Client) listJobStatus(ctx context.Context, path, kind string) ([]JobStatus, error) {
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s job status: %w", kind, err)
+	}
+
+	var statuses []JobStatus
+	if err := json.Unmarshal(resp.Data, &statuses); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s job status: %w", kind, err)
+	}
+
+	return statuses, nil
+}
+
+func findJobStatus(statuses []JobStatus, id string) *JobStatus {
+	for i := range statuses {
+		if statuses[i].ID == id {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
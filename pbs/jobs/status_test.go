@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJobStatusUnmarshal(t *testing.T) {
+	data := `[
+		{"id": "sync-a", "store": "backup", "next-run": 1760000000, "last-run-state": "OK",
+		 "last-run-upid": "UPID:pbs:00001234:00005678:00000000:68E00000:syncjob:sync-a:root@pam:", "last-run-endtime": 1759990000, "duration": 42},
+		{"id": "sync-b", "store": "backup"}
+	]`
+
+	var statuses []JobStatus
+	if err := json.Unmarshal([]byte(data), &statuses); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	status := findJobStatus(statuses, "sync-a")
+	if status == nil || status.NextRun == nil || *status.NextRun != 1760000000 || status.LastRunEndtime == nil || status.LastRunState != JobStateOK {
+		t.Fatalf("findJobStatus(sync-a) = %+v", status)
+	}
+	if status := findJobStatus(statuses, "sync-b"); status == nil || status.LastRunEndtime != nil || status.LastRunUPID != "" {
+		t.Errorf("findJobStatus(sync-b) = %+v", status)
+	}
+	if findJobStatus(statuses, "missing") != nil {
+		t.Error("expected nil for unknown job")
+	}
+}
+
+func TestJobStatusFailed(t *testing.T) {
+	tests := map[string]bool{
+		"":                           false,
+		"OK":                         false,
+		"WARNINGS: 3":                false,
+		"connection refused":         true,
+		"unexpected status code 500": true,
+	}
+	for state, expected := range tests {
+		s := JobStatus{LastRunState: state}
+		if got := s.Failed(); got != expected {
+			t.Errorf("Failed() for %q = %v, want %v", state, got, expected)
+		}
+	}
+}
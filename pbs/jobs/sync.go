@@ -0,0 +1,239 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/micah/terraform-provider-pbs/pbs/datastores"
+)
+
+// Sync directions. In a pull job the local store is the target and the remote
+// the source; a push job sends the local store to the remote.
+const (
+	SyncDirectionPull = "pull"
+	SyncDirectionPush = "push"
+)
+
+// Group filter kinds accepted in sync job group-filter entries
+const (
+	GroupFilterGroup = "group"
+	GroupFilterType  = "type"
+	GroupFilterRegex = "regex"
+)
+
+// Group filter actions; PBS spells an exclude filter with an "exclude:" prefix
+const (
+	GroupFilterInclude = "include"
+	GroupFilterExclude = "exclude"
+)
+
+// GroupFilter is a parsed group-filter entry such as "group:vm/100", "type:ct",
+// "regex:^vm/1\d\d$" or "exclude:type:host"
+type GroupFilter struct {
+	Exclude bool
+	Kind    string
+	Value   string
+	regex   *regexp.Regexp
+}
+
+// ParseGroupFilter parses a PBS group-filter entry
+func ParseGroupFilter(s string) (GroupFilter, error) {
+	f := GroupFilter{}
+	rest := s
+	if after, ok := strings.CutPrefix(rest, "exclude:"); ok {
+		f.Exclude = true
+		rest = after
+	}
+
+	kind, value, ok := strings.Cut(rest, ":")
+	if !ok || value == "" {
+		return GroupFilter{}, fmt.Errorf("invalid group filter %q: expected [exclude:]group:<type>/<id>, type:<vm|ct|host> or regex:<pattern>", s)
+	}
+	f.Kind, f.Value = kind, value
+
+	switch kind {
+	case GroupFilterGroup:
+		backupType, backupID, ok := strings.Cut(value, "/")
+		if !ok || !isBackupType(backupType) || backupID == "" {
+			return GroupFilter{}, fmt.Errorf("invalid group filter %q: expected group:<vm|ct|host>/<id>", s)
+		}
+	case GroupFilterType:
+		if !isBackupType(value) {
+			return GroupFilter{}, fmt.Errorf("invalid group filter %q: type must be vm, ct or host", s)
+		}
+	case GroupFilterRegex:
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return GroupFilter{}, fmt.Errorf("invalid group filter %q: %w", s, err)
+		}
+		f.regex = re
+	default:
+		return GroupFilter{}, fmt.Errorf("invalid group filter %q: unknown kind %q", s, kind)
+	}
+
+	return f, nil
+}
+
+// NewGroupFilter builds and validates a filter from its parts, e.g.
+// ("exclude", "type", "host")
+func NewGroupFilter(action, kind, value string) (GroupFilter, error) {
+	s := kind + ":" + value
+	switch action {
+	case GroupFilterInclude, "":
+	case GroupFilterExclude:
+		s = "exclude:" + s
+	default:
+		return GroupFilter{}, fmt.Errorf("invalid group filter action %q: must be %s or %s", action, GroupFilterInclude, GroupFilterExclude)
+	}
+	return ParseGroupFilter(s)
+}
+
+// Action returns GroupFilterInclude or GroupFilterExclude
+func (f GroupFilter) Action() string {
+	if f.Exclude {
+		return GroupFilterExclude
+	}
+	return GroupFilterInclude
+}
+
+// String formats the filter as understood by PBS
+func (f GroupFilter) String() string {
+	s := f.Kind + ":" + f.Value
+	if f.Exclude {
+		return "exclude:" + s
+	}
+	return s
+}
+
+// Matches reports whether the filter selects the group, ignoring Exclude
+func (f GroupFilter) Matches(backupType, backupID string) bool {
+	switch f.Kind {
+	case GroupFilterGroup:
+		return f.Value == backupType+"/"+backupID
+	case GroupFilterType:
+		return f.Value == backupType
+	case GroupFilterRegex:
+		return f.regex != nil && f.regex.MatchString(backupType+"/"+backupID)
+	}
+	return false
+}
+
+// GroupFiltersMatch applies a sync job's group filters the way PBS does: a group
+// is selected when no include filter is given or any include filter matches it,
+// unless an exclude filter matches it. The order of the filters does not matter.
+func GroupFiltersMatch(filters []GroupFilter, backupType, backupID string) bool {
+	selected, _ := EvaluateGroupFilters(filters, backupType, backupID)
+	return selected
+}
+
+// EvaluateGroupFilters works like GroupFiltersMatch and also returns the index
+// of the filter that decided the outcome: the first matching exclude filter,
+// otherwise the first matching include filter. It is -1 when no filter matched,
+// i.e. the group is selected because there are no include filters, or dropped
+// because none of them matched.
+func EvaluateGroupFilters(filters []GroupFilter, backupType, backupID string) (bool, int) {
+	include, hasInclude := -1, false
+	for i, f := range filters {
+		if f.Exclude {
+			if f.Matches(backupType, backupID) {
+				return false, i
+			}
+			continue
+		}
+		hasInclude = true
+		if include < 0 && f.Matches(backupType, backupID) {
+			include = i
+		}
+	}
+	return include >= 0 || !hasInclude, include
+}
+
+// CheckGroupFilters reports filter lists that are valid for PBS but unlikely to
+// do what was intended, such as duplicates or an include that is also excluded.
+// The returned messages are keyed by filter index.
+func CheckGroupFilters(filters []GroupFilter) map[int]string {
+	problems := map[int]string{}
+	seen := map[string]int{}
+	for i, f := range filters {
+		key := f.String()
+		if first, ok := seen[key]; ok {
+			problems[i] = fmt.Sprintf("group filter %q duplicates entry %d", key, first)
+			continue
+		}
+		seen[key] = i
+	}
+
+	for i, f := range filters {
+		if f.Exclude || problems[i] != "" {
+			continue
+		}
+		for j, other := range filters {
+			if other.Exclude && other.Kind == f.Kind && other.Value == f.Value {
+				problems[i] = fmt.Sprintf("group filter %q is also excluded by entry %d; excludes always win, so it selects nothing", f.String(), j)
+				break
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
+
+// OwnedBy reports whether a group owner is user or one of its API tokens
+func OwnedBy(owner, user string) bool {
+	return owner == user || strings.HasPrefix(owner, user+"!")
+}
+
+// ValidateSyncNamespaces checks the namespaces of a sync job and that the
+// mapping between them fits within the PBS namespace depth limit. ns is the
+// local namespace and remoteNS the remote one; with maxDepth set, both sides
+// plus maxDepth levels below them must fit.
+func ValidateSyncNamespaces(ns, remoteNS string, maxDepth *int) error {
+	if err := datastores.ValidateNamespace(ns); err != nil {
+		return err
+	}
+	if err := datastores.ValidateNamespace(remoteNS); err != nil {
+		return err
+	}
+	if maxDepth == nil {
+		return nil
+	}
+
+	for _, side := range []struct{ label, ns string }{{"local namespace", ns}, {"remote namespace", remoteNS}} {
+		if depth := datastores.NamespaceDepth(side.ns); depth+*maxDepth > datastores.MaxNamespaceDepth {
+			return fmt.Errorf("max_depth %d below the %s %q (depth %d) exceeds the PBS limit of %d levels",
+				*maxDepth, side.label, side.ns, depth, datastores.MaxNamespaceDepth)
+		}
+	}
+	return nil
+}
+
+// MapSyncNamespace returns the namespace a source namespace is synced to: the
+// part below the source root is appended to the target root. ns must be the
+// source root or below it.
+func MapSyncNamespace(sourceRoot, targetRoot, ns string) string {
+	rel := ns
+	if sourceRoot != "" {
+		rel = strings.TrimPrefix(strings.TrimPrefix(ns, sourceRoot), "/")
+	}
+	switch {
+	case targetRoot == "":
+		return rel
+	case rel == "":
+		return targetRoot
+	}
+	return targetRoot + "/" + rel
+}
+
+func isBackupType(t string) bool {
+	return t == "vm" || t == "ct" || t == "host"
+}
@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"testing"
+)
+
+func TestParseGroupFilter(t *testing.T) {
+	valid := []string{"group:vm/100", "type:ct", "regex:^vm/1\\d\\d$", "exclude:type:host", "exclude:group:ct/200"}
+	for _, s := range valid {
+		f, err := ParseGroupFilter(s)
+		if err != nil {
+			t.Errorf("ParseGroupFilter(%q) error = %v", s, err)
+			continue
+		}
+		if f.String() != s {
+			t.Errorf("String() = %q, want %q", f.String(), s)
+		}
+	}
+
+	invalid := []string{"vm/100", "group:vm", "group:qemu/100", "type:lxc", "regex:(", "owner:root@pam", "exclude:", "type:"}
+	for _, s := range invalid {
+		if _, err := ParseGroupFilter(s); err == nil {
+			t.Errorf("ParseGroupFilter(%q) expected error", s)
+		}
+	}
+}
+
+func TestGroupFiltersMatch(t *testing.T) {
+	parse := func(specs ...string) []GroupFilter {
+		var filters []GroupFilter
+		for _, s := range specs {
+			f, err := ParseGroupFilter(s)
+			if err != nil {
+				t.Fatalf("ParseGroupFilter(%q) error = %v", s, err)
+			}
+			filters = append(filters, f)
+		}
+		return filters
+	}
+
+	tests := []struct {
+		name     string
+		filters  []GroupFilter
+		group    [2]string
+		expected bool
+	}{
+		{name: "no filters selects all", group: [2]string{"vm", "100"}, expected: true},
+		{name: "type include", filters: parse("type:vm"), group: [2]string{"vm", "100"}, expected: true},
+		{name: "type include misses", filters: parse("type:vm"), group: [2]string{"ct", "100"}, expected: false},
+		{name: "any include", filters: parse("type:vm", "group:ct/200"), group: [2]string{"ct", "200"}, expected: true},
+		{name: "regex on type/id", filters: parse("regex:^ct/2"), group: [2]string{"ct", "201"}, expected: true},
+		{name: "exclude only keeps others", filters: parse("exclude:type:host"), group: [2]string{"vm", "1"}, expected: true},
+		{name: "exclude only removes match", filters: parse("exclude:type:host"), group: [2]string{"host", "pbs"}, expected: false},
+		{name: "exclude wins over include", filters: parse("type:vm", "exclude:group:vm/100"), group: [2]string{"vm", "100"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GroupFiltersMatch(tt.filters, tt.group[0], tt.group[1]); got != tt.expected {
+				t.Errorf("GroupFiltersMatch() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewGroupFilter(t *testing.T) {
+	f, err := NewGroupFilter(GroupFilterExclude, GroupFilterType, "host")
+	if err != nil {
+		t.Fatalf("NewGroupFilter() error = %v", err)
+	}
+	if f.String() != "exclude:type:host" || f.Action() != GroupFilterExclude {
+		t.Errorf("NewGroupFilter() = %q (%s)", f.String(), f.Action())
+	}
+
+	if _, err := NewGroupFilter("skip", GroupFilterType, "vm"); err == nil {
+		t.Error("expected error for unknown action")
+	}
+	if _, err := NewGroupFilter(GroupFilterInclude, GroupFilterGroup, "vm"); err == nil {
+		t.Error("expected error for group filter without id")
+	}
+}
+
+func TestEvaluateGroupFilters(t *testing.T) {
+	var filters []GroupFilter
+	for _, s := range []string{"type:vm", "regex:^vm/1", "exclude:group:vm/100"} {
+		f, err := ParseGroupFilter(s)
+		if err != nil {
+			t.Fatalf("ParseGroupFilter(%q) error = %v", s, err)
+		}
+		filters = append(filters, f)
+	}
+
+	tests := []struct {
+		backupType, backupID string
+		selected             bool
+		decidedBy            int
+	}{
+		{"vm", "100", false, 2},
+		{"vm", "101", true, 0},
+		{"ct", "101", false, -1},
+	}
+	for _, tt := range tests {
+		selected, decidedBy := EvaluateGroupFilters(filters, tt.backupType, tt.backupID)
+		if selected != tt.selected || decidedBy != tt.decidedBy {
+			t.Errorf("EvaluateGroupFilters(%s/%s) = %v, %d, want %v, %d", tt.backupType, tt.backupID, selected, decidedBy, tt.selected, tt.decidedBy)
+		}
+	}
+}
+
+func TestCheckGroupFilters(t *testing.T) {
+	var filters []GroupFilter
+	for _, s := range []string{"type:vm", "exclude:type:vm", "type:ct", "type:ct", "exclude:group:ct/100"} {
+		f, err := ParseGroupFilter(s)
+		if err != nil {
+			t.Fatalf("ParseGroupFilter(%q) error = %v", s, err)
+		}
+		filters = append(filters, f)
+	}
+
+	problems := CheckGroupFilters(filters)
+	if len(problems) != 2 || problems[0] == "" || problems[3] == "" {
+		t.Errorf("CheckGroupFilters() = %v", problems)
+	}
+	if CheckGroupFilters(filters[2:3]) != nil {
+		t.Error("expected no problems for a single filter")
+	}
+}
+
+func TestOwnedBy(t *testing.T) {
+	if !OwnedBy("sync@pbs", "sync@pbs") || !OwnedBy("sync@pbs!token", "sync@pbs") {
+		t.Error("expected user and its tokens to match")
+	}
+	if OwnedBy("sync@pbs2", "sync@pbs") || OwnedBy("root@pam", "sync@pbs") {
+		t.Error("expected other users not to match")
+	}
+}
+
+func TestValidateSyncNamespaces(t *testing.T) {
+	depth := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		ns       string
+		remoteNS string
+		maxDepth *int
+		wantErr  bool
+	}{
+		{name: "root to root", wantErr: false},
+		{name: "nested mapping", ns: "prod/vms", remoteNS: "offsite/prod", maxDepth: depth(2)},
+		{name: "unlimited depth", ns: "a/b/c/d", remoteNS: "x/y/z/w"},
+		{name: "invalid component", ns: "prod/-bad", wantErr: true},
+		{name: "empty component", remoteNS: "a//b", wantErr: true},
+		{name: "too deep namespace", ns: "1/2/3/4/5/6/7/8", wantErr: true},
+		{name: "remote depth plus max depth", remoteNS: "a/b/c/d/e", maxDepth: depth(3), wantErr: true},
+		{name: "local depth plus max depth", ns: "a/b/c/d/e/f", maxDepth: depth(2), wantErr: true},
+		{name: "exactly at limit", ns: "a/b/c/d", remoteNS: "x", maxDepth: depth(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSyncNamespaces(tt.ns, tt.remoteNS, tt.maxDepth)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSyncNamespaces() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMapSyncNamespace(t *testing.T) {
+	tests := []struct {
+		source, target, ns, expected string
+	}{
+		{"", "", "", ""},
+		{"", "offsite", "", "offsite"},
+		{"", "offsite", "prod/vms", "offsite/prod/vms"},
+		{"prod", "", "prod/vms", "vms"},
+		{"prod", "offsite/prod", "prod", "offsite/prod"},
+		{"prod", "offsite/prod", "prod/vms/db", "offsite/prod/vms/db"},
+	}
+
+	for _, tt := range tests {
+		if got := MapSyncNamespace(tt.source, tt.target, tt.ns); got != tt.expected {
+			t.Errorf("MapSyncNamespace(%q, %q, %q) = %q, want %q", tt.source, tt.target, tt.ns, got, tt.expected)
+		}
+	}
+}
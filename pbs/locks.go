@@ -0,0 +1,137 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package pbs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LockDomain identifies a group of PBS configuration files guarded by the same
+// server-side lock. Operations in different domains never contend on the server.
+type LockDomain string
+
+// Lock domains for the PBS configuration files managed by the provider
+const (
+	LockDomainDatastore     LockDomain = "datastore"
+	LockDomainJobs          LockDomain = "jobs"
+	LockDomainNotifications LockDomain = "notifications"
+	LockDomainMetrics       LockDomain = "metrics"
+	LockDomainRemote        LockDomain = "remote"
+	LockDomainS3            LockDomain = "s3"
+)
+
+const (
+	lockRetryAttempts = 3
+	lockRetryDelay    = 2 * time.Second
+)
+
+// LockManager serialises configuration changes per PBS host and lock domain.
+// PBS takes an exclusive file lock (e.g. /etc/proxmox-backup/.datastore.lck) for
+// every config write and fails concurrent writers, so the provider queues them
+// instead. Hosts and domains are independent of each other.
+type LockManager struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// NewLockManager creates an empty lock manager
+func NewLockManager() *LockManager {
+	return &LockManager{locks: make(map[string]chan struct{})}
+}
+
+// sharedLocks is used by every client in the process, so provider aliases that
+// point at the same host still queue behind each other.
+var sharedLocks = NewLockManager()
+
+// Acquire blocks until the lock for the endpoint and domain is free or ctx is
+// done. The returned function releases the lock.
+func (m *LockManager) Acquire(ctx context.Context, endpoint string, domain LockDomain) (func(), error) {
+	key := strings.ToLower(strings.TrimSuffix(endpoint, "/")) + "|" + string(domain)
+
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	waitStart := time.Now()
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("interrupted while waiting for %s configuration lock on %s: %w", domain, endpoint, ctx.Err())
+	}
+
+	acquired := time.Now()
+	tflog.Debug(ctx, "Acquired PBS configuration lock", map[string]any{
+		"endpoint": endpoint,
+		"domain":   string(domain),
+		"wait":     acquired.Sub(waitStart).String(),
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-lock
+			tflog.Debug(ctx, "Released PBS configuration lock", map[string]any{
+				"endpoint": endpoint,
+				"domain":   string(domain),
+				"held":     time.Since(acquired).String(),
+			})
+		})
+	}, nil
+}
+
+// WithConfigLock runs fn while holding the lock for the domain on this client's
+// host. If PBS still reports lock contention, e.g. because another client is
+// writing the same configuration, fn is retried with a linear backoff.
+func (c *Client) WithConfigLock(ctx context.Context, domain LockDomain, fn func() error) error {
+	unlock, err := c.locks.Acquire(ctx, c.api.Endpoint(), domain)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !IsLockContentionError(err) || attempt >= lockRetryAttempts {
+			return err
+		}
+
+		delay := lockRetryDelay * time.Duration(attempt)
+		tflog.Debug(ctx, "PBS configuration lock busy, retrying", map[string]any{
+			"domain":  string(domain),
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("interrupted while retrying locked %s configuration change: %w", domain, ctx.Err())
+		}
+	}
+}
+
+// IsLockContentionError reports whether err means PBS timed out waiting for one
+// of its configuration file locks. PBS reports this as
+// `Unable to acquire lock "/etc/proxmox-backup/.datastore.lck" - EINTR: Interrupted system call`.
+func IsLockContentionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(err.Error()), "unable to acquire lock")
+}
@@ -0,0 +1,78 @@
+package pbs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLockManagerSerialisesSameHostAndDomain(t *testing.T) {
+	m := NewLockManager()
+	ctx := context.Background()
+
+	unlock, err := m.Acquire(ctx, "https://pbs.example:8007", LockDomainDatastore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Endpoint comparison ignores case and a trailing slash
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := m.Acquire(waitCtx, "https://PBS.example:8007/", LockDomainDatastore); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected second acquire to time out, got %v", err)
+	}
+
+	unlock()
+	unlock() // releasing twice must not panic or free someone else's lock
+
+	again, err := m.Acquire(ctx, "https://pbs.example:8007", LockDomainDatastore)
+	if err != nil {
+		t.Fatalf("expected lock to be free after release, got %v", err)
+	}
+	again()
+}
+
+func TestLockManagerIndependentKeys(t *testing.T) {
+	m := NewLockManager()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	unlock, err := m.Acquire(ctx, "https://pbs-a.example:8007", LockDomainDatastore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer unlock()
+
+	otherDomain, err := m.Acquire(ctx, "https://pbs-a.example:8007", LockDomainJobs)
+	if err != nil {
+		t.Fatalf("expected other domain on same host not to block, got %v", err)
+	}
+	defer otherDomain()
+
+	otherHost, err := m.Acquire(ctx, "https://pbs-b.example:8007", LockDomainDatastore)
+	if err != nil {
+		t.Fatalf("expected same domain on other host not to block, got %v", err)
+	}
+	defer otherHost()
+}
+
+func TestIsLockContentionError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("API error 500: unable to parse config"), false},
+		{errors.New("Unable to acquire lock \"/etc/proxmox-backup/.datastore.lck\" - EINTR: Interrupted system call"), true},
+		{errors.New("API request failed with status 500: unable to acquire lock \"/etc/proxmox-backup/.remote.lck\" - EINTR: Interrupted system call"), true},
+		{errors.New("unable to open lock file \"/etc/proxmox-backup/.s3.lck\" - EACCES: Permission denied"), false},
+		{errors.New("read /etc/proxmox-backup/datastore.cfg: Interrupted system call"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsLockContentionError(tt.err); got != tt.want {
+			t.Errorf("IsLockContentionError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CheckInfluxDBToken verifies that an InfluxDB v2 API token may read the given
+// bucket. PBS has no endpoint to test a metrics server, so the provider queries
+// InfluxDB directly.
+func CheckInfluxDBToken(ctx context.Context, serverURL, organization, bucket, token string, verifyTLS bool) error {
+	if serverURL == "" {
+		return fmt.Errorf("InfluxDB URL is required")
+	}
+
+	query := url.Values{}
+	if organization != "" {
+		query.Set("org", organization)
+	}
+	if bucket != "" {
+		query.Set("name", bucket)
+	}
+	endpoint := strings.TrimSuffix(serverURL, "/") + "/api/v2/buckets?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build InfluxDB request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+token)
+
+	resp, err := influxDBHTTPClient(verifyTLS).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach InfluxDB at %s: %w", serverURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("InfluxDB at %s rejected the token: %s %s", serverURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// ProbeInfluxDBWrite verifies that an InfluxDB v2 API token may write to the
+// given bucket by sending a write request without any points, so nothing is
+// stored. The error distinguishes an unreachable server, a rejected token, a
+// token without write access and a missing organization or bucket.
+func ProbeInfluxDBWrite(ctx context.Context, serverURL, organization, bucket, token string, verifyTLS bool) error {
+	if serverURL == "" {
+		return fmt.Errorf("InfluxDB URL is required")
+	}
+
+	query := url.Values{}
+	query.Set("org", organization)
+	query.Set("bucket", bucket)
+	query.Set("precision", "s")
+	endpoint := strings.TrimSuffix(serverURL, "/") + "/api/v2/write?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to build InfluxDB request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+token)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := influxDBHTTPClient(verifyTLS).Do(req)
+	if err != nil {
+		return fmt.Errorf("InfluxDB at %s is not reachable: %w", serverURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	detail := strings.TrimSpace(resp.Status + " " + string(body))
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("InfluxDB at %s rejected the token: %s", serverURL, detail)
+	case http.StatusForbidden:
+		return fmt.Errorf("the token may not write to bucket %q on InfluxDB at %s: %s", bucket, serverURL, detail)
+	case http.StatusNotFound:
+		return fmt.Errorf("organization %q or bucket %q not found on InfluxDB at %s: %s", organization, bucket, serverURL, detail)
+	default:
+		return fmt.Errorf("InfluxDB at %s rejected the write: %s", serverURL, detail)
+	}
+}
+
+// ProbeInfluxDBUDP checks that an InfluxDB UDP endpoint can be resolved. PBS
+// always sends these metrics over UDP, which is connectionless, so a listener
+// cannot be confirmed.
+func ProbeInfluxDBUDP(ctx context.Context, host string, port int) error {
+	address := net.JoinHostPort(host, fmt.Sprintf("%d", port))
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return fmt.Errorf("InfluxDB at %s is not reachable: %w", address, err)
+	}
+	return conn.Close()
+}
+
+func influxDBHTTPClient(verifyTLS bool) *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !verifyTLS, //nolint:gosec // mirrors the verify_tls setting of the metrics server
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckInfluxDBToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/buckets" || r.URL.Query().Get("name") != "pbs" || r.URL.Query().Get("org") != "ops" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Token good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"unauthorized","message":"unauthorized access"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"buckets":[]}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	if err := CheckInfluxDBToken(ctx, srv.URL+"/", "ops", "pbs", "good", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := CheckInfluxDBToken(ctx, srv.URL, "ops", "pbs", "revoked", true)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected 401 error, got %v", err)
+	}
+}
+
+func TestProbeInfluxDBWrite(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/write" || len(body) != 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch {
+		case r.Header.Get("Authorization") == "Token read-only":
+			w.WriteHeader(http.StatusForbidden)
+		case r.Header.Get("Authorization") != "Token good":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Query().Get("org") != "ops" || r.URL.Query().Get("bucket") != "pbs":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not found","message":"bucket \"other\" not found"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	if err := ProbeInfluxDBWrite(ctx, srv.URL, "ops", "pbs", "good", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string]struct {
+		bucket, token, want string
+	}{
+		"revoked token":   {bucket: "pbs", token: "revoked", want: "rejected the token"},
+		"read-only token": {bucket: "pbs", token: "read-only", want: "may not write"},
+		"missing bucket":  {bucket: "other", token: "good", want: "not found"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ProbeInfluxDBWrite(ctx, srv.URL, "ops", tc.bucket, tc.token, true)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	unreachable := srv.URL
+	srv.Close()
+	if err := ProbeInfluxDBWrite(ctx, unreachable, "ops", "pbs", "good", true); err == nil || !strings.Contains(err.Error(), "not reachable") {
+		t.Fatalf("expected unreachable error, got %v", err)
+	}
+}
+
+func TestProbeInfluxDBUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer conn.Close()
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+
+	ctx := context.Background()
+	if err := ProbeInfluxDBUDP(ctx, "127.0.0.1", port); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ProbeInfluxDBUDP(ctx, "influxdb.invalid", port); err == nil {
+		t.Fatal("expected error for unresolvable host")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MetricsHostID is the ID of the metric data points describing the PBS host
+const MetricsHostID = "host"
+
+// metricsDatastorePrefix prefixes the ID of datastore metric data points
+const metricsDatastorePrefix = "datastore/"
+
+// MetricDataPoint is a single value reported by /status/metrics, as PBS sends
+// it to the configured metrics servers
+type MetricDataPoint struct {
+	ID        string  `json:"id"`
+	Metric    string  `json:"metric"`
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+	Type      string  `json:"type,omitempty"`
+}
+
+// Datastore returns the datastore name of a datastore data point and whether
+// the point describes a datastore
+func (p MetricDataPoint) Datastore() (string, bool) {
+	return strings.CutPrefix(p.ID, metricsDatastorePrefix)
+}
+
+// GetMetricsStatus returns the current host and datastore metrics
+func (c *Client) GetMetricsStatus(ctx context.Context) ([]MetricDataPoint, error) {
+	resp, err := c.api.Get(ctx, "/status/metrics")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics status: %w", err)
+	}
+
+	var status struct {
+		Data []MetricDataPoint `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metrics status: %w", err)
+	}
+
+	return status.Data, nil
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMetricDataPointDatastore(t *testing.T) {
+	data := `{"data": [
+		{"id": "host", "metric": "cpu_current", "timestamp": 1760000000, "value": 0.25, "type": "gauge"},
+		{"id": "datastore/backups", "metric": "used", "timestamp": 1760000000, "value": 1024, "type": "gauge"}
+	]}`
+
+	var status struct {
+		Data []MetricDataPoint `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(status.Data) != 2 {
+		t.Fatalf("got %d data points, want 2", len(status.Data))
+	}
+
+	if _, ok := status.Data[0].Datastore(); ok || status.Data[0].ID != MetricsHostID {
+		t.Errorf("expected host data point, got %+v", status.Data[0])
+	}
+	if store, ok := status.Data[1].Datastore(); !ok || store != "backups" || status.Data[1].Value != 1024 {
+		t.Errorf("Datastore() = %q, %v for %+v", store, ok, status.Data[1])
+	}
+}
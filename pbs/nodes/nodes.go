@@ -0,0 +1,166 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package nodes provides API client functionality for PBS node discovery and status
+package nodes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/micah/terraform-provider-pbs/pbs/api"
+)
+
+// Client represents the nodes API client
+type Client struct {
+	api *api.Client
+
+	mu    sync.Mutex
+	nodes []api.NodeInfo
+}
+
+// NewClient creates a new nodes API client
+func NewClient(apiClient *api.Client) *Client {
+	return &Client{api: apiClient}
+}
+
+// Usage holds total and used bytes of a memory or filesystem resource
+type Usage struct {
+	Total int64 `json:"total"`
+	Used  int64 `json:"used"`
+	Free  int64 `json:"free,omitempty"`
+	Avail int64 `json:"avail,omitempty"`
+}
+
+// CPUInfo describes the processors of a node
+type CPUInfo struct {
+	Model   string `json:"model"`
+	Sockets int64  `json:"sockets"`
+	CPUs    int64  `json:"cpus"`
+}
+
+// Kernel describes the running kernel of a node
+type Kernel struct {
+	Sysname string `json:"sysname"`
+	Release string `json:"release"`
+	Version string `json:"version"`
+	Machine string `json:"machine"`
+}
+
+// Status represents the /nodes/{node}/status response
+type Status struct {
+	Uptime        int64     `json:"uptime"`
+	CPU           float64   `json:"cpu"`
+	Wait          float64   `json:"wait"`
+	LoadAvg       []float64 `json:"loadavg"`
+	CPUInfo       CPUInfo   `json:"cpuinfo"`
+	Memory        Usage     `json:"memory"`
+	Swap          Usage     `json:"swap"`
+	Root          Usage     `json:"root"`
+	KVersion      string    `json:"kversion"`
+	CurrentKernel *Kernel   `json:"current-kernel,omitempty"`
+}
+
+// Version represents the /version response
+type Version struct {
+	Version string `json:"version"`
+	Release string `json:"release"`
+	RepoID  string `json:"repoid"`
+}
+
+// String returns the full PBS version, e.g. 4.0.14 for version 4.0 release 14
+func (v *Version) String() string {
+	if v.Release == "" {
+		return v.Version
+	}
+	return v.Version + "." + v.Release
+}
+
+// ListNodes returns the nodes of the PBS instance. The result is discovered once
+// per client and cached, since nodes do not change during a Terraform run.
+func (c *Client) ListNodes(ctx context.Context) ([]api.NodeInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nodes != nil {
+		return c.nodes, nil
+	}
+
+	nodes, err := c.api.GetNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Node < nodes[j].Node })
+
+	c.nodes = nodes
+	return nodes, nil
+}
+
+// ResolveNode returns node if set, otherwise the only node of the PBS instance.
+// It fails if node is empty and the instance does not have exactly one node.
+func (c *Client) ResolveNode(ctx context.Context, node string) (string, error) {
+	if node != "" {
+		return node, nil
+	}
+
+	nodes, err := c.ListNodes(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine default node: %w", err)
+	}
+
+	switch len(nodes) {
+	case 0:
+		return "", fmt.Errorf("failed to determine default node: PBS reported no nodes")
+	case 1:
+		return nodes[0].Node, nil
+	}
+
+	names := make([]string, len(nodes))
+	for i, n := range nodes {
+		names[i] = n.Node
+	}
+	return "", fmt.Errorf("node must be set explicitly, PBS reported multiple nodes: %s", strings.Join(names, ", "))
+}
+
+// GetNodeStatus returns resource usage and system information of a node
+func (c *Client) GetNodeStatus(ctx context.Context, node string) (*Status, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/status", url.PathEscape(node))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of node %s: %w", node, err)
+	}
+
+	var status Status
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status of node %s: %w", node, err)
+	}
+
+	return &status, nil
+}
+
+// GetVersion returns the version of the PBS instance
+func (c *Client) GetVersion(ctx context.Context) (*Version, error) {
+	resp, err := c.api.Get(ctx, "/version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PBS version: %w", err)
+	}
+
+	var version Version
+	if err := json.Unmarshal(resp.Data, &version); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PBS version: %w", err)
+	}
+
+	return &version, nil
+}
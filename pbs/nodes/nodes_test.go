@@ -0,0 +1,49 @@
+package nodes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStatusUnmarshal(t *testing.T) {
+	data := []byte(`{
+		"uptime": 86400,
+		"cpu": 0.125,
+		"wait": 0.01,
+		"loadavg": [0.5, 0.4, 0.3],
+		"cpuinfo": {"model": "AMD EPYC 7302P", "sockets": 1, "cpus": 32},
+		"memory": {"total": 68719476736, "used": 8589934592, "free": 60129542144},
+		"swap": {"total": 0, "used": 0, "free": 0},
+		"root": {"total": 107374182400, "used": 10737418240, "avail": 96636764160},
+		"kversion": "Linux 6.14.8-2-pve #1 SMP PREEMPT_DYNAMIC",
+		"current-kernel": {"sysname": "Linux", "release": "6.14.8-2-pve", "version": "#1 SMP", "machine": "x86_64"}
+	}`)
+
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if status.CPUInfo.CPUs != 32 || status.Memory.Used != 8589934592 || status.Root.Avail != 96636764160 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if status.CurrentKernel == nil || status.CurrentKernel.Release != "6.14.8-2-pve" {
+		t.Errorf("unexpected kernel: %+v", status.CurrentKernel)
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	tests := []struct {
+		version Version
+		want    string
+	}{
+		{Version{Version: "4.0", Release: "14"}, "4.0.14"},
+		{Version{Version: "3.4"}, "3.4"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.version.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Match field types accepted by PBS in matcher match-field expressions
+const (
+	MatchFieldTypeExact = "exact"
+	MatchFieldTypeRegex = "regex"
+)
+
+// KnownMatchFields lists the metadata fields PBS attaches to notifications
+var KnownMatchFields = []string{"type", "hostname", "datastore", "job-id"}
+
+// MatchField is a parsed match-field expression such as "exact:type=gc,prune" or "regex:hostname=^pbs-.*$"
+type MatchField struct {
+	Type   string
+	Field  string
+	Values []string
+}
+
+// ParseMatchField parses a PBS match-field expression. Expressions without a
+// type prefix use the legacy "field=value" form and are treated as exact matches.
+func ParseMatchField(expr string) (MatchField, error) {
+	matchType := MatchFieldTypeExact
+	rest := expr
+
+	if prefix, remainder, ok := strings.Cut(expr, ":"); ok && (prefix == MatchFieldTypeExact || prefix == MatchFieldTypeRegex) {
+		matchType = prefix
+		rest = remainder
+	}
+
+	field, value, ok := strings.Cut(rest, "=")
+	if !ok || strings.TrimSpace(field) == "" {
+		return MatchField{}, fmt.Errorf("invalid match-field expression %q: expected [exact:|regex:]field=value", expr)
+	}
+
+	m := MatchField{Type: matchType, Field: field}
+	if matchType == MatchFieldTypeRegex {
+		// Regex patterns may legitimately contain commas, so they are never split
+		m.Values = []string{value}
+	} else {
+		m.Values = strings.Split(value, ",")
+	}
+
+	return m, nil
+}
+
+// String formats the match field in the prefixed form understood by PBS.
+func (m MatchField) String() string {
+	matchType := m.Type
+	if matchType == "" {
+		matchType = MatchFieldTypeExact
+	}
+	return fmt.Sprintf("%s:%s=%s", matchType, m.Field, strings.Join(m.Values, ","))
+}
+
+// Validate checks that the field is known to PBS, that values are present and,
+// for regex matches, that the pattern compiles.
+func (m MatchField) Validate() error {
+	if !isKnownMatchField(m.Field) {
+		return fmt.Errorf("unknown match field %q; PBS notifications carry the fields %s", m.Field, strings.Join(KnownMatchFields, ", "))
+	}
+	if len(m.Values) == 0 {
+		return fmt.Errorf("match field %q requires at least one value", m.Field)
+	}
+
+	switch m.Type {
+	case MatchFieldTypeExact, "":
+		for _, v := range m.Values {
+			if v == "" {
+				return fmt.Errorf("match field %q contains an empty value", m.Field)
+			}
+			if strings.Contains(v, ",") {
+				return fmt.Errorf("match field %q value %q must not contain a comma; list alternatives as separate values", m.Field, v)
+			}
+		}
+	case MatchFieldTypeRegex:
+		if len(m.Values) != 1 {
+			return fmt.Errorf("regex match field %q takes exactly one pattern, got %d", m.Field, len(m.Values))
+		}
+		if _, err := regexp.Compile(m.Values[0]); err != nil {
+			return fmt.Errorf("invalid regex for match field %q: %w", m.Field, err)
+		}
+	default:
+		return fmt.Errorf("invalid match type %q for field %q: expected %s or %s", m.Type, m.Field, MatchFieldTypeExact, MatchFieldTypeRegex)
+	}
+
+	return nil
+}
+
+func isKnownMatchField(field string) bool {
+	for _, known := range KnownMatchFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
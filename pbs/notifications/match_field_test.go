@@ -0,0 +1,100 @@
+package notifications
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMatchField(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected MatchField
+		wantErr  bool
+	}{
+		{
+			name:     "exact with alternatives",
+			expr:     "exact:type=gc,prune",
+			expected: MatchField{Type: MatchFieldTypeExact, Field: "type", Values: []string{"gc", "prune"}},
+		},
+		{
+			name:     "regex keeps commas",
+			expr:     "regex:hostname=^pbs-(a|b){1,2}$",
+			expected: MatchField{Type: MatchFieldTypeRegex, Field: "hostname", Values: []string{"^pbs-(a|b){1,2}$"}},
+		},
+		{
+			name:     "legacy form without prefix",
+			expr:     "datastore=store1",
+			expected: MatchField{Type: MatchFieldTypeExact, Field: "datastore", Values: []string{"store1"}},
+		},
+		{
+			name:    "missing value separator",
+			expr:    "exact:type",
+			wantErr: true,
+		},
+		{
+			name:    "empty field",
+			expr:    "regex:=foo",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMatchField(tt.expr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("got %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatchFieldStringRoundTrip(t *testing.T) {
+	for _, expr := range []string{"exact:type=gc,prune", "regex:job-id=^nightly-.*$"} {
+		m, err := ParseMatchField(expr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if m.String() != expr {
+			t.Fatalf("round trip of %q produced %q", expr, m.String())
+		}
+	}
+}
+
+func TestMatchFieldValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   MatchField
+		wantErr bool
+	}{
+		{name: "valid exact", field: MatchField{Type: "exact", Field: "type", Values: []string{"gc"}}},
+		{name: "valid regex", field: MatchField{Type: "regex", Field: "hostname", Values: []string{"^pbs-\\d+$"}}},
+		{name: "unknown field", field: MatchField{Type: "exact", Field: "typ", Values: []string{"gc"}}, wantErr: true},
+		{name: "bad regex", field: MatchField{Type: "regex", Field: "datastore", Values: []string{"(unclosed"}}, wantErr: true},
+		{name: "regex with multiple patterns", field: MatchField{Type: "regex", Field: "datastore", Values: []string{"a", "b"}}, wantErr: true},
+		{name: "exact value with comma", field: MatchField{Type: "exact", Field: "job-id", Values: []string{"a,b"}}, wantErr: true},
+		{name: "no values", field: MatchField{Type: "exact", Field: "type"}, wantErr: true},
+		{name: "unknown type", field: MatchField{Type: "glob", Field: "type", Values: []string{"gc"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.field.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
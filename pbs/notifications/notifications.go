@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/micah/terraform-provider-pbs/pbs/api"
 )
@@ -36,6 +37,31 @@ const (
 	NotificationTargetTypeWebhook  NotificationTargetType = "webhook"
 )
 
+// Origin values reported by PBS for notification targets and matchers
+const (
+	OriginUserCreated     = "user-created"
+	OriginBuiltin         = "builtin"
+	OriginModifiedBuiltin = "modified-builtin"
+)
+
+// Built-in entries shipped with PBS
+const (
+	BuiltinMatcherName        = "default-matcher"
+	BuiltinSendmailTargetName = "mail-to-root"
+)
+
+// Comments of the built-in entries in the default PBS notification config
+const (
+	builtinMatcherComment        = "Route all notifications to mail-to-root"
+	builtinSendmailTargetComment = "Send mails to root@pam's email address"
+)
+
+// IsBuiltinOrigin reports whether an entry was shipped with PBS (modified or not).
+// Built-in entries cannot be created or deleted, only updated and reset.
+func IsBuiltinOrigin(origin string) bool {
+	return origin == OriginBuiltin || origin == OriginModifiedBuiltin
+}
+
 // SMTPTarget represents an SMTP notification target configuration
 type SMTPTarget struct {
 	Name       string   `json:"name"`
@@ -73,6 +99,7 @@ type SendmailTarget struct {
 	Comment    string   `json:"comment,omitempty"`
 	Disable    *bool    `json:"disable,omitempty"`
 	Origin     string   `json:"origin,omitempty"`
+	Delete     []string `json:"delete,omitempty"` // fields to delete on update
 }
 
 // WebhookTarget represents a Webhook notification target configuration
@@ -81,11 +108,12 @@ type WebhookTarget struct {
 	URL     string            `json:"url"`
 	Body    string            `json:"body,omitempty"`
 	Method  string            `json:"method,omitempty"` // POST, PUT
-	Headers map[string]string `json:"header,omitempty"`
-	Secret  string            `json:"secret,omitempty"`
+	Headers map[string]string `json:"-"`                // header property strings, decoded
+	Secrets []WebhookSecret   `json:"-"`                // secret property strings, decoded
 	Comment string            `json:"comment,omitempty"`
 	Disable *bool             `json:"disable,omitempty"`
 	Origin  string            `json:"origin,omitempty"`
+	Delete  []string          `json:"delete,omitempty"` // fields to delete on update
 }
 
 // SMTP Target Methods
@@ -449,6 +477,9 @@ func (c *Client) UpdateSendmailTarget(ctx context.Context, name string, target *
 	if target.Disable != nil {
 		body["disable"] = *target.Disable
 	}
+	if len(target.Delete) > 0 {
+		body["delete"] = target.Delete
+	}
 
 	path := fmt.Sprintf("/config/notifications/endpoints/sendmail/%s", url.PathEscape(name))
 	_, err := c.api.Put(ctx, path, body)
@@ -474,6 +505,36 @@ func (c *Client) DeleteSendmailTarget(ctx context.Context, name string) error {
 	return nil
 }
 
+// ResetSendmailTarget restores a built-in Sendmail target to its shipped defaults.
+// Built-in targets cannot be deleted, so the optional properties are cleared via the
+// delete parameter and mail-to-root gets its shipped recipient and comment back.
+func (c *Client) ResetSendmailTarget(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("target name is required")
+	}
+
+	path := fmt.Sprintf("/config/notifications/endpoints/sendmail/%s", url.PathEscape(name))
+	if _, err := c.api.Put(ctx, path, resetSendmailTargetBody(name)); err != nil {
+		return fmt.Errorf("failed to reset built-in Sendmail target %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// resetSendmailTargetBody builds the update that restores a built-in Sendmail target.
+func resetSendmailTargetBody(name string) map[string]interface{} {
+	if name == BuiltinSendmailTargetName {
+		return map[string]interface{}{
+			"mailto-user": []string{"root@pam"},
+			"comment":     builtinSendmailTargetComment,
+			"delete":      []string{"mailto", "author", "disable"},
+		}
+	}
+	return map[string]interface{}{
+		"delete": []string{"mailto", "author", "comment", "disable"},
+	}
+}
+
 // Webhook Target Methods
 
 // ListWebhookTargets lists all Webhook notification target configurations
@@ -527,11 +588,11 @@ func (c *Client) CreateWebhookTarget(ctx context.Context, target *WebhookTarget)
 	if target.Method != "" {
 		body["method"] = target.Method
 	}
-	if target.Secret != "" {
-		body["secret"] = target.Secret
+	if len(target.Secrets) > 0 {
+		body["secret"] = encodeWebhookSecrets(target.Secrets)
 	}
 	if len(target.Headers) > 0 {
-		body["header"] = target.Headers
+		body["header"] = encodeWebhookHeaders(target.Headers)
 	}
 	if target.Comment != "" {
 		body["comment"] = target.Comment
@@ -548,7 +609,9 @@ func (c *Client) CreateWebhookTarget(ctx context.Context, target *WebhookTarget)
 	return nil
 }
 
-// UpdateWebhookTarget updates an existing Webhook notification target
+// UpdateWebhookTarget updates an existing Webhook notification target. Headers
+// and Secrets are only sent when non-nil and replace the stored lists; secrets
+// without a value keep their stored value.
 func (c *Client) UpdateWebhookTarget(ctx context.Context, name string, target *WebhookTarget) error {
 	if name == "" {
 		return fmt.Errorf("target name is required")
@@ -565,11 +628,11 @@ func (c *Client) UpdateWebhookTarget(ctx context.Context, name string, target *W
 	if target.Method != "" {
 		body["method"] = target.Method
 	}
-	if target.Secret != "" {
-		body["secret"] = target.Secret
+	if target.Secrets != nil {
+		body["secret"] = encodeWebhookSecrets(target.Secrets)
 	}
-	if len(target.Headers) > 0 {
-		body["header"] = target.Headers
+	if target.Headers != nil {
+		body["header"] = encodeWebhookHeaders(target.Headers)
 	}
 	if target.Comment != "" {
 		body["comment"] = target.Comment
@@ -577,6 +640,9 @@ func (c *Client) UpdateWebhookTarget(ctx context.Context, name string, target *W
 	if target.Disable != nil {
 		body["disable"] = *target.Disable
 	}
+	if len(target.Delete) > 0 {
+		body["delete"] = target.Delete
+	}
 
 	path := fmt.Sprintf("/config/notifications/endpoints/webhook/%s", url.PathEscape(name))
 	_, err := c.api.Put(ctx, path, body)
@@ -630,6 +696,41 @@ func (c *Client) ListNotificationTargets(ctx context.Context) ([]NotificationTar
 	return targets, nil
 }
 
+// TestTarget sends a test notification through the named target.
+// PBS delivers the test synchronously, so a delivery failure is returned as an error.
+func (c *Client) TestTarget(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("target name is required")
+	}
+
+	path := fmt.Sprintf("/config/notifications/targets/%s/test", url.PathEscape(name))
+	_, err := c.api.Post(ctx, path, map[string]interface{}{})
+	if err != nil {
+		if isAPINotFoundError(err, path) {
+			return fmt.Errorf("this PBS version does not support testing notification targets: %w", err)
+		}
+		return fmt.Errorf("failed to send test notification via target %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// IsNotFoundError reports whether err is PBS's 404 response for a notification entry that does not exist.
+func IsNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "status 404")
+}
+
+// isAPINotFoundError reports whether err is PBS's "path not found" response for apiPath,
+// which indicates the endpoint does not exist on the server rather than a missing object.
+func isAPINotFoundError(err error, apiPath string) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, fmt.Sprintf("Path '/api2/json%s'", apiPath)) && strings.Contains(msg, "not found")
+}
+
 // Notification Matchers
 
 // NotificationMatcher represents a notification matcher (routing rule)
@@ -637,7 +738,7 @@ type NotificationMatcher struct {
 	Name          string   `json:"name"`
 	Targets       []string `json:"target,omitempty"`
 	MatchSeverity []string `json:"match-severity,omitempty"` // info, notice, warning, error
-	MatchField    []string `json:"match-field,omitempty"`    // field=value pairs
+	MatchField    []string `json:"match-field,omitempty"`    // [exact:|regex:]field=value expressions, see ParseMatchField
 	MatchCalendar []string `json:"match-calendar,omitempty"` // calendar IDs
 	Mode          string   `json:"mode,omitempty"`           // all, any
 	InvertMatch   *bool    `json:"invert-match,omitempty"`
@@ -766,6 +867,36 @@ func (c *Client) UpdateNotificationMatcher(ctx context.Context, name string, mat
 	return nil
 }
 
+// ResetNotificationMatcher restores a built-in matcher to its shipped defaults.
+// Built-in matchers cannot be deleted, so the optional properties are cleared via the
+// delete parameter and default-matcher gets its shipped target and comment back.
+func (c *Client) ResetNotificationMatcher(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("matcher name is required")
+	}
+
+	path := fmt.Sprintf("/config/notifications/matchers/%s", url.PathEscape(name))
+	if _, err := c.api.Put(ctx, path, resetNotificationMatcherBody(name)); err != nil {
+		return fmt.Errorf("failed to reset built-in notification matcher %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// resetNotificationMatcherBody builds the update that restores a built-in matcher.
+func resetNotificationMatcherBody(name string) map[string]interface{} {
+	if name == BuiltinMatcherName {
+		return map[string]interface{}{
+			"target":  []string{BuiltinSendmailTargetName},
+			"comment": builtinMatcherComment,
+			"delete":  []string{"match-severity", "match-field", "match-calendar", "mode", "invert-match", "disable"},
+		}
+	}
+	return map[string]interface{}{
+		"delete": []string{"match-severity", "match-field", "match-calendar", "mode", "invert-match", "comment", "disable"},
+	}
+}
+
 // DeleteNotificationMatcher deletes a notification matcher
 func (c *Client) DeleteNotificationMatcher(ctx context.Context, name string) error {
 	if name == "" {
@@ -1,8 +1,16 @@
 package notifications
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/micah/terraform-provider-pbs/pbs/api"
 )
 
 func TestIsAPINotFoundError(t *testing.T) {
@@ -30,6 +38,18 @@ func TestIsAPINotFoundError(t *testing.T) {
 			apiPath:  "/config/notifications/endpoints",
 			expected: false,
 		},
+		{
+			name:     "test endpoint missing",
+			err:      errors.New("API request failed with status 404: {\"data\":null,\"message\":\"Path '/api2/json/config/notifications/targets/mail/test' not found.\\n\"}"),
+			apiPath:  "/config/notifications/targets/mail/test",
+			expected: true,
+		},
+		{
+			name:     "missing target is not a missing endpoint",
+			err:      errors.New("API request failed with status 500: {\"data\":null,\"message\":\"target 'mail' does not exist\\n\"}"),
+			apiPath:  "/config/notifications/targets/mail/test",
+			expected: false,
+		},
 		{
 			name:     "nil error",
 			err:      nil,
@@ -52,3 +72,107 @@ func TestIsAPINotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func TestTestTarget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/api2/json/config/notifications/targets/mail/test":
+			_, _ = w.Write([]byte(`{"data":null}`))
+		case "/api2/json/config/notifications/targets/broken/test":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"data":null,"message":"could not notify via target broken: connection refused\n"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"data":null,"message":"Path '` + r.URL.Path + `' not found.\n"}`))
+		}
+	}))
+	defer srv.Close()
+
+	apiClient, err := api.NewClient(api.Credentials{APIToken: "root@pam!test:secret"}, api.ClientOptions{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client := NewClient(apiClient)
+	ctx := context.Background()
+
+	if err := client.TestTarget(ctx, "mail"); err != nil {
+		t.Fatalf("TestTarget(mail) error = %v", err)
+	}
+
+	tests := map[string]struct {
+		target, want string
+	}{
+		"delivery failure":  {target: "broken", want: "failed to send test notification via target broken"},
+		"unsupported":       {target: "old", want: "does not support testing notification targets"},
+		"empty target name": {target: "", want: "target name is required"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := client.TestTarget(ctx, tc.target)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	if !IsNotFoundError(errors.New("failed to get notification matcher x: API request failed with status 404: {\"data\":null,\"message\":\"matcher 'x' not found\\n\"}")) {
+		t.Error("expected a 404 response to be a not-found error")
+	}
+	for _, err := range []error{
+		nil,
+		errors.New("API request failed with status 500: internal error"),
+		errors.New("failed to get notification matcher x: dial tcp 10.0.0.5:8007: connection refused"),
+	} {
+		if IsNotFoundError(err) {
+			t.Errorf("IsNotFoundError(%v) = true, want false", err)
+		}
+	}
+}
+
+func TestResetNotificationMatcherBody(t *testing.T) {
+	body := resetNotificationMatcherBody(BuiltinMatcherName)
+	if !reflect.DeepEqual(body["target"], []string{BuiltinSendmailTargetName}) {
+		t.Errorf("target = %v, want [%s]", body["target"], BuiltinSendmailTargetName)
+	}
+	if body["comment"] != "Route all notifications to mail-to-root" {
+		t.Errorf("comment = %v, want the shipped comment", body["comment"])
+	}
+	if slices.Contains(body["delete"].([]string), "comment") {
+		t.Errorf("delete = %v, must not clear the restored comment", body["delete"])
+	}
+
+	body = resetNotificationMatcherBody("other")
+	if _, ok := body["target"]; ok {
+		t.Errorf("unexpected target in reset of a non-default matcher: %v", body)
+	}
+	if !slices.Contains(body["delete"].([]string), "comment") {
+		t.Errorf("delete = %v, want comment cleared", body["delete"])
+	}
+}
+
+func TestResetSendmailTargetBody(t *testing.T) {
+	body := resetSendmailTargetBody(BuiltinSendmailTargetName)
+	if !reflect.DeepEqual(body["mailto-user"], []string{"root@pam"}) {
+		t.Errorf("mailto-user = %v, want [root@pam]", body["mailto-user"])
+	}
+	if body["comment"] != "Send mails to root@pam's email address" {
+		t.Errorf("comment = %v, want the shipped comment", body["comment"])
+	}
+	if !reflect.DeepEqual(body["delete"], []string{"mailto", "author", "disable"}) {
+		t.Errorf("delete = %v", body["delete"])
+	}
+
+	body = resetSendmailTargetBody("other")
+	if _, ok := body["mailto-user"]; ok {
+		t.Errorf("unexpected mailto-user in reset of a non-default target: %v", body)
+	}
+	if !slices.Contains(body["delete"].([]string), "comment") {
+		t.Errorf("delete = %v, want comment cleared", body["delete"])
+	}
+}
@@ -0,0 +1,287 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Severities lists the notification severities known to PBS
+var Severities = []string{"info", "notice", "warning", "error", "unknown"}
+
+// Event describes a notification for local route evaluation
+type Event struct {
+	Severity  string
+	Fields    map[string]string
+	Timestamp time.Time
+}
+
+// RouteResult is the outcome of evaluating all matchers against an event
+type RouteResult struct {
+	Matchers []string // names of the matchers that matched, in evaluation order
+	Targets  []string // de-duplicated target names of the matched matchers
+}
+
+// RouteNotification evaluates the matchers locally the same way PBS does when
+// dispatching a notification. Disabled matchers are skipped.
+func RouteNotification(matchers []NotificationMatcher, event Event) (*RouteResult, error) {
+	result := &RouteResult{Matchers: []string{}, Targets: []string{}}
+	seen := make(map[string]bool)
+
+	for i := range matchers {
+		m := &matchers[i]
+		if m.Disable != nil && *m.Disable {
+			continue
+		}
+
+		matched, err := m.Matches(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate notification matcher %s: %w", m.Name, err)
+		}
+		if !matched {
+			continue
+		}
+
+		result.Matchers = append(result.Matchers, m.Name)
+		for _, target := range m.Targets {
+			if !seen[target] {
+				seen[target] = true
+				result.Targets = append(result.Targets, target)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Matches reports whether the matcher would route the event. Conditions are
+// combined according to the matcher mode ("all" by default) and the final
+// result is inverted when invert-match is set.
+func (m *NotificationMatcher) Matches(event Event) (bool, error) {
+	anyMode := m.Mode == "any"
+	isMatch := !anyMode
+
+	apply := func(current, next bool) bool {
+		if anyMode {
+			return current || next
+		}
+		return current && next
+	}
+
+	if len(m.MatchSeverity) > 0 {
+		matched, err := checkMatches(anyMode, m.MatchSeverity, func(expr string) (bool, error) {
+			return matchSeverity(expr, event.Severity), nil
+		})
+		if err != nil {
+			return false, err
+		}
+		isMatch = apply(isMatch, matched)
+	}
+
+	if len(m.MatchField) > 0 {
+		matched, err := checkMatches(anyMode, m.MatchField, func(expr string) (bool, error) {
+			return matchFieldExpr(expr, event.Fields)
+		})
+		if err != nil {
+			return false, err
+		}
+		isMatch = apply(isMatch, matched)
+	}
+
+	if len(m.MatchCalendar) > 0 {
+		matched, err := checkMatches(anyMode, m.MatchCalendar, func(expr string) (bool, error) {
+			d, err := ParseDailyDuration(expr)
+			if err != nil {
+				return false, err
+			}
+			return d.Contains(event.Timestamp), nil
+		})
+		if err != nil {
+			return false, err
+		}
+		isMatch = apply(isMatch, matched)
+	}
+
+	invert := m.InvertMatch != nil && *m.InvertMatch
+	return isMatch != invert, nil
+}
+
+// checkMatches evaluates a list of match directives of one kind using the matcher mode.
+func checkMatches(anyMode bool, exprs []string, match func(string) (bool, error)) (bool, error) {
+	for _, expr := range exprs {
+		matched, err := match(expr)
+		if err != nil {
+			return false, err
+		}
+		if anyMode && matched {
+			return true, nil
+		}
+		if !anyMode && !matched {
+			return false, nil
+		}
+	}
+	return !anyMode, nil
+}
+
+// matchSeverity checks a comma-separated match-severity entry.
+func matchSeverity(expr, severity string) bool {
+	for _, s := range strings.Split(expr, ",") {
+		if strings.TrimSpace(s) == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFieldExpr checks a match-field expression against the event metadata.
+// A field missing from the event never matches.
+func matchFieldExpr(expr string, fields map[string]string) (bool, error) {
+	m, err := ParseMatchField(expr)
+	if err != nil {
+		return false, err
+	}
+
+	value, ok := fields[m.Field]
+	if !ok {
+		return false, nil
+	}
+
+	if m.Type == MatchFieldTypeRegex {
+		re, err := regexp.Compile(m.Values[0])
+		if err != nil {
+			return false, fmt.Errorf("invalid regex in match-field %q: %w", expr, err)
+		}
+		return re.MatchString(value), nil
+	}
+
+	for _, v := range m.Values {
+		if v == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DailyDuration is a parsed match-calendar expression such as "mon..fri 8:00-17:30".
+// It describes a time window that repeats on the selected weekdays.
+type DailyDuration struct {
+	Weekdays [7]bool // indexed by time.Weekday
+	Start    int     // minutes since midnight, inclusive
+	End      int     // minutes since midnight, exclusive
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseDailyDuration parses a match-calendar expression of the form
+// "[weekdays] start-end", where weekdays is a comma-separated list of days or
+// day ranges ("mon..fri" or "mon-fri") and start/end are "H" or "H:MM".
+func ParseDailyDuration(expr string) (*DailyDuration, error) {
+	parts := strings.Fields(expr)
+	if len(parts) == 0 || len(parts) > 2 {
+		return nil, fmt.Errorf("invalid match-calendar %q: expected [weekdays] start-end", expr)
+	}
+
+	d := &DailyDuration{}
+	span := parts[len(parts)-1]
+
+	if len(parts) == 2 {
+		if err := d.parseWeekdays(parts[0]); err != nil {
+			return nil, fmt.Errorf("invalid match-calendar %q: %w", expr, err)
+		}
+	} else {
+		for i := range d.Weekdays {
+			d.Weekdays[i] = true
+		}
+	}
+
+	startStr, endStr, ok := strings.Cut(span, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid match-calendar %q: expected a start-end time range", expr)
+	}
+
+	var err error
+	if d.Start, err = parseClock(startStr); err != nil {
+		return nil, fmt.Errorf("invalid match-calendar %q: %w", expr, err)
+	}
+	if d.End, err = parseClock(endStr); err != nil {
+		return nil, fmt.Errorf("invalid match-calendar %q: %w", expr, err)
+	}
+	if d.End <= d.Start {
+		return nil, fmt.Errorf("invalid match-calendar %q: end time must be after start time", expr)
+	}
+
+	return d, nil
+}
+
+func (d *DailyDuration) parseWeekdays(spec string) error {
+	for _, item := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(item, "..")
+		if !isRange {
+			from, to, isRange = strings.Cut(item, "-")
+		}
+
+		start, ok := weekdayNames[strings.ToLower(from)]
+		if !ok {
+			return fmt.Errorf("unknown weekday %q", from)
+		}
+		end := start
+		if isRange {
+			if end, ok = weekdayNames[strings.ToLower(to)]; !ok {
+				return fmt.Errorf("unknown weekday %q", to)
+			}
+		}
+
+		// Ranges may wrap around the end of the week, e.g. "sat..mon"
+		for day := start; ; day = (day + 1) % 7 {
+			d.Weekdays[day] = true
+			if day == end {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// parseClock parses "H" or "H:MM" into minutes since midnight. "24" and
+// "24:00" are accepted so a window can extend to the end of the day.
+func parseClock(s string) (int, error) {
+	hourStr, minuteStr, hasMinutes := strings.Cut(s, ":")
+
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("invalid hour %q", hourStr)
+	}
+
+	minute := 0
+	if hasMinutes {
+		minute, err = strconv.Atoi(minuteStr)
+		if err != nil || len(minuteStr) != 2 || minute < 0 || minute > 59 {
+			return 0, fmt.Errorf("invalid minute %q", minuteStr)
+		}
+	}
+	if hour == 24 && minute != 0 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+
+	return hour*60 + minute, nil
+}
+
+// Contains reports whether t falls into the window, evaluated in t's location.
+func (d *DailyDuration) Contains(t time.Time) bool {
+	if !d.Weekdays[t.Weekday()] {
+		return false
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return minutes >= d.Start && minutes < d.End
+}
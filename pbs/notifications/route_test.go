@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestNotificationMatcherMatches(t *testing.T) {
+	// Wednesday 2025-01-15 10:30 UTC
+	ts := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	event := Event{
+		Severity:  "error",
+		Fields:    map[string]string{"type": "gc", "datastore": "store1", "hostname": "pbs-01"},
+		Timestamp: ts,
+	}
+
+	tests := []struct {
+		name    string
+		matcher NotificationMatcher
+		want    bool
+	}{
+		{name: "no conditions in all mode", matcher: NotificationMatcher{}, want: true},
+		{name: "no conditions in any mode", matcher: NotificationMatcher{Mode: "any"}, want: false},
+		{name: "severity list", matcher: NotificationMatcher{MatchSeverity: []string{"warning,error"}}, want: true},
+		{name: "severity mismatch", matcher: NotificationMatcher{MatchSeverity: []string{"info"}}, want: false},
+		{name: "exact field", matcher: NotificationMatcher{MatchField: []string{"exact:type=prune,gc"}}, want: true},
+		{name: "legacy field", matcher: NotificationMatcher{MatchField: []string{"datastore=store2"}}, want: false},
+		{name: "regex field", matcher: NotificationMatcher{MatchField: []string{"regex:hostname=^pbs-\\d+$"}}, want: true},
+		{name: "missing field", matcher: NotificationMatcher{MatchField: []string{"exact:job-id=nightly"}}, want: false},
+		{
+			name: "all mode requires every condition",
+			matcher: NotificationMatcher{
+				MatchSeverity: []string{"error"},
+				MatchField:    []string{"exact:type=verify"},
+			},
+			want: false,
+		},
+		{
+			name: "any mode accepts one condition",
+			matcher: NotificationMatcher{
+				Mode:          "any",
+				MatchSeverity: []string{"error"},
+				MatchField:    []string{"exact:type=verify"},
+			},
+			want: true,
+		},
+		{name: "calendar inside window", matcher: NotificationMatcher{MatchCalendar: []string{"mon..fri 8-17"}}, want: true},
+		{name: "calendar outside weekdays", matcher: NotificationMatcher{MatchCalendar: []string{"sat,sun 0-24"}}, want: false},
+		{name: "calendar outside hours", matcher: NotificationMatcher{MatchCalendar: []string{"10:31-12:00"}}, want: false},
+		{name: "inverted", matcher: NotificationMatcher{MatchSeverity: []string{"info"}, InvertMatch: boolPtr(true)}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.matcher.Matches(event)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteNotification(t *testing.T) {
+	matchers := []NotificationMatcher{
+		{Name: "default-matcher", Targets: []string{"mail-to-root"}},
+		{Name: "critical", MatchSeverity: []string{"error"}, Targets: []string{"pagerduty", "mail-to-root"}},
+		{Name: "disabled", Targets: []string{"slack"}, Disable: boolPtr(true)},
+		{Name: "prune-only", MatchField: []string{"exact:type=prune"}, Targets: []string{"ops"}},
+	}
+
+	result, err := RouteNotification(matchers, Event{Severity: "error", Fields: map[string]string{"type": "gc"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"default-matcher", "critical"}; !reflect.DeepEqual(result.Matchers, want) {
+		t.Fatalf("matchers = %v, want %v", result.Matchers, want)
+	}
+	if want := []string{"mail-to-root", "pagerduty"}; !reflect.DeepEqual(result.Targets, want) {
+		t.Fatalf("targets = %v, want %v", result.Targets, want)
+	}
+}
+
+func TestParseDailyDuration(t *testing.T) {
+	valid := []string{"8-12", "8:00-15:30", "mon-fri 9:00-17:00", "sun,tue-wed,fri 9-17", "sat..mon 0-24"}
+	for _, expr := range valid {
+		if _, err := ParseDailyDuration(expr); err != nil {
+			t.Errorf("ParseDailyDuration(%q) unexpected error: %v", expr, err)
+		}
+	}
+
+	invalid := []string{"", "mon", "12-8", "funday 8-12", "8:5-9", "25-26", "mon fri 8-12"}
+	for _, expr := range invalid {
+		if _, err := ParseDailyDuration(expr); err == nil {
+			t.Errorf("ParseDailyDuration(%q) expected error", expr)
+		}
+	}
+
+	d, err := ParseDailyDuration("sat..mon 22-24")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !d.Contains(time.Date(2025, 1, 13, 23, 0, 0, 0, time.UTC)) { // Monday
+		t.Errorf("expected wrapped weekday range to include Monday")
+	}
+	if d.Contains(time.Date(2025, 1, 14, 23, 0, 0, 0, time.UTC)) { // Tuesday
+		t.Errorf("expected wrapped weekday range to exclude Tuesday")
+	}
+}
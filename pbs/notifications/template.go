@@ -0,0 +1,980 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PBS renders webhook URLs, bodies and header values as Handlebars templates.
+// This file implements the subset PBS supports so that templates can be checked
+// and previewed at plan time: variables, the json, escape and url-encode helpers,
+// the if, unless, each and with block helpers, comparison subexpressions and
+// comments. Values are never HTML-escaped, matching PBS.
+
+// TemplateVariables lists the top-level variables PBS passes to webhook templates
+var TemplateVariables = []string{"title", "message", "severity", "timestamp", "fields", "secrets"}
+
+// inlineHelpers maps the helpers usable in {{ helper args }} and subexpressions
+// to their minimum and maximum argument count (-1 for unbounded)
+var inlineHelpers = map[string][2]int{
+	"json":       {1, 1},
+	"escape":     {1, 1},
+	"url-encode": {1, 1},
+	"lookup":     {2, 2},
+	"eq":         {2, 2},
+	"ne":         {2, 2},
+	"gt":         {2, 2},
+	"gte":        {2, 2},
+	"lt":         {2, 2},
+	"lte":        {2, 2},
+	"and":        {1, -1},
+	"or":         {1, -1},
+	"not":        {1, 1},
+	"len":        {1, 1},
+}
+
+// blockHelpers lists the helpers usable as {{#helper arg}}...{{/helper}}
+var blockHelpers = map[string]bool{"if": true, "unless": true, "each": true, "with": true}
+
+// Template is a parsed webhook template
+type Template struct {
+	nodes []tmplNode
+}
+
+type tmplNode interface{}
+
+type textNode string
+
+type exprNode struct {
+	expr tmplExpr
+}
+
+type blockNode struct {
+	helper  string
+	arg     tmplExpr
+	body    []tmplNode
+	inverse []tmplNode
+}
+
+type tmplExpr interface{}
+
+type literalExpr struct {
+	value any
+}
+
+type callExpr struct {
+	helper string
+	args   []tmplExpr
+}
+
+// pathExpr is a variable reference such as title, fields.datastore, ../title,
+// @root.secrets.token or @index
+type pathExpr struct {
+	segments []string
+	parent   int
+	root     bool
+	data     string
+}
+
+// ParseTemplate parses a Handlebars template as rendered by PBS
+func ParseTemplate(src string) (*Template, error) {
+	p := &templateParser{}
+	if err := p.parse(src); err != nil {
+		return nil, err
+	}
+	return &Template{nodes: p.root}, nil
+}
+
+type parseFrame struct {
+	block   *blockNode
+	inElse  bool
+	chained bool
+}
+
+type templateParser struct {
+	root      []tmplNode
+	stack     []*parseFrame
+	trimStart bool
+}
+
+func (p *templateParser) append(n tmplNode) {
+	if len(p.stack) == 0 {
+		p.root = append(p.root, n)
+		return
+	}
+	f := p.stack[len(p.stack)-1]
+	if f.inElse {
+		f.block.inverse = append(f.block.inverse, n)
+	} else {
+		f.block.body = append(f.block.body, n)
+	}
+}
+
+func (p *templateParser) nodes() *[]tmplNode {
+	if len(p.stack) == 0 {
+		return &p.root
+	}
+	f := p.stack[len(p.stack)-1]
+	if f.inElse {
+		return &f.block.inverse
+	}
+	return &f.block.body
+}
+
+func (p *templateParser) text(s string) {
+	if p.trimStart {
+		s = strings.TrimLeft(s, " \t\r\n")
+		p.trimStart = false
+	}
+	if s != "" {
+		p.append(textNode(s))
+	}
+}
+
+// trimEnd removes trailing whitespace from the preceding text node for {{~
+func (p *templateParser) trimEnd() {
+	nodes := p.nodes()
+	if len(*nodes) == 0 {
+		return
+	}
+	if t, ok := (*nodes)[len(*nodes)-1].(textNode); ok {
+		(*nodes)[len(*nodes)-1] = textNode(strings.TrimRight(string(t), " \t\r\n"))
+	}
+}
+
+func (p *templateParser) parse(src string) error {
+	for len(src) > 0 {
+		start := strings.Index(src, "{{")
+		if start < 0 {
+			p.text(src)
+			break
+		}
+		if start > 0 && src[start-1] == '\\' {
+			p.text(src[:start-1] + "{{")
+			src = src[start+2:]
+			continue
+		}
+		p.text(src[:start])
+		src = src[start:]
+
+		var content, closer string
+		switch {
+		case strings.HasPrefix(src, "{{!--"):
+			closer = "--}}"
+		case strings.HasPrefix(src, "{{!"):
+			closer = "}}"
+		case strings.HasPrefix(src, "{{{"):
+			closer = "}}}"
+		default:
+			closer = "}}"
+		}
+		end := strings.Index(src[2:], closer)
+		if end < 0 {
+			return fmt.Errorf("unclosed tag %q", truncate(src, 20))
+		}
+		content = src[2 : 2+end]
+		src = src[2+end+len(closer):]
+
+		if strings.HasPrefix(content, "!") {
+			continue
+		}
+		if closer == "}}}" {
+			content = strings.TrimPrefix(content, "{")
+		}
+		if strings.HasPrefix(content, "~") {
+			content = content[1:]
+			p.trimEnd()
+		}
+		if strings.HasSuffix(content, "~") {
+			content = content[:len(content)-1]
+			p.trimStart = true
+		}
+		if err := p.tag(strings.TrimSpace(content)); err != nil {
+			return err
+		}
+	}
+
+	if len(p.stack) > 0 {
+		return fmt.Errorf("block {{#%s}} is never closed", p.stack[len(p.stack)-1].block.helper)
+	}
+	return nil
+}
+
+func (p *templateParser) tag(content string) error {
+	switch {
+	case content == "":
+		return fmt.Errorf("empty tag {{}}")
+	case strings.HasPrefix(content, ">"):
+		return fmt.Errorf("partials are not supported in webhook templates: {{%s}}", content)
+	case strings.HasPrefix(content, "#"):
+		block, err := parseBlockOpen(strings.TrimSpace(content[1:]))
+		if err != nil {
+			return err
+		}
+		p.append(block)
+		p.stack = append(p.stack, &parseFrame{block: block})
+		return nil
+	case strings.HasPrefix(content, "/"):
+		return p.closeBlock(strings.TrimSpace(content[1:]))
+	case content == "else" || content == "^" || strings.HasPrefix(content, "else "):
+		return p.elseTag(strings.TrimSpace(strings.TrimPrefix(content, "else")))
+	}
+
+	expr, err := parseMustache(content)
+	if err != nil {
+		return err
+	}
+	p.append(&exprNode{expr: expr})
+	return nil
+}
+
+func (p *templateParser) elseTag(chain string) error {
+	if len(p.stack) == 0 {
+		return fmt.Errorf("{{else}} outside of a block")
+	}
+	f := p.stack[len(p.stack)-1]
+	if f.inElse {
+		return fmt.Errorf("duplicate {{else}} in {{#%s}}", f.block.helper)
+	}
+	f.inElse = true
+	if chain == "" || chain == "^" {
+		return nil
+	}
+
+	// {{else if x}} opens a nested block that is closed by the outer {{/if}}
+	block, err := parseBlockOpen(chain)
+	if err != nil {
+		return err
+	}
+	p.append(block)
+	p.stack = append(p.stack, &parseFrame{block: block, chained: true})
+	return nil
+}
+
+func (p *templateParser) closeBlock(name string) error {
+	for len(p.stack) > 0 && p.stack[len(p.stack)-1].chained {
+		p.stack = p.stack[:len(p.stack)-1]
+	}
+	if len(p.stack) == 0 {
+		return fmt.Errorf("{{/%s}} does not close any block", name)
+	}
+	f := p.stack[len(p.stack)-1]
+	if f.block.helper != name {
+		return fmt.Errorf("{{/%s}} closes {{#%s}}", name, f.block.helper)
+	}
+	p.stack = p.stack[:len(p.stack)-1]
+	return nil
+}
+
+func parseBlockOpen(content string) (*blockNode, error) {
+	tokens, err := tokenizeTag(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("block tag without helper name")
+	}
+	helper := tokens[0]
+	if !blockHelpers[helper] {
+		return nil, fmt.Errorf("unknown block helper {{#%s}}; supported are if, unless, each and with", helper)
+	}
+	args, err := parseArgs(tokens[1:])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf("{{#%s}} takes exactly one argument", helper)
+	}
+	return &blockNode{helper: helper, arg: args[0]}, nil
+}
+
+func parseMustache(content string) (tmplExpr, error) {
+	tokens, err := tokenizeTag(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		if _, ok := inlineHelpers[tokens[0]]; ok {
+			return nil, fmt.Errorf("helper %q requires an argument", tokens[0])
+		}
+		return parseOperand(tokens[0])
+	}
+	return parseCall(tokens)
+}
+
+func parseCall(tokens []string) (tmplExpr, error) {
+	helper := tokens[0]
+	arity, ok := inlineHelpers[helper]
+	if !ok {
+		return nil, fmt.Errorf("unknown helper %q; supported are %s", helper, strings.Join(sortedKeys(inlineHelpers), ", "))
+	}
+	args, err := parseArgs(tokens[1:])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) < arity[0] || (arity[1] >= 0 && len(args) > arity[1]) {
+		return nil, fmt.Errorf("helper %q called with %d arguments", helper, len(args))
+	}
+	return &callExpr{helper: helper, args: args}, nil
+}
+
+func parseArgs(tokens []string) ([]tmplExpr, error) {
+	var args []tmplExpr
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] == ")" {
+			return nil, fmt.Errorf("unbalanced )")
+		}
+		if tokens[i] != "(" {
+			arg, err := parseOperand(tokens[i])
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			continue
+		}
+
+		depth, j := 1, i+1
+		for ; j < len(tokens) && depth > 0; j++ {
+			switch tokens[j] {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+		}
+		if depth > 0 {
+			return nil, fmt.Errorf("unbalanced (")
+		}
+		inner := tokens[i+1 : j-1]
+		if len(inner) == 0 {
+			return nil, fmt.Errorf("empty subexpression")
+		}
+		call, err := parseCall(inner)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, call)
+		i = j - 1
+	}
+	return args, nil
+}
+
+func parseOperand(token string) (tmplExpr, error) {
+	if strings.HasPrefix(token, `"`) || strings.HasPrefix(token, "'") {
+		return &literalExpr{value: token[1 : len(token)-1]}, nil
+	}
+	switch token {
+	case "true":
+		return &literalExpr{value: true}, nil
+	case "false":
+		return &literalExpr{value: false}, nil
+	case "null":
+		return &literalExpr{value: nil}, nil
+	}
+	if n, err := strconv.ParseFloat(token, 64); err == nil {
+		return &literalExpr{value: n}, nil
+	}
+	return parsePath(token)
+}
+
+func parsePath(token string) (*pathExpr, error) {
+	p := &pathExpr{}
+	rest := token
+
+	for strings.HasPrefix(rest, "../") {
+		p.parent++
+		rest = rest[3:]
+	}
+	if strings.HasPrefix(rest, "@root") {
+		p.root = true
+		rest = strings.TrimPrefix(strings.TrimPrefix(rest, "@root"), ".")
+	} else if strings.HasPrefix(rest, "@") {
+		p.data = rest[1:]
+		if p.data == "" {
+			return nil, fmt.Errorf("invalid variable %q", token)
+		}
+		return p, nil
+	}
+	if rest == "this" || rest == "." || rest == "" {
+		return p, nil
+	}
+	rest = strings.TrimPrefix(strings.TrimPrefix(rest, "this."), "./")
+
+	for rest != "" {
+		var seg string
+		if strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ in variable %q", token)
+			}
+			seg, rest = rest[1:end], rest[end+1:]
+		} else {
+			end := strings.IndexAny(rest, "./")
+			if end < 0 {
+				end = len(rest)
+			}
+			seg, rest = rest[:end], rest[end:]
+		}
+		if seg == "" {
+			return nil, fmt.Errorf("invalid variable %q", token)
+		}
+		p.segments = append(p.segments, seg)
+		if rest != "" {
+			if rest[0] != '.' && rest[0] != '/' {
+				return nil, fmt.Errorf("invalid variable %q", token)
+			}
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("invalid variable %q", token)
+			}
+		}
+	}
+	return p, nil
+}
+
+// tokenizeTag splits tag content into words, quoted strings and parentheses
+func tokenizeTag(content string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(content[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in {{%s}}", content)
+			}
+			tokens = append(tokens, content[i:i+end+2])
+			i += end + 2
+		default:
+			j := i
+			for j < len(content) && !strings.ContainsRune(" \t\r\n()", rune(content[j])) {
+				if content[j] == '[' {
+					if k := strings.IndexByte(content[j:], ']'); k > 0 {
+						j += k
+					}
+				}
+				j++
+			}
+			tokens = append(tokens, content[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// SecretReferences returns the sorted names of all secrets.<name> references
+func (t *Template) SecretReferences() []string {
+	seen := map[string]bool{}
+	walkPaths(t.nodes, 0, func(p *pathExpr, depth int) {
+		if rootLevel(p, depth) && len(p.segments) >= 2 && p.segments[0] == "secrets" {
+			seen[p.segments[1]] = true
+		}
+	})
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that all top-level variables are known to PBS.
+func (t *Template) Validate() error {
+	var unknown []string
+	walkPaths(t.nodes, 0, func(p *pathExpr, depth int) {
+		if rootLevel(p, depth) && len(p.segments) > 0 && !contains(TemplateVariables, p.segments[0]) {
+			unknown = append(unknown, p.segments[0])
+		}
+	})
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown template variable %q; PBS provides %s", unknown[0], strings.Join(TemplateVariables, ", "))
+	}
+	return nil
+}
+
+// UnmanagedSecrets returns the referenced secrets that are not in secretNames.
+// They may still exist in PBS, e.g. when they were set outside Terraform.
+func (t *Template) UnmanagedSecrets(secretNames []string) []string {
+	var names []string
+	for _, name := range t.SecretReferences() {
+		if !contains(secretNames, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// rootLevel reports whether p is resolved against the notification itself rather
+// than the context of an enclosing each or with block
+func rootLevel(p *pathExpr, depth int) bool {
+	if p.data != "" {
+		return false
+	}
+	return p.root || (depth > 0 && p.parent >= depth) || (depth == 0 && p.parent == 0)
+}
+
+func walkPaths(nodes []tmplNode, depth int, fn func(*pathExpr, int)) {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *exprNode:
+			walkExprPaths(n.expr, depth, fn)
+		case *blockNode:
+			walkExprPaths(n.arg, depth, fn)
+			inner := depth
+			if n.helper == "each" || n.helper == "with" {
+				inner++
+			}
+			walkPaths(n.body, inner, fn)
+			walkPaths(n.inverse, depth, fn)
+		}
+	}
+}
+
+func walkExprPaths(e tmplExpr, depth int, fn func(*pathExpr, int)) {
+	switch e := e.(type) {
+	case *pathExpr:
+		fn(e, depth)
+	case *callExpr:
+		for _, arg := range e.args {
+			walkExprPaths(arg, depth, fn)
+		}
+	}
+}
+
+// Render renders the template with data as the root context
+func (t *Template) Render(data map[string]any) (string, error) {
+	r := &renderer{stack: []renderFrame{{value: data}}}
+	var sb strings.Builder
+	if err := r.render(&sb, t.nodes); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+type renderFrame struct {
+	value any
+	data  map[string]any
+}
+
+type renderer struct {
+	stack []renderFrame
+}
+
+func (r *renderer) render(sb *strings.Builder, nodes []tmplNode) error {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case textNode:
+			sb.WriteString(string(n))
+		case *exprNode:
+			v, err := r.eval(n.expr)
+			if err != nil {
+				return err
+			}
+			sb.WriteString(formatValue(v))
+		case *blockNode:
+			if err := r.renderBlock(sb, n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *renderer) renderBlock(sb *strings.Builder, b *blockNode) error {
+	v, err := r.eval(b.arg)
+	if err != nil {
+		return err
+	}
+
+	switch b.helper {
+	case "if":
+		if truthy(v) {
+			return r.render(sb, b.body)
+		}
+		return r.render(sb, b.inverse)
+	case "unless":
+		if !truthy(v) {
+			return r.render(sb, b.body)
+		}
+		return r.render(sb, b.inverse)
+	case "with":
+		if !truthy(v) {
+			return r.render(sb, b.inverse)
+		}
+		return r.withFrame(renderFrame{value: v}, func() error { return r.render(sb, b.body) })
+	}
+
+	// each
+	switch items := v.(type) {
+	case []any:
+		if len(items) == 0 {
+			return r.render(sb, b.inverse)
+		}
+		for i, item := range items {
+			frame := renderFrame{value: item, data: map[string]any{"index": i, "first": i == 0, "last": i == len(items)-1}}
+			if err := r.withFrame(frame, func() error { return r.render(sb, b.body) }); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		if len(items) == 0 {
+			return r.render(sb, b.inverse)
+		}
+		keys := make([]string, 0, len(items))
+		for k := range items {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			frame := renderFrame{value: items[k], data: map[string]any{"key": k, "index": i, "first": i == 0, "last": i == len(keys)-1}}
+			if err := r.withFrame(frame, func() error { return r.render(sb, b.body) }); err != nil {
+				return err
+			}
+		}
+	default:
+		return r.render(sb, b.inverse)
+	}
+	return nil
+}
+
+func (r *renderer) withFrame(f renderFrame, fn func() error) error {
+	r.stack = append(r.stack, f)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+	return fn()
+}
+
+func (r *renderer) eval(e tmplExpr) (any, error) {
+	switch e := e.(type) {
+	case *literalExpr:
+		return e.value, nil
+	case *pathExpr:
+		return r.lookup(e), nil
+	case *callExpr:
+		args := make([]any, len(e.args))
+		for i, arg := range e.args {
+			v, err := r.eval(arg)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+		return callHelper(e.helper, args)
+	}
+	return nil, fmt.Errorf("unexpected expression %T", e)
+}
+
+func (r *renderer) lookup(p *pathExpr) any {
+	if p.data != "" {
+		for i := len(r.stack) - 1; i >= 0; i-- {
+			if v, ok := r.stack[i].data[p.data]; ok {
+				return v
+			}
+		}
+		return nil
+	}
+
+	var v any
+	switch {
+	case p.root:
+		v = r.stack[0].value
+	case p.parent >= len(r.stack):
+		return nil
+	default:
+		v = r.stack[len(r.stack)-1-p.parent].value
+	}
+	for _, seg := range p.segments {
+		v = index(v, seg)
+	}
+	return v
+}
+
+func index(v any, key string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return v[key]
+	case map[string]string:
+		if s, ok := v[key]; ok {
+			return s
+		}
+	case []any:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(v) {
+			return v[i]
+		}
+	}
+	return nil
+}
+
+func callHelper(helper string, args []any) (any, error) {
+	switch helper {
+	case "json":
+		b, err := json.Marshal(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("json helper: %w", err)
+		}
+		return string(b), nil
+	case "escape":
+		b, _ := json.Marshal(formatValue(args[0]))
+		return string(b[1 : len(b)-1]), nil
+	case "url-encode":
+		return strings.ReplaceAll(url.QueryEscape(formatValue(args[0])), "+", "%20"), nil
+	case "lookup":
+		return index(args[0], formatValue(args[1])), nil
+	case "eq":
+		return equal(args[0], args[1]), nil
+	case "ne":
+		return !equal(args[0], args[1]), nil
+	case "gt", "gte", "lt", "lte":
+		c := compare(args[0], args[1])
+		return map[string]bool{"gt": c > 0, "gte": c >= 0, "lt": c < 0, "lte": c <= 0}[helper], nil
+	case "and":
+		for _, a := range args {
+			if !truthy(a) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "or":
+		for _, a := range args {
+			if truthy(a) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "not":
+		return !truthy(args[0]), nil
+	case "len":
+		switch v := args[0].(type) {
+		case string:
+			return len(v), nil
+		case []any:
+			return len(v), nil
+		case map[string]any:
+			return len(v), nil
+		}
+		return 0, nil
+	}
+	return nil, fmt.Errorf("unknown helper %q", helper)
+}
+
+func toNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func equal(a, b any) bool {
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			return x == y
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func compare(a, b any) int {
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(formatValue(a), formatValue(b))
+}
+
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	if n, ok := toNumber(v); ok {
+		return n != 0
+	}
+	return true
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any, map[string]any:
+		return "[object]"
+	}
+	return fmt.Sprint(v)
+}
+
+// SampleNotification returns the template data of a typical PBS notification,
+// used to preview templates. Secret values are replaced by placeholders. The
+// message contains newlines and quotes like real PBS messages, so bodies that
+// embed it in JSON without the escape helper fail to render valid JSON.
+func SampleNotification(secretNames []string) map[string]any {
+	secrets := make(map[string]any, len(secretNames))
+	for _, name := range secretNames {
+		secrets[name] = "<secret:" + name + ">"
+	}
+
+	return map[string]any{
+		"title":     "Verify Datastore 'store1' successful",
+		"message":   "Job ID:    v-3fb332a6-ba43\nDatastore: store1\n\nVerification of \"vm/100\" successful.\n",
+		"severity":  "info",
+		"timestamp": int64(1735689600),
+		"fields": map[string]any{
+			"datastore": "store1",
+			"hostname":  "pbs",
+			"job-id":    "v-3fb332a6-ba43",
+			"type":      "verify",
+		},
+		"secrets": secrets,
+	}
+}
+
+// WebhookPreview is a webhook request rendered with SampleNotification
+type WebhookPreview struct {
+	URL     string
+	Body    string
+	Headers map[string]string
+}
+
+// TemplateProblem describes an invalid template in one part of a webhook target.
+// Field is "url", "body" or "headers"; Key is the header name for headers.
+// Warning is set for problems that do not prevent rendering.
+type TemplateProblem struct {
+	Field   string
+	Key     string
+	Message string
+	Warning bool
+}
+
+// PreviewWebhook parses, validates and renders the URL, body and header value
+// templates of a webhook target. A body that is JSON, judged by its
+// Content-Type header or its first character, must render to valid JSON.
+// References to secrets outside secretNames are reported as warnings, since the
+// secret may have been set in PBS directly. The preview is nil when any other
+// problem is found.
+func PreviewWebhook(target *WebhookTarget, secretNames []string) (*WebhookPreview, []TemplateProblem) {
+	var problems []TemplateProblem
+	failed := false
+
+	render := func(field, key, src string) string {
+		tmpl, err := ParseTemplate(src)
+		if err == nil {
+			err = tmpl.Validate()
+		}
+		var out string
+		if err == nil {
+			unmanaged := tmpl.UnmanagedSecrets(secretNames)
+			for _, name := range unmanaged {
+				problems = append(problems, TemplateProblem{
+					Field: field,
+					Key:   key,
+					Message: fmt.Sprintf("template references secrets.%s, which is not configured on this target; "+
+						"it only renders if the secret was set in PBS outside of Terraform", name),
+					Warning: true,
+				})
+			}
+			out, err = tmpl.Render(SampleNotification(append(slices.Clone(secretNames), unmanaged...)))
+		}
+		if err != nil {
+			problems = append(problems, TemplateProblem{Field: field, Key: key, Message: err.Error()})
+			failed = true
+		}
+		return out
+	}
+
+	preview := &WebhookPreview{Headers: map[string]string{}}
+	preview.URL = render("url", "", target.URL)
+	preview.Body = render("body", "", target.Body)
+
+	contentType := ""
+	for _, name := range sortedKeys(target.Headers) {
+		preview.Headers[name] = render("headers", name, target.Headers[name])
+		if strings.EqualFold(name, "Content-Type") {
+			contentType = preview.Headers[name]
+		}
+	}
+
+	if failed {
+		return nil, problems
+	}
+
+	trimmed := strings.TrimSpace(preview.Body)
+	isJSON := strings.Contains(strings.ToLower(contentType), "json") || strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+	if isJSON && trimmed != "" {
+		var v any
+		if err := json.Unmarshal([]byte(preview.Body), &v); err != nil {
+			return nil, append(problems, TemplateProblem{
+				Field: "body",
+				Message: fmt.Sprintf("body does not render to valid JSON for a sample notification: %s. "+
+					"Notification titles and messages contain quotes and newlines; use {{ escape message }} inside JSON strings "+
+					"and {{ json fields }} for whole values.", err.Error()),
+			})
+		}
+	}
+
+	return preview, problems
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
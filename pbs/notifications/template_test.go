@@ -0,0 +1,189 @@
+package notifications
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTemplateRender(t *testing.T) {
+	data := SampleNotification([]string{"token"})
+
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{name: "plain text", src: "hello", expected: "hello"},
+		{name: "variable", src: "{{ severity }}: {{title}}", expected: "info: Verify Datastore 'store1' successful"},
+		{name: "no html escaping", src: "{{ message }}", expected: data["message"].(string)},
+		{name: "nested field", src: "{{ fields.datastore }}/{{ fields.[job-id] }}", expected: "store1/v-3fb332a6-ba43"},
+		{name: "dashed field", src: "{{ fields.job-id }}", expected: "v-3fb332a6-ba43"},
+		{name: "number", src: "{{ timestamp }}", expected: "1735689600"},
+		{name: "secret placeholder", src: "Bearer {{ secrets.token }}", expected: "Bearer <secret:token>"},
+		{name: "missing renders empty", src: "[{{ fields.nope }}]", expected: "[]"},
+		{name: "json helper", src: "{{ json fields.type }}", expected: `"verify"`},
+		{name: "escape helper", src: `"{{ escape message }}"`, expected: `"Job ID:    v-3fb332a6-ba43\nDatastore: store1\n\nVerification of \"vm/100\" successful.\n"`},
+		{name: "url-encode helper", src: "q={{ url-encode title }}", expected: "q=Verify%20Datastore%20%27store1%27%20successful"},
+		{name: "triple stash", src: "{{{ severity }}}", expected: "info"},
+		{name: "comments", src: "a{{! short }}b{{!-- long }} --}}c", expected: "abc"},
+		{name: "escaped mustache", src: `\{{ title }}`, expected: "{{ title }}"},
+		{name: "if else", src: "{{#if fields.datastore}}yes{{else}}no{{/if}}", expected: "yes"},
+		{name: "unless", src: "{{#unless fields.nope}}missing{{/unless}}", expected: "missing"},
+		{name: "else if chain", src: `{{#if (eq severity "error")}}E{{else if (eq severity "info")}}I{{else}}O{{/if}}`, expected: "I"},
+		{name: "each map", src: "{{#each fields}}{{@key}}={{this}};{{/each}}", expected: "datastore=store1;hostname=pbs;job-id=v-3fb332a6-ba43;type=verify;"},
+		{name: "each parent", src: "{{#each fields}}{{#if @first}}{{../severity}}{{/if}}{{/each}}", expected: "info"},
+		{name: "with", src: "{{#with fields}}{{hostname}}{{/with}}", expected: "pbs"},
+		{name: "whitespace control", src: "a  {{~ severity ~}}  b", expected: "ainfob"},
+		{name: "comparison", src: `{{#if (gt timestamp 0)}}ok{{/if}}`, expected: "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.src)
+			if err != nil {
+				t.Fatalf("ParseTemplate(%q) error = %v", tt.src, err)
+			}
+			got, err := tmpl.Render(data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Render() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "unclosed tag", src: "{{ title"},
+		{name: "unclosed block", src: "{{#if title}}x"},
+		{name: "mismatched close", src: "{{#if title}}x{{/each}}"},
+		{name: "stray close", src: "{{/if}}"},
+		{name: "stray else", src: "a{{else}}b"},
+		{name: "unknown helper", src: "{{ upper title }}"},
+		{name: "unknown block helper", src: "{{#repeat title}}{{/repeat}}"},
+		{name: "helper without argument", src: "{{ json }}"},
+		{name: "too many arguments", src: "{{ escape title message }}"},
+		{name: "partial", src: "{{> header }}"},
+		{name: "empty tag", src: "{{ }}"},
+		{name: "unterminated string", src: `{{#if (eq severity "info)}}{{/if}}`},
+		{name: "unbalanced subexpression", src: "{{#if (eq severity title}}{{/if}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTemplate(tt.src); err == nil {
+				t.Errorf("ParseTemplate(%q) expected error", tt.src)
+			}
+		})
+	}
+}
+
+func TestTemplateValidate(t *testing.T) {
+	tmpl, err := ParseTemplate("{{ secrets.token }} {{#each fields}}{{this}} {{@root.secrets.other}}{{/each}}")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+
+	if got := strings.Join(tmpl.SecretReferences(), ","); got != "other,token" {
+		t.Errorf("SecretReferences() = %q, want %q", got, "other,token")
+	}
+	if err := tmpl.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+	if got := tmpl.UnmanagedSecrets([]string{"token", "other"}); len(got) != 0 {
+		t.Errorf("UnmanagedSecrets() = %v, want none", got)
+	}
+	if got := strings.Join(tmpl.UnmanagedSecrets([]string{"token"}), ","); got != "other" {
+		t.Errorf("UnmanagedSecrets() = %q, want %q", got, "other")
+	}
+
+	typo, err := ParseTemplate("{{ tittle }} {{#each fields}}{{hostname}}{{/each}}")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+	if err := typo.Validate(); err == nil || !strings.Contains(err.Error(), "tittle") {
+		t.Errorf("Validate() error = %v, want unknown variable tittle", err)
+	}
+}
+
+func TestPreviewWebhook(t *testing.T) {
+	t.Run("valid json body", func(t *testing.T) {
+		target := &WebhookTarget{
+			URL:     "https://hooks.example.com/{{ secrets.path }}?sev={{ severity }}",
+			Body:    `{"text": "{{ escape title }}: {{ escape message }}", "fields": {{ json fields }}}`,
+			Headers: map[string]string{"Content-Type": "application/json", "Authorization": "Bearer {{ secrets.token }}"},
+		}
+		preview, problems := PreviewWebhook(target, []string{"path", "token"})
+		if len(problems) > 0 {
+			t.Fatalf("PreviewWebhook() problems = %+v", problems)
+		}
+		if preview.URL != "https://hooks.example.com/<secret:path>?sev=info" {
+			t.Errorf("URL = %q", preview.URL)
+		}
+		if preview.Headers["Authorization"] != "Bearer <secret:token>" {
+			t.Errorf("Authorization header = %q", preview.Headers["Authorization"])
+		}
+		if !json.Valid([]byte(preview.Body)) {
+			t.Errorf("Body is not valid JSON: %s", preview.Body)
+		}
+	})
+
+	t.Run("unescaped message breaks json", func(t *testing.T) {
+		target := &WebhookTarget{URL: "https://hooks.example.com", Body: `{"text": "{{ message }}"}`}
+		preview, problems := PreviewWebhook(target, nil)
+		if preview != nil || len(problems) != 1 || problems[0].Field != "body" {
+			t.Fatalf("PreviewWebhook() = %v, %+v; want one body problem", preview, problems)
+		}
+		if !strings.Contains(problems[0].Message, "escape") {
+			t.Errorf("problem should suggest the escape helper: %s", problems[0].Message)
+		}
+	})
+
+	t.Run("plain text body is not checked as json", func(t *testing.T) {
+		target := &WebhookTarget{URL: "https://hooks.example.com", Body: "{{ title }}\n{{ message }}"}
+		if _, problems := PreviewWebhook(target, nil); len(problems) > 0 {
+			t.Errorf("PreviewWebhook() problems = %+v", problems)
+		}
+	})
+
+	t.Run("problems per field", func(t *testing.T) {
+		target := &WebhookTarget{
+			URL:     "https://hooks.example.com/{{ secrets.missing }}",
+			Body:    "{{#if title}}",
+			Headers: map[string]string{"X-Title": "{{ upper title }}"},
+		}
+		_, problems := PreviewWebhook(target, nil)
+		if len(problems) != 3 {
+			t.Fatalf("PreviewWebhook() problems = %+v, want 3", problems)
+		}
+		if problems[0].Field != "url" || problems[1].Field != "body" || problems[2].Field != "headers" || problems[2].Key != "X-Title" {
+			t.Errorf("unexpected problems %+v", problems)
+		}
+		if !problems[0].Warning || problems[1].Warning || problems[2].Warning {
+			t.Errorf("only the unmanaged secret should be a warning: %+v", problems)
+		}
+	})
+
+	t.Run("unmanaged secret is a warning", func(t *testing.T) {
+		target := &WebhookTarget{
+			URL:     "https://hooks.example.com/{{ secrets.path }}",
+			Headers: map[string]string{"Authorization": "Bearer {{ secrets.manual }}"},
+		}
+		preview, problems := PreviewWebhook(target, []string{"path"})
+		if preview == nil {
+			t.Fatalf("PreviewWebhook() preview = nil, problems = %+v", problems)
+		}
+		if len(problems) != 1 || !problems[0].Warning || problems[0].Key != "Authorization" || !strings.Contains(problems[0].Message, "secrets.manual") {
+			t.Errorf("PreviewWebhook() problems = %+v, want one warning for secrets.manual", problems)
+		}
+		if preview.Headers["Authorization"] != "Bearer <secret:manual>" {
+			t.Errorf("Authorization header = %q", preview.Headers["Authorization"])
+		}
+	})
+}
@@ -0,0 +1,137 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package notifications
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WebhookSecret is a named secret of a webhook target, available to templates
+// as {{ secrets.<name> }}. PBS never returns secret values, so Value is nil for
+// secrets read from the API. A nil Value in an update keeps the stored value.
+type WebhookSecret struct {
+	Name  string
+	Value *string
+}
+
+// SecretNames returns the names of the target's secrets
+func (t *WebhookTarget) SecretNames() []string {
+	names := make([]string, len(t.Secrets))
+	for i, secret := range t.Secrets {
+		names[i] = secret.Name
+	}
+	return names
+}
+
+// UnmarshalJSON decodes the header and secret property strings returned by PBS
+func (t *WebhookTarget) UnmarshalJSON(data []byte) error {
+	type plain WebhookTarget
+	raw := struct {
+		*plain
+		Header []string `json:"header"`
+		Secret []string `json:"secret"`
+	}{plain: (*plain)(t)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	t.Headers = nil
+	if len(raw.Header) > 0 {
+		t.Headers = make(map[string]string, len(raw.Header))
+	}
+	for _, prop := range raw.Header {
+		name, value, err := ParseWebhookProperty(prop)
+		if err != nil {
+			return fmt.Errorf("invalid header %q: %w", prop, err)
+		}
+		if value == nil {
+			value = new(string)
+		}
+		t.Headers[name] = *value
+	}
+
+	t.Secrets = nil
+	for _, prop := range raw.Secret {
+		name, value, err := ParseWebhookProperty(prop)
+		if err != nil {
+			return fmt.Errorf("invalid secret %q: %w", prop, err)
+		}
+		t.Secrets = append(t.Secrets, WebhookSecret{Name: name, Value: value})
+	}
+
+	return nil
+}
+
+// FormatWebhookProperty formats a header or secret as the property string
+// "name=<name>,value=<base64 value>" expected by PBS. A nil value is omitted.
+func FormatWebhookProperty(name string, value *string) string {
+	if value == nil {
+		return "name=" + name
+	}
+	return "name=" + name + ",value=" + base64.StdEncoding.EncodeToString([]byte(*value))
+}
+
+// ParseWebhookProperty parses a header or secret property string. The value is
+// nil when the property has none, as for secrets returned by PBS.
+func ParseWebhookProperty(prop string) (string, *string, error) {
+	var name string
+	var value *string
+
+	for _, part := range strings.Split(prop, ",") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return "", nil, fmt.Errorf("expected key=value, got %q", part)
+		}
+		switch key {
+		case "name":
+			name = val
+		case "value":
+			decoded, err := base64.StdEncoding.DecodeString(val)
+			if err != nil {
+				return "", nil, fmt.Errorf("value is not base64 encoded: %w", err)
+			}
+			s := string(decoded)
+			value = &s
+		default:
+			return "", nil, fmt.Errorf("unknown key %q", key)
+		}
+	}
+
+	if name == "" {
+		return "", nil, fmt.Errorf("missing name")
+	}
+	return name, value, nil
+}
+
+// encodeWebhookHeaders formats headers as property strings sorted by name
+func encodeWebhookHeaders(headers map[string]string) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	props := make([]string, len(names))
+	for i, name := range names {
+		value := headers[name]
+		props[i] = FormatWebhookProperty(name, &value)
+	}
+	return props
+}
+
+func encodeWebhookSecrets(secrets []WebhookSecret) []string {
+	props := make([]string, len(secrets))
+	for i, secret := range secrets {
+		props[i] = FormatWebhookProperty(secret.Name, secret.Value)
+	}
+	return props
+}
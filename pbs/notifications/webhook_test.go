@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWebhookProperty(t *testing.T) {
+	value := "Bearer abc,def=="
+	prop := FormatWebhookProperty("Authorization", &value)
+	if prop != "name=Authorization,value=QmVhcmVyIGFiYyxkZWY9PQ==" {
+		t.Fatalf("FormatWebhookProperty() = %q", prop)
+	}
+
+	name, got, err := ParseWebhookProperty(prop)
+	if err != nil {
+		t.Fatalf("ParseWebhookProperty() error = %v", err)
+	}
+	if name != "Authorization" || got == nil || *got != value {
+		t.Errorf("ParseWebhookProperty() = %q, %v", name, got)
+	}
+
+	if prop := FormatWebhookProperty("token", nil); prop != "name=token" {
+		t.Errorf("FormatWebhookProperty() without value = %q", prop)
+	}
+	name, got, err = ParseWebhookProperty("name=token")
+	if err != nil || name != "token" || got != nil {
+		t.Errorf("ParseWebhookProperty() without value = %q, %v, %v", name, got, err)
+	}
+
+	for _, bad := range []string{"value=YQ==", "name=x,value=%%%", "name=x,other=y", "garbage"} {
+		if _, _, err := ParseWebhookProperty(bad); err == nil {
+			t.Errorf("ParseWebhookProperty(%q) expected error", bad)
+		}
+	}
+}
+
+func TestWebhookTargetUnmarshal(t *testing.T) {
+	data := `{
+		"name": "slack",
+		"url": "https://hooks.example.com/{{ secrets.path }}",
+		"method": "post",
+		"header": ["name=Content-Type,value=YXBwbGljYXRpb24vanNvbg==", "name=X-Empty"],
+		"secret": ["name=path", "name=token"]
+	}`
+
+	var target WebhookTarget
+	if err := json.Unmarshal([]byte(data), &target); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	expectedHeaders := map[string]string{"Content-Type": "application/json", "X-Empty": ""}
+	if !reflect.DeepEqual(target.Headers, expectedHeaders) {
+		t.Errorf("Headers = %v, want %v", target.Headers, expectedHeaders)
+	}
+	if !reflect.DeepEqual(target.SecretNames(), []string{"path", "token"}) {
+		t.Errorf("SecretNames() = %v", target.SecretNames())
+	}
+	if target.Secrets[0].Value != nil {
+		t.Errorf("secret values should not be set")
+	}
+	if target.Name != "slack" || target.Method != "post" {
+		t.Errorf("plain fields not decoded: %+v", target)
+	}
+}
+
+func TestEncodeWebhookLists(t *testing.T) {
+	headers := encodeWebhookHeaders(map[string]string{"X-B": "b", "X-A": "a"})
+	if !reflect.DeepEqual(headers, []string{"name=X-A,value=YQ==", "name=X-B,value=Yg=="}) {
+		t.Errorf("encodeWebhookHeaders() = %v", headers)
+	}
+
+	value := "s3cret"
+	secrets := encodeWebhookSecrets([]WebhookSecret{{Name: "keep"}, {Name: "token", Value: &value}})
+	if !reflect.DeepEqual(secrets, []string{"name=keep", "name=token,value=czNjcmV0"}) {
+		t.Errorf("encodeWebhookSecrets() = %v", secrets)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package subscription provides API client functionality for PBS subscription management
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/micah/terraform-provider-pbs/pbs/api"
+)
+
+// KeyRegex matches the PBS subscription key format, e.g. pbsc-1234abcdef
+var KeyRegex = regexp.MustCompile(`^pbs([cbsp])-[0-9a-f]{10}$`)
+
+// levels maps the subscription key level character to its name
+var levels = map[string]string{
+	"c": "community",
+	"b": "basic",
+	"s": "standard",
+	"p": "premium",
+}
+
+// Client represents the subscription API client
+type Client struct {
+	api *api.Client
+}
+
+// NewClient creates a new subscription API client
+func NewClient(apiClient *api.Client) *Client {
+	return &Client{api: apiClient}
+}
+
+// Info represents the subscription state of a node
+type Info struct {
+	Status      string `json:"status"` // new, notfound, active, invalid, expired, suspended
+	ServerID    string `json:"serverid,omitempty"`
+	CheckTime   *int64 `json:"checktime,omitempty"`
+	Key         string `json:"key,omitempty"`
+	Message     string `json:"message,omitempty"`
+	ProductName string `json:"productname,omitempty"`
+	RegDate     string `json:"regdate,omitempty"`
+	NextDueDate string `json:"nextduedate,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// Level returns the subscription level encoded in the key, or an empty string if unknown
+func (i *Info) Level() string {
+	return KeyLevel(i.Key)
+}
+
+// KeyLevel returns the subscription level encoded in a key, or an empty string if the key is malformed
+func KeyLevel(key string) string {
+	m := KeyRegex.FindStringSubmatch(strings.TrimSpace(key))
+	if m == nil {
+		return ""
+	}
+	return levels[m[1]]
+}
+
+// GetSubscription returns the subscription state of a node
+func (c *Client) GetSubscription(ctx context.Context, node string) (*Info, error) {
+	if node == "" {
+		return nil, fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/subscription", url.PathEscape(node))
+	resp, err := c.api.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription on node %s: %w", node, err)
+	}
+
+	var info Info
+	if err := json.Unmarshal(resp.Data, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscription on node %s: %w", node, err)
+	}
+
+	return &info, nil
+}
+
+// SetSubscription sets the subscription key of a node
+func (c *Client) SetSubscription(ctx context.Context, node, key string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+	if key == "" {
+		return fmt.Errorf("subscription key is required")
+	}
+
+	body := map[string]interface{}{
+		"key": strings.TrimSpace(key),
+	}
+
+	path := fmt.Sprintf("/nodes/%s/subscription", url.PathEscape(node))
+	if _, err := c.api.Put(ctx, path, body); err != nil {
+		return fmt.Errorf("failed to set subscription key on node %s: %w", node, err)
+	}
+
+	return nil
+}
+
+// CheckSubscription asks the node to re-validate its subscription with the shop server
+func (c *Client) CheckSubscription(ctx context.Context, node string, force bool) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+
+	body := map[string]interface{}{}
+	if force {
+		body["force"] = true
+	}
+
+	path := fmt.Sprintf("/nodes/%s/subscription", url.PathEscape(node))
+	if _, err := c.api.Post(ctx, path, body); err != nil {
+		return fmt.Errorf("failed to check subscription on node %s: %w", node, err)
+	}
+
+	return nil
+}
+
+// DeleteSubscription removes the subscription key from a node
+func (c *Client) DeleteSubscription(ctx context.Context, node string) error {
+	if node == "" {
+		return fmt.Errorf("node is required")
+	}
+
+	path := fmt.Sprintf("/nodes/%s/subscription", url.PathEscape(node))
+	if _, err := c.api.Delete(ctx, path); err != nil {
+		return fmt.Errorf("failed to delete subscription on node %s: %w", node, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package subscription
+
+import (
+	"testing"
+)
+
+func TestKeyLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{name: "community", key: "pbsc-0123456789", expected: "community"},
+		{name: "basic", key: "pbsb-abcdef0123", expected: "basic"},
+		{name: "standard", key: "pbss-0123456789", expected: "standard"},
+		{name: "premium with whitespace", key: " pbsp-0123456789\n", expected: "premium"},
+		{name: "pve key", key: "pve2c-0123456789", expected: ""},
+		{name: "unknown level", key: "pbsx-0123456789", expected: ""},
+		{name: "empty", key: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyLevel(tt.key); got != tt.expected {
+				t.Fatalf("KeyLevel(%q) = %q, want %q", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
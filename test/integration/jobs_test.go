@@ -364,8 +364,23 @@ resource "pbs_sync_job" "test_filter" {
   remote       = "%s"
   remote_store = "backup"
   schedule     = "daily"
-	group_filter = ["group:vm/node1", "group:ct/node2"]
   namespace    = "production"
+
+  group_filter {
+    kind  = "group"
+    value = "vm/node1"
+  }
+
+  group_filter {
+    kind  = "group"
+    value = "ct/node2"
+  }
+
+  group_filter {
+    action = "exclude"
+    kind   = "regex"
+    value  = "^ct/node2-old"
+  }
   comment      = "Sync job with filters"
 }
 `, jobID, datastoreName, remoteName)
@@ -380,8 +395,9 @@ resource "pbs_sync_job" "test_filter" {
 	jobsClient := jobs.NewClient(tc.APIClient)
 	job, err := jobsClient.GetSyncJob(context.Background(), jobID)
 	require.NoError(t, err)
-	assert.Len(t, job.GroupFilter, 2)
+	assert.Len(t, job.GroupFilter, 3)
 	assert.Contains(t, job.GroupFilter, "group:vm/node1")
 	assert.Contains(t, job.GroupFilter, "group:ct/node2")
+	assert.Contains(t, job.GroupFilter, "exclude:regex:^ct/node2-old")
 	assert.Equal(t, "production", job.Namespace)
 }
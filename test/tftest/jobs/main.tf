@@ -150,9 +150,13 @@ variable "burst_out" {
 }
 
 variable "group_filter" {
-  type        = list(string)
+  type = list(object({
+    action = optional(string)
+    kind   = string
+    value  = string
+  }))
   description = "Group filters"
-  default     = null
+  default     = []
 }
 
 variable "verified_only" {
@@ -230,10 +234,18 @@ resource "pbs_sync_job" "test" {
   rate_out         = var.rate_out
   burst_in         = var.burst_in
   burst_out        = var.burst_out
-  group_filter     = var.group_filter
   namespace        = var.namespace
   max_depth        = var.max_depth
   comment          = var.comment
+
+  dynamic "group_filter" {
+    for_each = var.group_filter
+    content {
+      action = group_filter.value.action
+      kind   = group_filter.value.kind
+      value  = group_filter.value.value
+    }
+  }
 }
 
 resource "pbs_verify_job" "test" {
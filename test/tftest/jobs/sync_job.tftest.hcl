@@ -161,25 +161,34 @@ run "sync_job_with_group_filter" {
     remote_store = "backup"
     schedule     = "daily"
     namespace    = "production"
-    group_filter = ["group:vm/node1", "group:ct/node2"]
-    comment      = "Sync job with filters"
+    group_filter = [
+      { kind = "group", value = "vm/node1" },
+      { kind = "group", value = "ct/node2" },
+      { action = "exclude", kind = "regex", value = "^ct/node2-old" },
+    ]
+    comment = "Sync job with filters"
   }
 
   assert {
-    condition     = length(pbs_sync_job.test[0].group_filter) == 2
+    condition     = length(pbs_sync_job.test[0].group_filter) == 3
     error_message = "group_filter length mismatch"
   }
 
   assert {
-    condition     = contains(pbs_sync_job.test[0].group_filter, "group:vm/node1")
+    condition     = pbs_sync_job.test[0].group_filter[0].action == "include" && pbs_sync_job.test[0].group_filter[0].value == "vm/node1"
     error_message = "group_filter missing vm/node1"
   }
 
   assert {
-    condition     = contains(pbs_sync_job.test[0].group_filter, "group:ct/node2")
+    condition     = pbs_sync_job.test[0].group_filter[1].kind == "group" && pbs_sync_job.test[0].group_filter[1].value == "ct/node2"
     error_message = "group_filter missing ct/node2"
   }
 
+  assert {
+    condition     = pbs_sync_job.test[0].group_filter[2].action == "exclude"
+    error_message = "group_filter exclude action mismatch"
+  }
+
   assert {
     condition     = pbs_sync_job.test[0].namespace == "production"
     error_message = "namespace mismatch"